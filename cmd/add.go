@@ -26,8 +26,9 @@ var addCmd = &cobra.Command{
 
 The command creates:
   - A new problem entry in the database
-  - A boilerplate Go file at problems/<slug>.go
-  - A test file at problems/<slug>_test.go
+  - A boilerplate Go file at problems/<name>.go
+  - A test file at problems/<name>_test.go
+where <name> is the slug in snake_case, e.g. two_sum.
 
 Examples:
   dsa add "Two Sum" --difficulty easy --topic arrays
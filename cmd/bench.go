@@ -4,18 +4,30 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/ak95asb/dsa-dojo/internal/benchmarking"
 	"github.com/ak95asb/dsa-dojo/internal/database"
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/profiling"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 var (
-	benchSave       bool
-	benchMem        bool
-	benchCPUProfile string
-	benchMemProfile string
+	benchSave             bool
+	benchMem              bool
+	benchCPUProfile       string
+	benchMemProfile       string
+	benchCount            int
+	benchAlpha            float64
+	benchFailOnRegression float64
+	benchCrossEnv         bool
+	benchProfileTop       int
+	benchFlameGraph       string
+	benchVariant          string
 )
 
 var benchCmd = &cobra.Command{
@@ -25,15 +37,37 @@ var benchCmd = &cobra.Command{
 
 The command:
   - Runs go test -bench on the problem's test file
-  - Shows iterations, time per operation, allocations, memory
-  - Optionally saves results and compares with previous best
-  - Supports memory and CPU profiling
+  - Parses every benchmark and sub-benchmark (e.g. BenchmarkTwoSum/size=100)
+  - Shows iterations, time per operation, allocations, memory and custom metrics
+  - Runs each benchmark several times (--count) and reports medians
+  - Optionally saves results and compares with the most recent saved run
+    using a Mann-Whitney U test, reporting "~" when a change is not
+    significant; with fewer than 4 samples on either side, e.g. against
+    results saved before samples were kept, the test is skipped with a
+    warning and changes are compared with the threshold alone
+  - Reports a new personal best when a saved run is significantly faster
+    than the best saved run
+  - Optionally fails when time/op regresses (--fail-on-regression)
+  - Saved results link to the matching submission; see 'dsa bench history'
+  - Records the environment (Go version, OS/arch, CPU, GOMAXPROCS, host)
+    and only compares results from the same environment unless --cross-env
+  - Supports memory and CPU profiling, summarizing the profiles: top
+    functions by flat/cum cost, allocation sites, and per-line costs
+    of the solution file
+  - Optionally renders a self-contained SVG flame graph (--flamegraph)
+  - Benchmarks a named solution variant with --variant; saved results are
+    only compared with earlier results of the same variant
 
 Examples:
   dsa bench two-sum
   dsa bench two-sum --save
+  dsa bench two-sum --count 10 --save
+  dsa bench two-sum --variant hashmap --save
+  dsa bench two-sum --fail-on-regression 5
   dsa bench two-sum --mem
-  dsa bench two-sum --cpuprofile=two-sum.cpu.prof`,
+  dsa bench two-sum --cpuprofile=two-sum.cpu.prof
+  dsa bench two-sum --cpuprofile=two-sum.cpu.prof --top 20
+  dsa bench two-sum --flamegraph=two-sum.svg`,
 	Args: cobra.ExactArgs(1),
 	Run:  runBenchCommand,
 }
@@ -44,11 +78,34 @@ func init() {
 	benchCmd.Flags().BoolVar(&benchMem, "mem", false, "Enable memory profiling")
 	benchCmd.Flags().StringVar(&benchCPUProfile, "cpuprofile", "", "Write CPU profile to file")
 	benchCmd.Flags().StringVar(&benchMemProfile, "memprofile", "", "Write memory profile to file")
+	benchCmd.Flags().IntVar(&benchCount, "count", 5, "Run each benchmark N times")
+	benchCmd.Flags().Float64Var(&benchAlpha, "alpha", benchmarking.DefaultAlpha, "Significance level for comparisons (p-value threshold)")
+	benchCmd.Flags().BoolVar(&benchCrossEnv, "cross-env", false, "Compare with results recorded in a different environment")
+	benchCmd.Flags().IntVar(&benchProfileTop, "top", profiling.DefaultTop, "Number of functions and allocation sites to show from profiles")
+	benchCmd.Flags().StringVar(&benchFlameGraph, "flamegraph", "", "Write an SVG flame graph of the CPU profile to file")
+	benchCmd.Flags().StringVar(&benchVariant, "variant", "", "Benchmark a named solution variant (see 'dsa solve --variant')")
+	benchCmd.Flags().Float64Var(&benchFailOnRegression, "fail-on-regression", 0, "Exit with an error if time/op regresses significantly by more than this percent")
 }
 
 func runBenchCommand(cmd *cobra.Command, args []string) {
 	slug := args[0]
 
+	// Validate flags before doing any work
+	if benchCount < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --count must be at least 1\n")
+		os.Exit(2) // ExitUsageError
+	}
+	if benchAlpha <= 0 || benchAlpha >= 1 {
+		fmt.Fprintf(os.Stderr, "Error: --alpha must be between 0 and 1\n")
+		os.Exit(2) // ExitUsageError
+	}
+	failOnRegression := cmd.Flags().Changed("fail-on-regression")
+	if failOnRegression && benchFailOnRegression < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --fail-on-regression must not be negative\n")
+		os.Exit(2) // ExitUsageError
+	}
+	variant := parseVariantFlag(benchVariant)
+
 	// Initialize database
 	db, err := database.Initialize()
 	if err != nil {
@@ -72,66 +129,189 @@ func runBenchCommand(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	requireVariantFile(slug, variant)
+
 	// Create benchmarking components
 	executor := benchmarking.NewExecutor()
 	formatter := benchmarking.NewFormatter()
 	storage := benchmarking.NewStorage(db)
 	comparator := benchmarking.NewComparator()
+	comparator.Alpha = benchAlpha
+
+	// Profiles needed for analysis but not requested as files go to a temp dir
+	// (removed explicitly, since os.Exit skips deferred calls)
+	cpuProfile, memProfile := benchCPUProfile, benchMemProfile
+	var tempDir string
+	if (benchFlameGraph != "" && cpuProfile == "") || (benchMem && memProfile == "") {
+		tempDir, err = os.MkdirTemp("", "dsa-bench-profile-")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to create profile directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		if benchFlameGraph != "" && cpuProfile == "" {
+			cpuProfile = filepath.Join(tempDir, "cpu.prof")
+		}
+		if benchMem && memProfile == "" {
+			memProfile = filepath.Join(tempDir, "mem.prof")
+		}
+	}
 
 	// Build execution options
 	opts := benchmarking.ExecuteOptions{
+		Count:          benchCount,
 		MemProfile:     benchMem || benchMemProfile != "",
-		CPUProfile:     benchCPUProfile,
-		MemProfilePath: benchMemProfile,
+		CPUProfile:     cpuProfile,
+		MemProfilePath: memProfile,
+		Variant:        variant,
 	}
 
 	// Execute benchmarks
-	fmt.Printf("Running benchmarks for %s...\n\n", slug)
-	result, err := executor.Execute(prob, opts)
+	fmt.Printf("Running benchmarks for %s%s...\n\n", slug, variantSuffix(variant))
+	run, err := executor.Execute(prob, opts)
 	if err != nil {
+		removeTempDir(tempDir)
 		fmt.Fprintf(os.Stderr, "Error running benchmarks: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Display raw benchmark output
-	fmt.Println(result.RawOutput)
+	fmt.Println(run.RawOutput)
 
 	// Display formatted results
-	fmt.Print(formatter.FormatResult(result))
+	fmt.Print(formatter.FormatResults(run.Results))
+	fmt.Printf("\nEnvironment: %s\n", run.Environment)
 
-	// Handle comparison if save flag is set or if previous benchmarks exist
-	if benchSave {
-		// Get previous best for comparison
-		previousBest, err := storage.GetBestBenchmark(prob.ID)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to retrieve previous benchmarks: %v\n", err)
+	// Compare with previous results when saving or gating on regressions
+	var regressions []string
+	if benchSave || failOnRegression {
+		// Compare each benchmark with the most recent saved run of the same name
+		for _, result := range run.Results {
+			previous, mismatches, err := storage.FindBaseline(prob.ID, result.BenchmarkName, variant, run.Environment, benchCrossEnv)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to retrieve previous benchmarks: %v\n", err)
+			}
+
+			if len(mismatches) > 0 {
+				fmt.Print(formatter.FormatEnvironmentWarning(result.BenchmarkName, mismatches, previous != nil))
+				if previous == nil {
+					continue
+				}
+			}
+
+			// Only a saved run can become the personal best, by being
+			// significantly faster than the best saved run
+			comparison := comparator.Compare(result, previous)
+			if !benchSave {
+				comparison.IsNewBest = false
+			} else if previous != nil {
+				best, err := storage.FindBest(prob.ID, result.BenchmarkName, variant, run.Environment, benchCrossEnv)
+				comparison.IsNewBest = err == nil && best != nil && comparator.Compare(result, best).IsNewBest
+			}
+			fmt.Print(formatter.FormatComparison(comparison))
+
+			if failOnRegression && previous != nil && comparison.IsRegression(benchFailOnRegression) {
+				regressions = append(regressions, fmt.Sprintf("%s (+%.1f%%)", result.BenchmarkName, comparison.TimeDeltaPercent))
+			}
 		}
+	}
 
-		// Compare results
-		comparison := comparator.Compare(result, previousBest)
-		fmt.Print(formatter.FormatComparison(comparison))
+	if benchSave {
+		// Link results to the submission whose code is currently in the solution file
+		var solutionID *uint
+		if code, err := os.ReadFile(solution.VariantPath(slug, variant)); err == nil {
+			if record, err := solution.NewService(db).FindSubmissionByCode(prob.ID, string(code)); err == nil {
+				solutionID = &record.ID
+			}
+		}
 
-		// Save current result
-		if err := storage.SaveBenchmark(prob.ID, result); err != nil {
+		// Save current results
+		if err := storage.SaveRun(prob.ID, solutionID, run); err != nil {
+			removeTempDir(tempDir)
 			fmt.Fprintf(os.Stderr, "Error saving benchmark: %v\n", err)
 			os.Exit(1)
 		}
 
-		fmt.Println("\n✓ Benchmark results saved")
+		fmt.Printf("\n✓ Benchmark results saved (%d benchmarks)\n", len(run.Results))
+		if solutionID == nil {
+			fmt.Printf("  Not linked to a submission: the solution differs from every submission of %s\n", slug)
+		}
 	}
 
-	// Display profiling messages
+	// Summarize profiles
+	if cpuProfile != "" {
+		report := analyzeProfile(cpuProfile, slug, variant)
+		if report != nil && benchFlameGraph != "" {
+			if err := report.SaveFlameGraph(benchFlameGraph, fmt.Sprintf("CPU flame graph: %s", slug)); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			} else {
+				fmt.Printf("\n✓ Flame graph saved to %s (open in a browser)\n", benchFlameGraph)
+			}
+		}
+	}
+	if memProfile != "" {
+		analyzeProfile(memProfile, slug, variant)
+	}
+	removeTempDir(tempDir)
+
 	if benchCPUProfile != "" {
 		fmt.Printf("\nCPU profile saved to %s\n", benchCPUProfile)
-		fmt.Printf("View with: go tool pprof %s\n", benchCPUProfile)
-		fmt.Printf("Analyze with: go tool pprof -http=:8080 %s\n", benchCPUProfile)
+		fmt.Printf("Explore further with: go tool pprof -http=:8080 %s\n", benchCPUProfile)
 	}
 
 	if benchMemProfile != "" {
 		fmt.Printf("\nMemory profile saved to %s\n", benchMemProfile)
-		fmt.Printf("View with: go tool pprof %s\n", benchMemProfile)
-		fmt.Printf("Analyze with: go tool pprof -http=:8080 %s\n", benchMemProfile)
+		fmt.Printf("Explore further with: go tool pprof -http=:8080 %s\n", benchMemProfile)
+	}
+
+	if len(regressions) > 0 {
+		fmt.Fprintf(os.Stderr, "\nError: time/op regressed by more than %.1f%%: %s\n", benchFailOnRegression, strings.Join(regressions, ", "))
+		os.Exit(1)
 	}
 
 	os.Exit(0)
 }
+
+// analyzeProfile prints the top functions, allocation sites and per-line
+// costs of the solution file for a profile. Returns nil if it can't be parsed.
+func analyzeProfile(path, slug, variant string) *profiling.Report {
+	analyzer := profiling.NewAnalyzer()
+	formatter := profiling.NewFormatter()
+
+	report, err := analyzer.Analyze(path, benchProfileTop)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to analyze profile: %v\n", err)
+		return nil
+	}
+
+	fmt.Print(formatter.FormatReport(report))
+
+	// Solutions are benchmarked from a staged copy named solution.go; without
+	// one the boilerplate is annotated instead
+	sources := []string{solution.VariantPath(slug, variant)}
+	aliases := []string{"solution.go"}
+	if _, err := os.Stat(sources[0]); err != nil && variant == "" {
+		sources = []string{workspace.ProblemLayout(slug).ReferenceBoilerplate()}
+		aliases = nil
+	}
+	for _, source := range sources {
+		if _, err := os.Stat(source); err != nil {
+			continue
+		}
+		lines, err := report.Annotate(source, aliases...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+		fmt.Print(formatter.FormatAnnotation(report, source, lines))
+	}
+
+	return report
+}
+
+// removeTempDir removes a temporary directory if one was created
+func removeTempDir(dir string) {
+	if dir != "" {
+		os.RemoveAll(dir)
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ak95asb/dsa-dojo/internal/benchmarking"
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchCompareA         string
+	benchCompareB         string
+	benchCompareCount     int
+	benchCompareBenchTime string
+	benchCompareAlpha     float64
+	benchCompareVariant   string
+)
+
+var benchCompareCmd = &cobra.Command{
+	Use:   "compare [problem-id]",
+	Short: "Benchmark two solution versions head-to-head",
+	Long: `Run identical benchmarks on two versions of a solution and compare them
+side by side.
+
+The command:
+  - Takes each version from submission history (the index shown by
+    'dsa history <problem-id>', 1 = most recent) or the current solution file
+  - Stages each version with the problem's test files in the workspace, as
+    'dsa bench' does, so both run the problem's own benchmarks
+  - Compiles both once and runs them in alternating order for every round
+  - Reports time, memory and allocations per benchmark with a
+    Mann-Whitney U significance test (Δ is B relative to A)
+
+Test runs recorded without code cannot be compared. --count must be at
+least 4 so the significance test has enough samples.
+
+With --variant, history indexes count within that variant (as in
+'dsa history --variant') and 'current' is the variant's solution file.
+
+Examples:
+  dsa bench compare two-sum --a 2
+  dsa bench compare two-sum --a 3 --b 1
+  dsa bench compare two-sum --a 2 --b current --count 20
+  dsa bench compare two-sum --a 2 --benchtime 200ms
+  dsa bench compare two-sum --variant hashmap --a 2`,
+	Args: cobra.ExactArgs(1),
+	Run:  runBenchCompareCommand,
+}
+
+func init() {
+	benchCmd.AddCommand(benchCompareCmd)
+	benchCompareCmd.Flags().StringVar(&benchCompareA, "a", "", "Baseline version: history index or 'current'")
+	benchCompareCmd.Flags().StringVar(&benchCompareB, "b", "current", "Candidate version: history index or 'current'")
+	benchCompareCmd.Flags().IntVar(&benchCompareCount, "count", 10, "Number of interleaved rounds per version")
+	benchCompareCmd.Flags().StringVar(&benchCompareBenchTime, "benchtime", "100ms", "Benchmark duration per input size and round")
+	benchCompareCmd.Flags().Float64Var(&benchCompareAlpha, "alpha", benchmarking.DefaultAlpha, "Significance level for the Mann-Whitney U test")
+	benchCompareCmd.Flags().StringVar(&benchCompareVariant, "variant", "", "Compare versions of a named solution variant (see 'dsa solve --variant')")
+	benchCompareCmd.MarkFlagRequired("a")
+}
+
+func runBenchCompareCommand(cmd *cobra.Command, args []string) {
+	slug := args[0]
+
+	if benchCompareCount < benchmarking.MinSamples {
+		fmt.Fprintf(os.Stderr, "Error: --count must be at least %d for a significance test\n", benchmarking.MinSamples)
+		os.Exit(2) // ExitUsageError
+	}
+	if benchCompareAlpha <= 0 || benchCompareAlpha >= 1 {
+		fmt.Fprintf(os.Stderr, "Error: --alpha must be between 0 and 1\n")
+		os.Exit(2) // ExitUsageError
+	}
+	if benchCompareA == benchCompareB {
+		fmt.Fprintf(os.Stderr, "Error: --a and --b refer to the same version (%s)\n", benchCompareA)
+		os.Exit(2) // ExitUsageError
+	}
+
+	// Without --variant history indexes count across every variant
+	variant := parseVariantFlag(benchCompareVariant)
+	var variantFilter *string
+	if cmd.Flags().Changed("variant") {
+		variantFilter = &variant
+	}
+
+	// Initialize database
+	db, err := database.Initialize()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to connect to database: %v\n", err)
+		os.Exit(3) // ExitDatabaseError
+	}
+	defer func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}()
+
+	// Get problem by slug
+	problemSvc := problem.NewService(db)
+	prob, err := problemSvc.GetProblemBySlug(slug)
+	if err != nil {
+		if errors.Is(err, problem.ErrProblemNotFound) {
+			fmt.Fprintf(os.Stderr, "Problem '%s' not found. Run 'dsa list' to see available problems.\n", slug)
+			os.Exit(2) // ExitUsageError
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	requireVariantFile(slug, variant)
+
+	// Load both versions
+	history := problemHistory(solution.NewService(db), prob.ID, slug)
+	versionA, err := loadBenchVersion(history, variantFilter, slug, variant, benchCompareA)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --a: %v\n", err)
+		os.Exit(2) // ExitUsageError
+	}
+	versionB, err := loadBenchVersion(history, variantFilter, slug, variant, benchCompareB)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --b: %v\n", err)
+		os.Exit(2) // ExitUsageError
+	}
+
+	fmt.Printf("Comparing %s (A) against %s (B) for %s%s: %d rounds...\n",
+		versionA.Label, versionB.Label, slug, variantSuffix(variant), benchCompareCount)
+
+	executor := benchmarking.NewExecutor()
+	result, err := executor.HeadToHead(slug, versionA, versionB, benchmarking.HeadToHeadOptions{
+		Count:     benchCompareCount,
+		BenchTime: benchCompareBenchTime,
+		Alpha:     benchCompareAlpha,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Environment: %s\n", result.Environment.String())
+
+	formatter := benchmarking.NewFormatter()
+	fmt.Print(formatter.FormatHeadToHead(result))
+
+	os.Exit(0)
+}
+
+// loadBenchVersion resolves a version reference: "current" for the solution
+// file of the variant, or a 1-based submission history index
+func loadBenchVersion(history solution.History, variantFilter *string, slug, variant, ref string) (benchmarking.Version, error) {
+	if ref == "current" {
+		path := solution.VariantPath(slug, variant)
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return benchmarking.Version{}, fmt.Errorf("failed to read current solution %s: %w", path, err)
+		}
+		return benchmarking.Version{Label: "current", Code: string(code)}, nil
+	}
+
+	index, err := strconv.Atoi(ref)
+	if err != nil || index < 1 {
+		return benchmarking.Version{}, fmt.Errorf("invalid version '%s': use a history index (1 = most recent) or 'current'", ref)
+	}
+
+	record, err := history.Get(variantFilter, index)
+	if err != nil {
+		return benchmarking.Version{}, err
+	}
+	// Test runs are listed in history but record no code
+	if record.Code == "" {
+		return benchmarking.Version{}, fmt.Errorf("#%d is a test run without saved code; pick a submission from 'dsa history %s'", index, slug)
+	}
+	return benchmarking.Version{Label: fmt.Sprintf("#%d", index), Code: record.Code}, nil
+}
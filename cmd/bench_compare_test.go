@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchCompareCommandExists(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench", "compare"})
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "compare", cmd.Name())
+	assert.Equal(t, "bench", cmd.Parent().Name())
+}
+
+func TestBenchCompareCommand_Flags(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench", "compare"})
+	assert.NoError(t, err)
+
+	aFlag := cmd.Flags().Lookup("a")
+	assert.NotNil(t, aFlag)
+	assert.Equal(t, "", aFlag.DefValue)
+	assert.Equal(t, []string{"true"}, aFlag.Annotations["cobra_annotation_bash_completion_one_required_flag"])
+
+	bFlag := cmd.Flags().Lookup("b")
+	assert.NotNil(t, bFlag)
+	assert.Equal(t, "current", bFlag.DefValue)
+
+	countFlag := cmd.Flags().Lookup("count")
+	assert.NotNil(t, countFlag)
+	assert.Equal(t, "10", countFlag.DefValue)
+
+	assert.Nil(t, cmd.Flags().Lookup("sizes"), "the problem's benchmarks choose their sizes")
+	assert.NotNil(t, cmd.Flags().Lookup("benchtime"))
+	assert.NotNil(t, cmd.Flags().Lookup("alpha"))
+	assert.NotNil(t, cmd.Flags().Lookup("variant"))
+}
+
+func TestBenchCompareCommand_HelpText(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench", "compare"})
+	assert.NoError(t, err)
+
+	assert.Contains(t, cmd.Long, "problem's own benchmarks")
+	assert.Contains(t, cmd.Long, "--variant")
+	assert.Contains(t, cmd.Long, "Mann-Whitney")
+	assert.Contains(t, cmd.Long, "dsa bench compare two-sum --a 2")
+	assert.Contains(t, cmd.Long, "dsa bench compare two-sum --a 3 --b 1")
+}
+
+// fakeHistory serves fixed records, most recent first
+type fakeHistory struct {
+	records []solution.SubmissionRecord
+}
+
+func (h *fakeHistory) List(variant *string) ([]solution.SubmissionRecord, error) {
+	return h.records, nil
+}
+
+func (h *fakeHistory) Get(variant *string, index int) (*solution.SubmissionRecord, error) {
+	return &h.records[index-1], nil
+}
+
+func (h *fakeHistory) FindByCode(code string) (*solution.SubmissionRecord, error) {
+	return nil, solution.ErrNoSubmission
+}
+
+func (h *fakeHistory) Restore(record *solution.SubmissionRecord) error {
+	return nil
+}
+
+func TestLoadBenchVersion(t *testing.T) {
+	history := &fakeHistory{records: []solution.SubmissionRecord{
+		{Code: "package solutions\n"},
+		{}, // A test run
+	}}
+
+	t.Run("submission with code", func(t *testing.T) {
+		version, err := loadBenchVersion(history, nil, "two-sum", "", "1")
+		require.NoError(t, err)
+		assert.Equal(t, "#1", version.Label)
+		assert.Equal(t, "package solutions\n", version.Code)
+	})
+
+	t.Run("test runs without code are refused", func(t *testing.T) {
+		_, err := loadBenchVersion(history, nil, "two-sum", "", "2")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "#2 is a test run without saved code")
+	})
+
+	t.Run("current reads the variant's file", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		originalDir, _ := os.Getwd()
+		os.Chdir(tmpDir)
+		defer os.Chdir(originalDir)
+
+		path := solution.VariantPath("two-sum", "hashmap")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("// hashmap\n"), 0644))
+
+		version, err := loadBenchVersion(history, nil, "two-sum", "hashmap", "current")
+		require.NoError(t, err)
+		assert.Equal(t, "current", version.Label)
+		assert.Equal(t, "// hashmap\n", version.Code)
+	})
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ak95asb/dsa-dojo/internal/benchmarking"
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchHistoryFormat  string
+	benchHistoryCompact bool
+	benchHistoryName    string
+)
+
+var benchHistoryCmd = &cobra.Command{
+	Use:   "history [problem-id]",
+	Short: "Show saved benchmark results over time",
+	Long: `Display every saved benchmark result for a problem, oldest first.
+
+The command:
+  - Groups results by benchmark name (including sub-benchmarks)
+  - Draws sparklines for time/op, memory/op and allocs/op
+  - Links each result to the submission that was benchmarked
+    (use 'dsa history <problem-id> --show N' to view its code)
+  - Supports JSON and CSV output for plotting, including the environment
+    (Go version, OS/arch, CPU, GOMAXPROCS, host) of each result
+
+Results are saved with 'dsa bench <problem-id> --save'.
+
+Examples:
+  dsa bench history two-sum
+  dsa bench history two-sum --name "TwoSum/size=1000"
+  dsa bench history two-sum --format json
+  dsa bench history two-sum --format csv > two-sum-bench.csv`,
+	Args: cobra.ExactArgs(1),
+	Run:  runBenchHistoryCommand,
+}
+
+func init() {
+	benchCmd.AddCommand(benchHistoryCmd)
+	benchHistoryCmd.Flags().StringVar(&benchHistoryFormat, "format", "table", "Output format (table, json, csv)")
+	benchHistoryCmd.Flags().BoolVar(&benchHistoryCompact, "compact", false, "Compact JSON output (single line)")
+	benchHistoryCmd.Flags().StringVar(&benchHistoryName, "name", "", "Only show results for this benchmark name")
+}
+
+// BenchHistoryResponse represents JSON output for bench history command
+//
+// JSON Schema:
+//
+//	{
+//	  "problem_id": "two-sum",
+//	  "results": [ /* array of BenchHistoryPointJSON objects, oldest first */ ]
+//	}
+type BenchHistoryResponse struct {
+	ProblemID string                  `json:"problem_id"`
+	Results   []BenchHistoryPointJSON `json:"results"`
+}
+
+// BenchHistoryPointJSON represents one saved benchmark result in JSON format
+//
+// JSON Schema:
+//
+//	{
+//	  "date": "2025-01-15T14:30:00Z",  // RFC3339 format
+//	  "benchmark": "TwoSum/size=100",
+//	  "ns_per_op": 2100,
+//	  "bytes_per_op": 1024,
+//	  "allocs_per_op": 4,
+//	  "runs": 5,
+//	  "submission": 2,                 // optional, index for 'dsa history --show'
+//	  "submission_passed": true,       // optional
+//	  "go_version": "go1.25.3",
+//	  "goos": "linux",
+//	  "goarch": "amd64",
+//	  "cpu": "Intel(R) Core(TM) i7-9750H CPU @ 2.60GHz",
+//	  "gomaxprocs": 8,
+//	  "host_hash": "3f2a9c1b7d4e",
+//	  "solution_hash": "9b74c9897bac..."
+//	}
+type BenchHistoryPointJSON struct {
+	Date             string  `json:"date"`
+	Benchmark        string  `json:"benchmark"`
+	NsPerOp          float64 `json:"ns_per_op"`
+	BytesPerOp       float64 `json:"bytes_per_op"`
+	AllocsPerOp      float64 `json:"allocs_per_op"`
+	Runs             int     `json:"runs"`
+	Submission       int     `json:"submission,omitempty"`
+	SubmissionPassed *bool   `json:"submission_passed,omitempty"`
+	GoVersion        string  `json:"go_version"`
+	GOOS             string  `json:"goos"`
+	GOARCH           string  `json:"goarch"`
+	CPU              string  `json:"cpu"`
+	GOMAXPROCS       int     `json:"gomaxprocs"`
+	HostHash         string  `json:"host_hash"`
+	SolutionHash     string  `json:"solution_hash"`
+}
+
+func runBenchHistoryCommand(cmd *cobra.Command, args []string) {
+	slug := args[0]
+
+	// Validate format
+	if !isValidFormat(benchHistoryFormat) {
+		fmt.Fprintf(os.Stderr, "Error: Invalid format '%s'. Valid formats: table, json, csv\n", benchHistoryFormat)
+		os.Exit(2) // ExitUsageError
+	}
+
+	// Initialize database
+	db, err := database.Initialize()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to connect to database: %v\n", err)
+		os.Exit(3) // ExitDatabaseError
+	}
+	defer func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}()
+
+	// Get problem by slug
+	problemSvc := problem.NewService(db)
+	prob, err := problemSvc.GetProblemBySlug(slug)
+	if err != nil {
+		if errors.Is(err, problem.ErrProblemNotFound) {
+			fmt.Fprintf(os.Stderr, "Problem '%s' not found. Run 'dsa list' to see available problems.\n", slug)
+			os.Exit(2) // ExitUsageError
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Load benchmarks and the submissions they link to
+	storage := benchmarking.NewStorage(db)
+	benchmarks, err := storage.GetBenchmarkHistory(prob.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error retrieving benchmark history: %v\n", err)
+		os.Exit(1)
+	}
+
+	submissions, err := solution.NewService(db).GetHistory(prob.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error retrieving submission history: %v\n", err)
+		os.Exit(1)
+	}
+
+	points := benchmarking.BuildHistory(benchmarks, submissions)
+	if benchHistoryName != "" {
+		filtered := points[:0]
+		for _, point := range points {
+			if point.Name == benchHistoryName {
+				filtered = append(filtered, point)
+			}
+		}
+		points = filtered
+	}
+
+	switch benchHistoryFormat {
+	case "json":
+		response := BenchHistoryResponse{
+			ProblemID: slug,
+			Results:   make([]BenchHistoryPointJSON, len(points)),
+		}
+		for i, point := range points {
+			response.Results[i] = BenchHistoryPointJSON{
+				Date:         point.CreatedAt.Format(time.RFC3339),
+				Benchmark:    point.Name,
+				NsPerOp:      point.NsPerOp,
+				BytesPerOp:   point.BytesPerOp,
+				AllocsPerOp:  point.AllocsPerOp,
+				Runs:         point.Count,
+				Submission:   point.SubmissionIndex,
+				GoVersion:    point.GoVersion,
+				GOOS:         point.GOOS,
+				GOARCH:       point.GOARCH,
+				CPU:          point.CPUModel,
+				GOMAXPROCS:   point.GOMAXPROCS,
+				HostHash:     point.HostHash,
+				SolutionHash: point.SolutionHash,
+			}
+			if point.SubmissionIndex > 0 {
+				passed := point.SubmissionPassed
+				response.Results[i].SubmissionPassed = &passed
+			}
+		}
+		if err := outputJSON(response, benchHistoryCompact); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "csv":
+		headers := []string{"Date", "Benchmark", "NsPerOp", "BytesPerOp", "AllocsPerOp", "Runs", "Submission", "SubmissionPassed",
+			"GoVersion", "GOOS", "GOARCH", "CPU", "GOMAXPROCS", "HostHash", "SolutionHash"}
+		rows := make([][]string, len(points))
+		for i, point := range points {
+			submission, passed := "", ""
+			if point.SubmissionIndex > 0 {
+				submission = strconv.Itoa(point.SubmissionIndex)
+				passed = formatBoolCSV(point.SubmissionPassed)
+			}
+			rows[i] = []string{
+				point.CreatedAt.Format(time.RFC3339),
+				point.Name,
+				strconv.FormatFloat(point.NsPerOp, 'f', -1, 64),
+				strconv.FormatFloat(point.BytesPerOp, 'f', -1, 64),
+				strconv.FormatFloat(point.AllocsPerOp, 'f', -1, 64),
+				strconv.Itoa(point.Count),
+				submission,
+				passed,
+				point.GoVersion,
+				point.GOOS,
+				point.GOARCH,
+				point.CPUModel,
+				strconv.Itoa(point.GOMAXPROCS),
+				point.HostHash,
+				point.SolutionHash,
+			}
+		}
+		if err := writeCSV(headers, rows); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	default:
+		if len(points) == 0 {
+			fmt.Printf("No saved benchmark results for %s\n", slug)
+			fmt.Printf("\nSave results with: dsa bench %s --save\n", slug)
+			os.Exit(0)
+		}
+
+		fmt.Printf("Benchmark History for %s:\n", slug)
+		fmt.Print(benchmarking.NewFormatter().FormatHistory(points))
+		fmt.Printf("\nUse 'dsa history %s --show N' to view the code of submission #N\n", slug)
+	}
+
+	os.Exit(0)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBenchHistoryCommandExists(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench", "history"})
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "history", cmd.Name())
+	assert.Equal(t, "bench", cmd.Parent().Name())
+}
+
+func TestBenchHistoryCommand_Flags(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench", "history"})
+	assert.NoError(t, err)
+
+	formatFlag := cmd.Flags().Lookup("format")
+	assert.NotNil(t, formatFlag)
+	assert.Equal(t, "table", formatFlag.DefValue)
+
+	assert.NotNil(t, cmd.Flags().Lookup("compact"))
+	assert.NotNil(t, cmd.Flags().Lookup("name"))
+}
+
+func TestBenchHistoryCommand_HelpText(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench", "history"})
+	assert.NoError(t, err)
+
+	assert.Contains(t, cmd.Long, "sparklines")
+	assert.Contains(t, cmd.Long, "submission")
+	assert.Contains(t, cmd.Long, "dsa bench history two-sum --format json")
+	assert.Contains(t, cmd.Long, "dsa bench history two-sum --format csv")
+}
@@ -114,3 +114,49 @@ func TestBenchCommand_FlagDescriptions(t *testing.T) {
 	assert.NotNil(t, memProfileFlag)
 	assert.Contains(t, memProfileFlag.Usage, "memory profile")
 }
+
+func TestBenchCommand_StatisticsFlags(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench"})
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+
+	countFlag := cmd.Flags().Lookup("count")
+	assert.NotNil(t, countFlag)
+	assert.Equal(t, "5", countFlag.DefValue)
+
+	alphaFlag := cmd.Flags().Lookup("alpha")
+	assert.NotNil(t, alphaFlag)
+	assert.Equal(t, "0.05", alphaFlag.DefValue)
+
+	failFlag := cmd.Flags().Lookup("fail-on-regression")
+	assert.NotNil(t, failFlag)
+	assert.Contains(t, failFlag.Usage, "regresses")
+
+	assert.Contains(t, cmd.Long, "Mann-Whitney")
+}
+
+func TestBenchCommand_CrossEnvFlag(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench"})
+	assert.NoError(t, err)
+
+	flag := cmd.Flags().Lookup("cross-env")
+	assert.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+	assert.Contains(t, cmd.Long, "--cross-env")
+}
+
+func TestBenchCommand_ProfileAnalysisFlags(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"bench"})
+	assert.NoError(t, err)
+
+	topFlag := cmd.Flags().Lookup("top")
+	assert.NotNil(t, topFlag)
+	assert.Equal(t, "10", topFlag.DefValue)
+
+	flameFlag := cmd.Flags().Lookup("flamegraph")
+	assert.NotNil(t, flameFlag)
+	assert.Equal(t, "", flameFlag.DefValue)
+
+	assert.Contains(t, cmd.Long, "flame graph")
+	assert.Contains(t, cmd.Long, "dsa bench two-sum --flamegraph=two-sum.svg")
+}
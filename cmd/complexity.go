@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/complexity"
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	complexitySizes     []int
+	complexityBenchTime string
+	complexityTarget    string
+)
+
+var complexityCmd = &cobra.Command{
+	Use:   "complexity [problem-id]",
+	Short: "Estimate the empirical time complexity of your solution",
+	Long: `Benchmark your solution across a ladder of generated input sizes and
+estimate its time complexity.
+
+The command:
+  - Generates random inputs from your solution's function signature
+  - Runs a benchmark for every input size
+  - Fits timings against O(1), O(log n), O(n), O(n log n), O(n^2) and O(2^n)
+  - Reports the best-fit class with a confidence level
+  - Stores the result with your latest submission
+  - Warns when the measured class is worse than the problem's target
+
+Inputs are generated once per size, so solutions that modify their input
+in place see already-processed data after the first iteration. Use small
+sizes (e.g. --sizes 10,12,14,16,18,20) for exponential solutions.
+
+Examples:
+  dsa complexity two-sum
+  dsa complexity binary-search --sizes 1000,10000,100000,1000000
+  dsa complexity merge-intervals --benchtime 500ms
+  dsa complexity my-problem --target "O(n log n)"`,
+	Args: cobra.ExactArgs(1),
+	Run:  runComplexityCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(complexityCmd)
+	complexityCmd.Flags().IntSliceVar(&complexitySizes, "sizes", complexity.DefaultSizes, "Input sizes to benchmark")
+	complexityCmd.Flags().StringVar(&complexityBenchTime, "benchtime", "100ms", "Benchmark duration per input size")
+	complexityCmd.Flags().StringVar(&complexityTarget, "target", "", "Target complexity (overrides the problem's target)")
+}
+
+func runComplexityCommand(cmd *cobra.Command, args []string) {
+	slug := args[0]
+
+	// Validate target before doing any work
+	targetStr := complexityTarget
+	if targetStr != "" {
+		if _, err := complexity.ParseClass(targetStr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2) // ExitUsageError
+		}
+	}
+
+	// Initialize database
+	db, err := database.Initialize()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to connect to database: %v\n", err)
+		os.Exit(3) // ExitDatabaseError
+	}
+	defer func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}()
+
+	// Get problem by slug
+	problemSvc := problem.NewService(db)
+	prob, err := problemSvc.GetProblemBySlug(slug)
+	if err != nil {
+		if errors.Is(err, problem.ErrProblemNotFound) {
+			fmt.Fprintf(os.Stderr, "Problem '%s' not found. Run 'dsa list' to see available problems.\n", slug)
+			os.Exit(2) // ExitUsageError
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Check if solution file exists
+	solutionPath := workspace.ProblemLayout(slug).Solution()
+	if _, err := os.Stat(solutionPath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Solution file not found: %s\n", solutionPath)
+		fmt.Fprintf(os.Stderr, "Run 'dsa solve %s' to create a solution file.\n", slug)
+		os.Exit(1)
+	}
+
+	// Run estimation
+	estimator := complexity.NewEstimator()
+	formatter := complexity.NewFormatter()
+
+	fmt.Printf("Estimating complexity for %s across %d input sizes...\n", slug, len(complexitySizes))
+	result, err := estimator.Estimate(solutionPath, workspace.FunctionName(slug), complexity.Options{
+		Sizes:     complexitySizes,
+		BenchTime: complexityBenchTime,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error estimating complexity: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Print(formatter.FormatEstimate(result.Estimate))
+
+	// Compare against target complexity
+	if targetStr == "" {
+		targetStr = prob.TargetComplexity
+	}
+	if targetStr != "" {
+		target, err := complexity.ParseClass(targetStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Ignoring target complexity: %v\n", err)
+		} else {
+			fmt.Print(formatter.FormatTargetCheck(result.Estimate, target))
+		}
+	}
+
+	// Store the estimate with the latest submission
+	solutionSvc := solution.NewService(db)
+	record, err := solutionSvc.RecordComplexity(prob.ID, result.Class.String(), result.Confidence)
+	if err != nil {
+		if errors.Is(err, solution.ErrNoSubmission) {
+			fmt.Printf("\nNo submission to attach the estimate to. Run 'dsa submit %s' first.\n", slug)
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "Warning: Failed to store complexity estimate: %v\n", err)
+		os.Exit(0)
+	}
+
+	fmt.Printf("\n✓ Estimate saved with submission #%d (%s)\n", record.ID, record.CreatedAt.Format("2006-01-02 15:04:05"))
+	os.Exit(0)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplexityCommandExists(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"complexity"})
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "complexity", cmd.Name())
+}
+
+func TestComplexityCommand_Flags(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"complexity"})
+	assert.NoError(t, err)
+
+	for _, name := range []string{"sizes", "benchtime", "target"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "%s flag should exist", name)
+	}
+}
+
+func TestComplexityCommand_HelpText(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"complexity"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Estimate the empirical time complexity of your solution", cmd.Short)
+	assert.Contains(t, cmd.Long, "O(n log n)")
+	assert.Contains(t, cmd.Long, "dsa complexity two-sum")
+}
@@ -12,12 +12,16 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
+
+	editorpkg "github.com/ak95asb/dsa-dojo/internal/editor"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 )
 
 // Valid configuration keys
 var validConfigKeys = []string{
 	"editor",
 	"editor_args",
+	"editor_mode",
 	"output_format",
 	"no_color",
 	"database_path",
@@ -26,6 +30,7 @@ var validConfigKeys = []string{
 	"status_format",
 	"output_style",
 	"color_scheme",
+	"git_history",
 }
 
 var configCmd = &cobra.Command{
@@ -47,6 +52,11 @@ Editor Integration:
 
   Supported placeholders: {file}, {line}, {column}
 
+  Arguments are split like a shell would, so quote arguments that contain
+  spaces. editor_mode decides whether dsa waits for the editor to close:
+  auto (default) waits for terminal editors such as vim, nano and emacs and
+  returns at once for others; wait and background force either behavior.
+
   Popular editor examples:
     Vim:     dsa config set editor_args "+{line}"
     Neovim:  dsa config set editor_args "-c 'normal {line}G'"
@@ -74,27 +84,39 @@ var configSetCmd = &cobra.Command{
 	Short: "Set configuration value",
 	Long: `Update a configuration setting in the global config file.
 
-Valid keys: editor, editor_args, output_format, no_color, database_path, verbose,
-            list_format, status_format, output_style, color_scheme
+Valid keys: editor, editor_args, editor_mode, output_format, no_color,
+            database_path, verbose, list_format, status_format, output_style,
+            color_scheme, git_history
 
 Editor Integration:
   editor       - Editor command (e.g., vim, code, nvim, emacs)
   editor_args  - Arguments with placeholders: {file}, {line}, {column}
+  editor_mode  - auto, wait or background: whether to wait for the editor
+
+Git History:
+  git_history  - When true, solutions/ is a git repository: every submit
+                 commits the solution file and 'dsa history' reads git log
+
+Values are taken as they are, even when they start with a dash, so
+editor_args needs no "--" before it (one is accepted and ignored).
 
 Examples:
   dsa config set editor vim
   dsa config set editor_args "+{line}"
+  dsa config set editor_args "-c 'normal {line}G'"
   dsa config set editor code
   dsa config set editor_args "--goto {file}:{line}"
   dsa config set output_format json
   dsa config set no_color true
+  dsa config set git_history true
 
 Editor Fallback:
   If editor is not configured, the CLI will:
   1. Check $EDITOR environment variable
   2. Use OS-specific default (open on macOS, xdg-open on Linux, start on Windows)`,
-	Args: cobra.ExactArgs(2),
-	Run:  runConfigSet,
+	// Values such as editor_args start with a dash and are not flags
+	DisableFlagParsing: true,
+	Run:                runConfigSet,
 }
 
 var configListCmd = &cobra.Command{
@@ -219,8 +241,29 @@ func runConfigGet(cmd *cobra.Command, args []string) {
 	fmt.Printf("%s: %v\n", key, value)
 }
 
+// configSetArgs returns the key and value given to 'config set', dropping the
+// first "--", which flag parsing would otherwise have removed
+func configSetArgs(args []string) []string {
+	for i, arg := range args {
+		if arg == "--" {
+			return append(append([]string{}, args[:i]...), args[i+1:]...)
+		}
+	}
+	return args
+}
+
 // runConfigSet updates a configuration value
 func runConfigSet(cmd *cobra.Command, args []string) {
+	if len(args) == 1 && (args[0] == "-h" || args[0] == "--help") {
+		cmd.Help()
+		return
+	}
+	args = configSetArgs(args)
+	if len(args) != 2 {
+		fmt.Fprintf(os.Stderr, "Error: accepts 2 arg(s), received %d\n", len(args))
+		fmt.Fprint(os.Stderr, cmd.UsageString())
+		os.Exit(2)
+	}
 	key := args[0]
 	value := args[1]
 
@@ -509,7 +552,7 @@ func isValidConfigKey(key string) bool {
 
 func parseConfigValue(key, value string) (interface{}, error) {
 	switch key {
-	case "no_color", "verbose":
+	case "no_color", "verbose", "git_history":
 		// Boolean values
 		if value == "true" || value == "false" {
 			return value == "true", nil
@@ -545,6 +588,16 @@ func parseConfigValue(key, value string) (interface{}, error) {
 			}
 		}
 		return nil, fmt.Errorf("color_scheme must be one of: %s", strings.Join(validSchemes, ", "))
+	case "editor_mode":
+		// Enum values for editor launch modes
+		validModes := make([]string, len(editorpkg.Modes))
+		for i, mode := range editorpkg.Modes {
+			if value == string(mode) {
+				return value, nil
+			}
+			validModes[i] = string(mode)
+		}
+		return nil, fmt.Errorf("editor_mode must be one of: %s", strings.Join(validModes, ", "))
 	case "editor", "editor_args", "database_path":
 		// String values
 		return value, nil
@@ -561,7 +614,7 @@ func getConfigSource(key string) string {
 	}
 
 	// Check if in project config
-	projectConfigPath := filepath.Join(".dsa", "config.yaml")
+	projectConfigPath := workspace.Settings("config.yaml")
 	if data, err := os.ReadFile(projectConfigPath); err == nil {
 		var projectConfig map[string]interface{}
 		if yaml.Unmarshal(data, &projectConfig) == nil {
@@ -657,6 +710,7 @@ func getDefaultConfig() map[string]interface{} {
 
 	defaults["editor"] = defaultEditor
 	defaults["editor_args"] = ""
+	defaults["editor_mode"] = "auto"
 	defaults["output_format"] = "text"
 	defaults["no_color"] = false
 	defaults["verbose"] = false
@@ -664,6 +718,7 @@ func getDefaultConfig() map[string]interface{} {
 	defaults["status_format"] = "table"
 	defaults["output_style"] = "normal"
 	defaults["color_scheme"] = "default"
+	defaults["git_history"] = false
 
 	// database_path default
 	home, err := os.UserHomeDir()
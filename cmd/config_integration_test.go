@@ -1054,6 +1054,7 @@ func TestIntegration_ResetToDefaultsMatchesExpected(t *testing.T) {
 	assert.Equal(t, "table", defaults["status_format"])
 	assert.Equal(t, "normal", defaults["output_style"])
 	assert.Equal(t, "default", defaults["color_scheme"])
+	assert.Equal(t, false, defaults["git_history"])
 	assert.Equal(t, "", defaults["editor_args"])
 	assert.Equal(t, filepath.Join(tmpHome, ".dsa", "dsa.db"), defaults["database_path"])
 }
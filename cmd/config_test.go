@@ -19,10 +19,12 @@ func TestIsValidConfigKey(t *testing.T) {
 	}{
 		{"editor", true},
 		{"editor_args", true},
+		{"editor_mode", true},
 		{"output_format", true},
 		{"no_color", true},
 		{"database_path", true},
 		{"verbose", true},
+		{"git_history", true},
 		{"invalid_key", false},
 		{"", false},
 	}
@@ -52,6 +54,16 @@ func TestParseConfigValue(t *testing.T) {
 		assert.Contains(t, err.Error(), "must be 'true' or 'false'")
 	})
 
+	t.Run("editor_mode enum", func(t *testing.T) {
+		val, err := parseConfigValue("editor_mode", "background")
+		assert.NoError(t, err)
+		assert.Equal(t, "background", val)
+
+		_, err = parseConfigValue("editor_mode", "later")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "editor_mode must be one of: auto, wait, background")
+	})
+
 	t.Run("output_format enum", func(t *testing.T) {
 		// Valid values
 		val, err := parseConfigValue("output_format", "text")
@@ -198,6 +210,42 @@ func TestConfigSet_WritesFile(t *testing.T) {
 	assert.Equal(t, "vim", readConfig["editor"])
 }
 
+func TestConfigSetArgs(t *testing.T) {
+	assert.Equal(t, []string{"editor", "vim"}, configSetArgs([]string{"editor", "vim"}))
+	assert.Equal(t, []string{"editor_args", "-c 'normal {line}G'"},
+		configSetArgs([]string{"editor_args", "--", "-c 'normal {line}G'"}))
+	assert.Equal(t, []string{"editor_args", "--"}, configSetArgs([]string{"--", "editor_args", "--"}))
+}
+
+func TestConfigSet_DashValues(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"short flag lookalike", []string{"editor_args", "-c 'normal {line}G'"}, "-c 'normal {line}G'"},
+		{"long flag lookalike", []string{"editor_args", "--goto {file}:{line}"}, "--goto {file}:{line}"},
+		{"after separator", []string{"editor_args", "--", "-g {file}:{line}"}, "-g {file}:{line}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpHome := t.TempDir()
+			t.Setenv("HOME", tmpHome)
+
+			rootCmd.SetArgs(append([]string{"config", "set"}, tt.args...))
+			require.NoError(t, rootCmd.Execute())
+
+			data, err := os.ReadFile(filepath.Join(tmpHome, ".dsa", "config.yaml"))
+			require.NoError(t, err)
+
+			var readConfig map[string]interface{}
+			require.NoError(t, yaml.Unmarshal(data, &readConfig))
+			assert.Equal(t, tt.want, readConfig["editor_args"])
+		})
+	}
+}
+
 func TestConfigUnset_RemovesKey(t *testing.T) {
 	tmpHome := t.TempDir()
 	t.Setenv("HOME", tmpHome)
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the workspace layout and migrate old files",
+	Long: `Doctor checks that the workspace follows the problem file layout used by
+solve, test, bench and test-gen, and with --fix repairs what it can.
+
+Problem files live at:
+  solutions/<name>.go                      Solution
+  solutions/variants/<variant>/<name>.go   Named solution variants
+  problems/<name>.go                       Boilerplate
+  problems/<name>_test.go                  Tests
+  problems/<name>_bench_test.go            Benchmarks (optional)
+  problems/testdata/<name>/                Fixtures
+  problems/testdata/fuzz/Fuzz<Function>/   Fuzz corpus
+where <name> is the problem slug in snake_case, e.g. two_sum.
+
+Checks:
+  - The workspace has go.mod, solutions/, problems/ and the structures/
+    helper package
+  - No problem files are named after the hyphenated slug (e.g. two-sum.go),
+    as earlier versions wrote some of them; --fix moves them into place
+  - No staging directories were left behind by interrupted test runs
+
+A file is never overwritten: if both the old and new file exist with
+different content, doctor asks you to merge them by hand.
+
+Examples:
+  dsa doctor
+  dsa doctor --fix
+
+Exit Codes:
+  0 - Workspace is healthy (or every issue was fixed)
+  1 - Issues remain`,
+	Args: cobra.NoArgs,
+	Run:  runDoctorCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Repair the issues found")
+}
+
+func runDoctorCommand(cmd *cobra.Command, args []string) {
+	if root, err := workspace.Find("."); err == nil {
+		fmt.Printf("Workspace: %s\n", root)
+	} else {
+		fmt.Println("No .dsa-workspace found; checking the current directory. Run 'dsa init' to create a workspace.")
+	}
+
+	issues, err := workspace.Diagnose()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(issues) == 0 {
+		fmt.Println("✓ No issues found")
+		os.Exit(0)
+	}
+
+	remaining := 0
+	for _, issue := range issues {
+		switch {
+		case doctorFix && issue.Fix != nil:
+			if err := issue.Fix(); err != nil {
+				fmt.Printf("✗ %s: %v\n", issue.Description, err)
+				remaining++
+				continue
+			}
+			fmt.Printf("✓ Fixed: %s\n", issue.Description)
+		case issue.Fix != nil:
+			fmt.Printf("✗ %s (fixable)\n", issue.Description)
+			remaining++
+		default:
+			fmt.Printf("✗ %s\n", issue.Description)
+			fmt.Printf("    %s\n", issue.Hint)
+			remaining++
+		}
+	}
+
+	if remaining == 0 {
+		fmt.Printf("\n✓ Fixed %d issue(s)\n", len(issues))
+		os.Exit(0)
+	}
+	if !doctorFix {
+		fmt.Println("\nRun 'dsa doctor --fix' to repair fixable issues")
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoctorCommand(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"doctor"})
+	assert.NoError(t, err)
+	assert.Equal(t, "doctor", cmd.Name())
+	assert.NotNil(t, cmd.Flags().Lookup("fix"))
+	assert.Error(t, cmd.Args(cmd, []string{"extra"}))
+
+	// The help documents the layout
+	assert.Contains(t, cmd.Long, "problems/<name>_test.go")
+	assert.Contains(t, cmd.Long, "solutions/variants/<variant>/<name>.go")
+}
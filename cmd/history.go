@@ -5,33 +5,56 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
+	"github.com/ak95asb/dsa-dojo/internal/benchmarking"
 	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/output"
 	"github.com/ak95asb/dsa-dojo/internal/problem"
 	"github.com/ak95asb/dsa-dojo/internal/solution"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var (
 	historyShow    int
 	historyRestore int
+	historyVariant string
+	historyDiff    bool
 )
 
 var historyCmd = &cobra.Command{
-	Use:   "history [problem-id]",
+	Use:   "history [problem-id] [--diff A B]",
 	Short: "View solution submission history",
 	Long: `Display all solution attempts for a problem.
 
 Options:
-  --show N     Display solution code from Nth attempt (1 = most recent)
-  --restore N  Restore Nth attempt as current solution (1 = most recent)
+  --show N        Display solution code from Nth attempt (1 = most recent)
+  --restore N     Restore Nth attempt as current solution (1 = most recent)
+  --variant NAME  Only include one solution variant; N counts within it
+                  (use "default" for solutions/<problem-id>.go)
+  --diff A B      Show a unified diff from attempt A to attempt B, where A and
+                  B are attempt numbers or "current" for the solution file,
+                  followed by the change in test results and saved benchmarks
+
+Restored submissions are written back to the file of the variant they were
+submitted as.
+
+With git_history enabled (dsa config set git_history true) every submit is
+committed to the solutions/ repository, history is read from its git log and
+--restore checks the file out of the submission's commit. Submissions stored
+before git mode was turned on are listed with them, without a commit.
 
 Examples:
   dsa history two-sum
   dsa history two-sum --show 2
-  dsa history two-sum --restore 3`,
-	Args: cobra.ExactArgs(1),
+  dsa history two-sum --restore 3
+  dsa history two-sum --variant hashmap
+  dsa history two-sum --variant hashmap --show 1
+  dsa history two-sum --diff 3 1
+  dsa history two-sum --diff 2 current`,
+	Args: historyArgs,
 	Run:  runHistoryCommand,
 }
 
@@ -39,11 +62,39 @@ func init() {
 	rootCmd.AddCommand(historyCmd)
 	historyCmd.Flags().IntVar(&historyShow, "show", 0, "Display solution code from Nth attempt")
 	historyCmd.Flags().IntVar(&historyRestore, "restore", 0, "Restore Nth attempt as current solution")
+	historyCmd.Flags().StringVar(&historyVariant, "variant", "", "Only include submissions of this solution variant")
+	historyCmd.Flags().BoolVar(&historyDiff, "diff", false, "Diff two attempts given as arguments: A B (number or 'current')")
+}
+
+// historyArgs requires the problem ID, plus the two attempts to compare with --diff
+func historyArgs(cmd *cobra.Command, args []string) error {
+	if historyDiff {
+		if len(args) != 3 {
+			return fmt.Errorf("--diff requires two attempts to compare, e.g. 'dsa history %s --diff 2 current'", firstArg(args))
+		}
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// firstArg returns the first argument, or a placeholder when there is none
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return "<problem-id>"
+	}
+	return args[0]
 }
 
 func runHistoryCommand(cmd *cobra.Command, args []string) {
 	slug := args[0]
 
+	// An empty filter includes every variant; "default" selects the default solution
+	var variantFilter *string
+	if cmd.Flags().Changed("variant") {
+		variant := parseVariantFlag(historyVariant)
+		variantFilter = &variant
+	}
+
 	// Initialize database
 	db, err := database.Initialize()
 	if err != nil {
@@ -69,22 +120,37 @@ func runHistoryCommand(cmd *cobra.Command, args []string) {
 
 	// Create solution service
 	solutionSvc := solution.NewService(db)
+	history := problemHistory(solutionSvc, prob.ID, slug)
 
 	// Route based on flags
-	if historyShow > 0 {
-		showSolution(solutionSvc, prob.ID, slug, historyShow)
+	if historyDiff {
+		diffSolutions(history, benchmarking.NewStorage(db), prob.ID, slug, variantFilter, args[1], args[2])
+	} else if historyShow > 0 {
+		showSolution(history, slug, variantFilter, historyShow)
 	} else if historyRestore > 0 {
-		restoreSolution(solutionSvc, prob.ID, slug, historyRestore)
+		restoreSolution(solutionSvc, history, prob.ID, slug, variantFilter, historyRestore)
 	} else {
-		listHistory(solutionSvc, prob.ID, slug)
+		listHistory(history, slug, variantFilter)
 	}
 
 	os.Exit(0)
 }
 
+// problemHistory returns where the history of a problem is read from: the
+// commits of the solutions repository when git_history is enabled and the
+// repository exists, together with submissions stored before that, otherwise
+// the database
+func problemHistory(svc *solution.Service, problemID uint, slug string) solution.History {
+	history := svc.ProblemHistory(slug, problemID)
+	if repo := solution.SolutionsRepo(); viper.GetBool("git_history") && repo.Exists() {
+		return solution.MergeHistory(solution.NewGitHistory(repo, slug), history)
+	}
+	return history
+}
+
 // listHistory displays all submissions for a problem
-func listHistory(svc *solution.Service, problemID uint, slug string) {
-	records, err := svc.GetHistory(problemID)
+func listHistory(history solution.History, slug string, variantFilter *string) {
+	records, err := history.List(variantFilter)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error retrieving history: %v\n", err)
 		os.Exit(1)
@@ -96,10 +162,37 @@ func listHistory(svc *solution.Service, problemID uint, slug string) {
 		return
 	}
 
+	// Show the variant column only when variants are in use, and the commit
+	// column only for history read from git
+	showVariants := false
+	showCommits := false
+	for _, record := range records {
+		if record.Variant != "" {
+			showVariants = true
+		}
+		if record.Commit != "" {
+			showCommits = true
+		}
+	}
+
 	// Display header
-	fmt.Printf("Solution History for %s:\n\n", slug)
-	fmt.Printf(" #  Date & Time           Status    Tests\n")
-	fmt.Printf("==  ====================  ========  =====\n")
+	if variantFilter != nil {
+		fmt.Printf("Solution History for %s (variant: %s):\n\n", slug, solution.VariantLabel(*variantFilter))
+	} else {
+		fmt.Printf("Solution History for %s:\n\n", slug)
+	}
+	header := " #  Date & Time           Status    Tests"
+	rule := "==  ====================  ========  ====="
+	if showCommits {
+		header += "  Commit"
+		rule += "  ======="
+	}
+	if showVariants {
+		header += "  Variant"
+		rule += "  ============"
+	}
+	fmt.Println(header)
+	fmt.Println(rule)
 
 	// Display submissions
 	for i, record := range records {
@@ -113,19 +206,35 @@ func listHistory(svc *solution.Service, problemID uint, slug string) {
 			status = "✗ Failed"
 		}
 
-		// Note: We don't store test counts in database.Solution model
-		// For this display, we'll show passed/failed status only
-		fmt.Printf("%2d  %s  %-8s  -\n", index, dateTime, status)
+		// Test counts are only recorded for newer submissions
+		tests := "-"
+		if record.TestsTotal > 0 {
+			tests = fmt.Sprintf("%d/%d", record.TestsPassed, record.TestsTotal)
+		}
+
+		line := fmt.Sprintf("%2d  %s  %-8s  %-5s", index, dateTime, status, tests)
+		if showCommits {
+			line += fmt.Sprintf("  %-7s", solution.ShortCommit(record.Commit))
+		}
+		if showVariants {
+			line += "  " + solution.VariantLabel(record.Variant)
+		}
+		fmt.Println(strings.TrimRight(line, " "))
 	}
 
 	// Display usage hints
-	fmt.Printf("\nUse 'dsa history %s --show N' to view solution #N\n", slug)
-	fmt.Printf("Use 'dsa history %s --restore N' to restore solution #N\n", slug)
+	flags := ""
+	if variantFilter != nil {
+		flags = " --variant " + solution.VariantLabel(*variantFilter)
+	}
+	fmt.Printf("\nUse 'dsa history %s%s --show N' to view solution #N\n", slug, flags)
+	fmt.Printf("Use 'dsa history %s%s --restore N' to restore solution #N\n", slug, flags)
+	fmt.Printf("Use 'dsa history %s%s --diff N M' to compare two solutions\n", slug, flags)
 }
 
 // showSolution displays code for a specific submission
-func showSolution(svc *solution.Service, problemID uint, slug string, index int) {
-	record, err := svc.GetSubmissionByIndex(problemID, index)
+func showSolution(history solution.History, slug string, variantFilter *string, index int) {
+	record, err := history.Get(variantFilter, index)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -134,6 +243,12 @@ func showSolution(svc *solution.Service, problemID uint, slug string, index int)
 	// Display header
 	fmt.Printf("Solution #%d for %s\n", index, slug)
 	fmt.Printf("Date: %s\n", record.CreatedAt.Format("2006-01-02 15:04:05"))
+	if record.Variant != "" {
+		fmt.Printf("Variant: %s\n", record.Variant)
+	}
+	if record.Commit != "" {
+		fmt.Printf("Commit: %s\n", record.Commit)
+	}
 
 	if record.Passed {
 		fmt.Printf("Status: ✓ Passed\n")
@@ -146,10 +261,11 @@ func showSolution(svc *solution.Service, problemID uint, slug string, index int)
 	fmt.Println(record.Code)
 }
 
-// restoreSolution restores a previous submission as current solution
-func restoreSolution(svc *solution.Service, problemID uint, slug string, index int) {
+// restoreSolution restores a previous submission as current solution; in a
+// git-backed workspace the file is checked out of the submission's commit
+func restoreSolution(svc *solution.Service, history solution.History, problemID uint, slug string, variantFilter *string, index int) {
 	// Get submission record
-	record, err := svc.GetSubmissionByIndex(problemID, index)
+	record, err := history.Get(variantFilter, index)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -169,7 +285,7 @@ func restoreSolution(svc *solution.Service, problemID uint, slug string, index i
 	}
 
 	// Backup current solution
-	backupPath, err := svc.BackupCurrentSolution(slug, problemID)
+	backupPath, err := svc.BackupCurrentVariant(slug, record.Variant, problemID)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating backup: %v\n", err)
 		os.Exit(1)
@@ -180,12 +296,182 @@ func restoreSolution(svc *solution.Service, problemID uint, slug string, index i
 	}
 
 	// Restore solution
-	if err := svc.RestoreSolution(slug, record); err != nil {
+	if err := history.Restore(record); err != nil {
 		fmt.Fprintf(os.Stderr, "Error restoring solution: %v\n", err)
 		os.Exit(1)
 	}
 
 	fmt.Println("✓ Solution restored from history")
-	fmt.Printf("  File: solutions/%s.go\n", slug)
+	fmt.Printf("  File: %s\n", solution.VariantPath(slug, record.Variant))
+	if record.Commit != "" {
+		fmt.Printf("  Commit: %s\n", solution.ShortCommit(record.Commit))
+	}
 	fmt.Printf("  Timestamp: %s\n", timestamp)
 }
+
+// diffVersion is one side of a --diff: a history attempt or the solution file
+type diffVersion struct {
+	label   string
+	code    string
+	variant string
+	record  *solution.SubmissionRecord // Nil for a solution file that was never submitted
+}
+
+// diffSolutions shows a unified diff between two attempts followed by the
+// change in test results and saved benchmarks
+func diffSolutions(history solution.History, storage *benchmarking.Storage, problemID uint, slug string, variantFilter *string, refA, refB string) {
+	if refA == "current" && refB == "current" {
+		fmt.Fprintf(os.Stderr, "Error: --diff needs at least one history attempt, e.g. 'dsa history %s --diff 1 current'\n", slug)
+		os.Exit(2) // ExitUsageError
+	}
+
+	// Resolve attempts first so "current" can follow the variant of the other side
+	var versionA, versionB *diffVersion
+	var err error
+	if refA != "current" {
+		if versionA, err = loadDiffAttempt(history, variantFilter, refA); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2) // ExitUsageError
+		}
+	}
+	if refB != "current" {
+		if versionB, err = loadDiffAttempt(history, variantFilter, refB); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2) // ExitUsageError
+		}
+	}
+	if versionA == nil {
+		versionA = loadDiffCurrent(history, slug, currentDiffVariant(variantFilter, versionB))
+	}
+	if versionB == nil {
+		versionB = loadDiffCurrent(history, slug, currentDiffVariant(variantFilter, versionA))
+	}
+
+	// Code diff
+	hunks := solution.Diff(versionA.code, versionB.code, solution.DefaultDiffContext)
+	if len(hunks) == 0 {
+		fmt.Printf("No code differences between %s and %s\n", versionA.label, versionB.label)
+	} else {
+		fmt.Print(output.FormatUnifiedDiff(versionA.label, versionB.label, hunks))
+	}
+
+	// Summary of changes, B relative to A
+	added, removed := 0, 0
+	for _, hunk := range hunks {
+		for _, line := range hunk.Lines {
+			switch line.Op {
+			case solution.DiffInsert:
+				added++
+			case solution.DiffDelete:
+				removed++
+			}
+		}
+	}
+
+	fmt.Printf("\nSummary (%s → %s):\n", versionA.label, versionB.label)
+	fmt.Printf("  Lines:      +%d -%d\n", added, removed)
+	if versionA.variant != versionB.variant {
+		fmt.Printf("  Variant:    %s → %s\n", solution.VariantLabel(versionA.variant), solution.VariantLabel(versionB.variant))
+	}
+	fmt.Printf("  Tests:      %s → %s\n", formatDiffTests(versionA.record), formatDiffTests(versionB.record))
+	if complexityA, complexityB := formatDiffComplexity(versionA.record), formatDiffComplexity(versionB.record); complexityA != "-" || complexityB != "-" {
+		fmt.Printf("  Complexity: %s → %s\n", complexityA, complexityB)
+	}
+
+	// Benchmark deltas from results saved with 'dsa bench --save'
+	benchA, errA := storage.GetSolutionBenchmarks(problemID, diffRecordID(versionA.record), versionA.code)
+	benchB, errB := storage.GetSolutionBenchmarks(problemID, diffRecordID(versionB.record), versionB.code)
+	if errA != nil || errB != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to retrieve saved benchmarks: %v\n", errors.Join(errA, errB))
+		return
+	}
+
+	comparisons := benchmarking.NewComparator().CompareSaved(benchA, benchB)
+	if len(comparisons) == 0 {
+		fmt.Printf("  Benchmarks: no saved results for both versions (save with 'dsa bench %s --save')\n", slug)
+		return
+	}
+	fmt.Printf("\nBenchmarks (A = %s, B = %s; Δ is B relative to A):\n\n", versionA.label, versionB.label)
+	fmt.Print(benchmarking.NewFormatter().FormatSavedComparison("A", "B", benchA, benchB, comparisons))
+}
+
+// loadDiffAttempt resolves a 1-based history attempt number
+func loadDiffAttempt(history solution.History, variantFilter *string, ref string) (*diffVersion, error) {
+	index, err := strconv.Atoi(ref)
+	if err != nil || index < 1 {
+		return nil, fmt.Errorf("invalid attempt '%s': use a history number (1 = most recent) or 'current'", ref)
+	}
+
+	record, err := history.Get(variantFilter, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return &diffVersion{
+		label:   fmt.Sprintf("#%d (%s)", index, record.CreatedAt.Format("2006-01-02 15:04:05")),
+		code:    record.Code,
+		variant: record.Variant,
+		record:  record,
+	}, nil
+}
+
+// loadDiffCurrent reads the solution file of a variant, linking it to the
+// submission with identical code if there is one
+func loadDiffCurrent(history solution.History, slug, variant string) *diffVersion {
+	path := solution.VariantPath(slug, variant)
+	code, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to read current solution %s: %v\n", path, err)
+		os.Exit(1)
+	}
+
+	version := &diffVersion{label: "current (" + path + ")", code: string(code), variant: variant}
+	if record, err := history.FindByCode(version.code); err == nil {
+		version.record = record
+	}
+	return version
+}
+
+// currentDiffVariant picks the variant whose file "current" refers to: the
+// filtered variant, else the variant of the attempt it is compared with
+func currentDiffVariant(variantFilter *string, other *diffVersion) string {
+	if variantFilter != nil {
+		return *variantFilter
+	}
+	if other != nil {
+		return other.variant
+	}
+	return ""
+}
+
+// formatDiffTests describes the test results of one side of a diff
+func formatDiffTests(record *solution.SubmissionRecord) string {
+	if record == nil {
+		return "not submitted"
+	}
+
+	status := "✗ Failed"
+	if record.Passed {
+		status = "✓ Passed"
+	}
+	if record.TestsTotal > 0 {
+		return fmt.Sprintf("%s (%d/%d)", status, record.TestsPassed, record.TestsTotal)
+	}
+	return status
+}
+
+// formatDiffComplexity returns the measured complexity class of one side of a diff
+func formatDiffComplexity(record *solution.SubmissionRecord) string {
+	if record == nil || record.ComplexityClass == "" {
+		return "-"
+	}
+	return record.ComplexityClass
+}
+
+// diffRecordID returns the submission ID of one side of a diff, or 0
+func diffRecordID(record *solution.SubmissionRecord) uint {
+	if record == nil {
+		return 0
+	}
+	return record.ID
+}
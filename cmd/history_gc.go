@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyGCKeepLast    int
+	historyGCKeepPassing bool
+	historyGCDryRun      bool
+)
+
+var historyGCCmd = &cobra.Command{
+	Use:   "gc [problem-id]",
+	Short: "Prune old submissions and reclaim history storage",
+	Long: `Remove old submissions according to a retention policy and compact
+stored solution code.
+
+A submission is kept if any rule keeps it:
+  --keep-last N    The N most recent submissions of each problem and variant
+  --keep-passing   Every passing submission (on by default)
+
+Only submissions with stored code count. Test runs recorded by 'dsa test'
+(including watch mode) without code neither push submissions out of
+--keep-last nor are deleted. Benchmarks of deleted submissions are kept and
+no longer point at them.
+
+The command also:
+  - Moves code stored inline by older versions into the deduplicated,
+    compressed code store
+  - Deletes stored code that no remaining submission references
+  - Removes timestamped copies in solutions/history/ whose code is in the
+    code store (backups made by --restore are kept)
+
+Without a problem ID every problem is processed.
+
+Examples:
+  dsa history gc --dry-run
+  dsa history gc
+  dsa history gc two-sum --keep-last 3
+  dsa history gc --keep-last 5 --keep-passing=false`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runHistoryGCCommand,
+}
+
+func init() {
+	historyCmd.AddCommand(historyGCCmd)
+	historyGCCmd.Flags().IntVar(&historyGCKeepLast, "keep-last", solution.DefaultKeepLast, "Keep the N most recent submissions per problem and variant")
+	historyGCCmd.Flags().BoolVar(&historyGCKeepPassing, "keep-passing", true, "Keep every passing submission")
+	historyGCCmd.Flags().BoolVar(&historyGCDryRun, "dry-run", false, "Show what would be removed without changing anything")
+}
+
+func runHistoryGCCommand(cmd *cobra.Command, args []string) {
+	if historyGCKeepLast < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --keep-last must not be negative\n")
+		os.Exit(2) // ExitUsageError
+	}
+
+	// Initialize database
+	db, err := database.Initialize()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to connect to database: %v\n", err)
+		os.Exit(3) // ExitDatabaseError
+	}
+	defer func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}()
+
+	// Resolve the problem when one is given
+	var slug string
+	var problemID uint
+	if len(args) == 1 {
+		slug = args[0]
+		prob, err := problem.NewService(db).GetProblemBySlug(slug)
+		if err != nil {
+			if errors.Is(err, problem.ErrProblemNotFound) {
+				fmt.Fprintf(os.Stderr, "Problem '%s' not found. Run 'dsa list' to see available problems.\n", slug)
+				os.Exit(2) // ExitUsageError
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		problemID = prob.ID
+	}
+
+	policy := solution.RetentionPolicy{KeepLast: historyGCKeepLast, KeepPassing: historyGCKeepPassing}
+	result, err := solution.NewService(db).GarbageCollect(slug, problemID, policy, historyGCDryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	verb := "Removed"
+	if historyGCDryRun {
+		fmt.Println("Dry run: nothing was changed.")
+		verb = "Would remove"
+	}
+	fmt.Printf("%s %d submissions (%d kept)\n", verb, result.SubmissionsDeleted, result.SubmissionsKept)
+	fmt.Printf("%s %d unreferenced stored solutions (%s)\n", verb, result.BlobsDeleted, formatByteCount(result.BlobBytesFreed))
+	fmt.Printf("%s %d history copies (%s)\n", verb, result.FilesRemoved, formatByteCount(result.FileBytesFreed))
+	if result.CodeCompacted > 0 {
+		moved := "Moved"
+		if historyGCDryRun {
+			moved = "Would move"
+		}
+		fmt.Printf("%s inline code of %d submissions to the code store\n", moved, result.CodeCompacted)
+	}
+
+	os.Exit(0)
+}
+
+// formatByteCount formats a size in bytes with a binary unit, e.g. "12.3 KB"
+func formatByteCount(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, suffix := float64(bytes)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryGCCommand(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"history", "gc"})
+	assert.NoError(t, err)
+	assert.Equal(t, "gc", cmd.Name())
+	assert.Contains(t, cmd.Long, "dsa history gc --dry-run")
+
+	keepLast := cmd.Flags().Lookup("keep-last")
+	assert.NotNil(t, keepLast)
+	assert.Equal(t, "10", keepLast.DefValue)
+
+	keepPassing := cmd.Flags().Lookup("keep-passing")
+	assert.NotNil(t, keepPassing)
+	assert.Equal(t, "true", keepPassing.DefValue)
+
+	assert.NotNil(t, cmd.Flags().Lookup("dry-run"))
+
+	assert.NoError(t, cmd.Args(cmd, []string{}))
+	assert.NoError(t, cmd.Args(cmd, []string{"two-sum"}))
+	assert.Error(t, cmd.Args(cmd, []string{"two-sum", "extra"}))
+}
+
+func TestFormatByteCount(t *testing.T) {
+	assert.Equal(t, "512 B", formatByteCount(512))
+	assert.Equal(t, "1.5 KB", formatByteCount(1536))
+	assert.Equal(t, "2.0 MB", formatByteCount(2*1024*1024))
+}
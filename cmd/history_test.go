@@ -96,3 +96,34 @@ func TestHistoryCommand_FlagDescriptions(t *testing.T) {
 	assert.NotNil(t, restoreFlag)
 	assert.Contains(t, restoreFlag.Usage, "Restore Nth attempt as current solution")
 }
+
+func TestHistoryCommand_DiffFlag(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"history"})
+	assert.NoError(t, err)
+
+	flag := cmd.Flags().Lookup("diff")
+	assert.NotNil(t, flag, "diff flag should exist")
+	assert.Equal(t, "false", flag.DefValue)
+	assert.Contains(t, cmd.Long, "dsa history two-sum --diff 2 current")
+
+	t.Run("requires two attempts with --diff", func(t *testing.T) {
+		historyDiff = true
+		defer func() { historyDiff = false }()
+
+		assert.NoError(t, cmd.Args(cmd, []string{"two-sum", "2", "current"}))
+		assert.Error(t, cmd.Args(cmd, []string{"two-sum", "2"}))
+	})
+
+	t.Run("requires only the problem without --diff", func(t *testing.T) {
+		assert.NoError(t, cmd.Args(cmd, []string{"two-sum"}))
+		assert.Error(t, cmd.Args(cmd, []string{"two-sum", "2", "1"}))
+	})
+}
+
+func TestHistoryCommand_GitHistoryHelp(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"history"})
+	assert.NoError(t, err)
+
+	assert.Contains(t, cmd.Long, "git_history")
+	assert.Contains(t, cmd.Long, "checks the file out")
+}
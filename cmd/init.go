@@ -9,22 +9,44 @@ import (
 	"path/filepath"
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
+var initModule string
+
 // initCmd represents the init command
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize your DSA practice workspace",
 	Long: `Initialize creates the ~/.dsa directory and database for storing
-your practice progress, solutions, and problem data.
+your practice progress, solutions, and problem data, and lays out a practice
+workspace in the current directory:
+  .dsa-workspace  Marks the workspace root
+  go.mod, go.sum  Make the workspace its own Go module, requiring testify
+                  for the tests
+  solutions/      Your solutions
+  problems/       Problem boilerplate and tests
+  structures/     ListNode, TreeNode and graph helpers for tests (see 'dsa test-gen')
+
+dsa finds the workspace by walking up from the current directory to the
+nearest .dsa-workspace, so commands work from any subdirectory and the
+workspace can live inside a larger repository. Outside a workspace, paths are
+relative to the current directory.
 
 This command is safe to run multiple times - it will detect an existing
-workspace and skip initialization.
+workspace and skip initialization. Inside an existing workspace, no new
+workspace is created.
+
+Examples:
+  dsa init
+  dsa init --module example.com/me/dsa
 
 Exit Codes:
   0 - Success (workspace initialized or already exists)
+  1 - Workspace files could not be created
   3 - Database error (check directory permissions)`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get home directory for displaying in messages
 		homeDir, err := os.UserHomeDir()
@@ -37,43 +59,70 @@ Exit Codes:
 		dsaDir := filepath.Join(homeDir, ".dsa")
 		dbPath := filepath.Join(dsaDir, "dsa.db")
 
-		// Check if workspace already exists
+		// Check if the database already exists
 		if _, err := os.Stat(dbPath); err == nil {
-			fmt.Printf("Workspace already initialized at %s\n", dsaDir)
-			return // Exit with code 0 (success)
-		}
-
-		// Initialize database
-		db, err := database.Initialize()
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "✗ Error: Failed to initialize workspace")
-			fmt.Fprintf(os.Stderr, "  %v\n", err)
-			fmt.Fprintln(os.Stderr, "\nTroubleshooting:")
-			fmt.Fprintln(os.Stderr, "  • Check directory permissions for ~/.dsa")
-			fmt.Fprintln(os.Stderr, "  • Ensure sufficient disk space")
-			fmt.Fprintln(os.Stderr, "  • Verify SQLite dependencies are available")
-			os.Exit(3)
-		}
-
-		// Seed initial problem library
-		count, err := database.SeedProblems(db)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to seed problem library: %v\n", err)
-			// Don't exit - workspace is still usable without seeded problems
-		} else if count > 0 {
-			fmt.Printf("✓ Seeded %d problems to library\n", count)
+			fmt.Printf("Database already initialized at %s\n", dsaDir)
+		} else {
+			initDatabase(dsaDir)
 		}
 
-		// Verify database connection (optional sanity check)
-		sqlDB, err := db.DB()
-		if err == nil {
-			sqlDB.Close()
-		}
-
-		fmt.Printf("✓ Workspace initialized at %s\n", dsaDir)
+		initWorkspace()
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
+	initCmd.Flags().StringVar(&initModule, "module", workspace.DefaultModule, "Module path of the workspace go.mod")
+}
+
+// initDatabase creates and seeds the database in dsaDir
+func initDatabase(dsaDir string) {
+	db, err := database.Initialize()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "✗ Error: Failed to initialize workspace")
+		fmt.Fprintf(os.Stderr, "  %v\n", err)
+		fmt.Fprintln(os.Stderr, "\nTroubleshooting:")
+		fmt.Fprintln(os.Stderr, "  • Check directory permissions for ~/.dsa")
+		fmt.Fprintln(os.Stderr, "  • Ensure sufficient disk space")
+		fmt.Fprintln(os.Stderr, "  • Verify SQLite dependencies are available")
+		os.Exit(3)
+	}
+
+	// Seed initial problem library
+	count, err := database.SeedProblems(db)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to seed problem library: %v\n", err)
+		// Don't exit - workspace is still usable without seeded problems
+	} else if count > 0 {
+		fmt.Printf("✓ Seeded %d problems to library\n", count)
+	}
+
+	// Verify database connection (optional sanity check)
+	sqlDB, err := db.DB()
+	if err == nil {
+		sqlDB.Close()
+	}
+
+	fmt.Printf("✓ Database initialized at %s\n", dsaDir)
+}
+
+// initWorkspace lays out a workspace in the current directory unless it is
+// already inside one
+func initWorkspace() {
+	if root, err := workspace.Find("."); err == nil {
+		fmt.Printf("Workspace already initialized at %s\n", root)
+		return
+	}
+
+	created, err := workspace.Init(".", initModule)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "✗ Error: Failed to create workspace: %v\n", err)
+		os.Exit(1)
+	}
+
+	cwd, _ := os.Getwd()
+	fmt.Printf("✓ Workspace initialized at %s\n", cwd)
+	for _, path := range created {
+		fmt.Printf("  Created %s\n", path)
+	}
 }
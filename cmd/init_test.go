@@ -5,12 +5,19 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 func TestInitCommand(t *testing.T) {
+	// init lays out a workspace in the current directory
+	originalDir, _ := os.Getwd()
+	os.Chdir(t.TempDir())
+	defer os.Chdir(originalDir)
+
 	t.Run("successfully initializes workspace", func(t *testing.T) {
 		// Create temp directory for test
 		tempHome := t.TempDir()
@@ -174,3 +181,57 @@ func TestInitCommand(t *testing.T) {
 		assert.Equal(t, "easy", retrievedProblem.Difficulty)
 	})
 }
+
+func TestInitCommand_Workspace(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+
+	t.Run("lays out a workspace in the current directory", func(t *testing.T) {
+		root := t.TempDir()
+		os.Chdir(root)
+
+		initCmd.Run(initCmd, []string{})
+
+		assert.FileExists(t, filepath.Join(root, workspace.Marker))
+		assert.DirExists(t, filepath.Join(root, "solutions"))
+		assert.DirExists(t, filepath.Join(root, "problems"))
+
+		goMod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		require.NoError(t, err)
+		assert.Contains(t, string(goMod), "module "+workspace.DefaultModule+"\n")
+	})
+
+	t.Run("keeps an existing go.mod", func(t *testing.T) {
+		root := t.TempDir()
+		os.Chdir(root)
+		require.NoError(t, os.WriteFile("go.mod", []byte("module example.com/mine\n"), 0644))
+
+		initCmd.Run(initCmd, []string{})
+
+		goMod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		require.NoError(t, err)
+		assert.Equal(t, "module example.com/mine\n", string(goMod))
+		assert.FileExists(t, filepath.Join(root, workspace.Marker))
+	})
+
+	t.Run("does not nest workspaces", func(t *testing.T) {
+		root := t.TempDir()
+		os.Chdir(root)
+		initCmd.Run(initCmd, []string{})
+
+		sub := filepath.Join(root, "solutions")
+		os.Chdir(sub)
+		initCmd.Run(initCmd, []string{})
+
+		assert.NoFileExists(t, filepath.Join(sub, workspace.Marker))
+		assert.NoFileExists(t, filepath.Join(sub, "go.mod"))
+	})
+}
+
+func TestInitCommand_Flags(t *testing.T) {
+	flag := initCmd.Flags().Lookup("module")
+	assert.NotNil(t, flag)
+	assert.Equal(t, workspace.DefaultModule, flag.DefValue)
+	assert.Contains(t, initCmd.Long, ".dsa-workspace")
+}
@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
 	"github.com/ak95asb/dsa-dojo/internal/output"
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +20,7 @@ var showCmd = &cobra.Command{
   - Full description
   - File paths for boilerplate and tests
   - Solution status and progress
+  - Snippets matching the problem's topic and tags (see 'dsa snippet')
 
 Examples:
   dsa show two-sum              # Show details for Two Sum problem
@@ -60,4 +63,16 @@ func runShowCommand(cmd *cobra.Command, args []string) {
 
 	// Format and display problem details
 	output.PrintProblemDetails(problemDetails)
+
+	// Suggest snippets sharing the problem's topic or tags
+	tags := append(strings.Split(problemDetails.Tags, ","), problemDetails.Topic)
+	suggestions, err := snippet.NewStore().Suggest(tags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to load snippets: %v\n", err)
+		return
+	}
+	if len(suggestions) > 0 {
+		fmt.Println()
+		output.PrintSnippetSuggestions(problemDetails.Slug, suggestions)
+	}
 }
@@ -41,7 +41,7 @@ func TestShowCommandIntegration(t *testing.T) {
 		assert.Contains(t, output, "Description", "Output should contain Description section")
 		assert.Contains(t, output, "Files", "Output should contain Files section")
 		assert.Contains(t, output, "Progress", "Output should contain Progress section")
-		assert.Contains(t, output, "problems/templates/two-sum.go", "Output should contain boilerplate path")
+		assert.Contains(t, output, "problems/templates/two_sum.go", "Output should contain boilerplate path")
 	})
 
 	t.Run("handles invalid slug gracefully", func(t *testing.T) {
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+var snippetCmd = &cobra.Command{
+	Use:   "snippet",
+	Short: "Manage reusable code snippets for solutions",
+	Long: `List, view, add and insert reusable Go snippets such as union-find or a
+min-heap into solution files.
+
+Built-in snippets:
+  bfs            Breadth-first search over an adjacency list, level by level
+  min-heap       Min-heap of ints implementing container/heap
+  segment-tree   Segment tree for range sums with point updates
+  trie           Prefix tree over lowercase ASCII words
+  union-find     Disjoint set union with path compression and union by rank
+
+Snippets are loaded from the first of:
+  .dsa/snippets/<name>.snippet    Workspace snippet (shared with your team)
+  ~/.dsa/snippets/<name>.snippet  Global snippet
+  built-in                        Shipped with dsa
+
+Snippets are tagged; 'dsa show' suggests those sharing a tag with the problem's
+topic or tags.
+
+Examples:
+  dsa snippet list
+  dsa snippet show union-find
+  dsa snippet add sliding-window --file window.go --tags arrays,sliding-window
+  dsa snippet insert number-of-islands union-find`,
+}
+
+func init() {
+	rootCmd.AddCommand(snippetCmd)
+}
+
+// exitOnSnippetError reports a snippet error, exiting with a usage error for
+// unknown snippet names
+func exitOnSnippetError(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if errors.Is(err, snippet.ErrSnippetNotFound) {
+		os.Exit(2) // ExitUsageError
+	}
+	os.Exit(1)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snippetAddFile        string
+	snippetAddTags        string
+	snippetAddDescription string
+	snippetAddWorkspace   bool
+)
+
+var snippetAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Save your own snippet",
+	Long: `Save Go code as a snippet, read from --file or standard input. The code may
+be a complete Go file or bare declarations; imports are kept and merged into
+solutions on insert.
+
+Snippets are global (~/.dsa/snippets) unless --workspace is given, which
+writes .dsa/snippets in the current directory so the snippet can be committed
+and shared. A snippet with the name of a built-in replaces it.
+
+Examples:
+  dsa snippet add sliding-window --file window.go --tags arrays,sliding-window
+  dsa snippet add dijkstra --tags graphs,shortest-path --workspace < dijkstra.go`,
+	Args: cobra.ExactArgs(1),
+	Run:  runSnippetAddCommand,
+}
+
+func init() {
+	snippetCmd.AddCommand(snippetAddCmd)
+	snippetAddCmd.Flags().StringVar(&snippetAddFile, "file", "", "Read the snippet code from this file instead of standard input")
+	snippetAddCmd.Flags().StringVar(&snippetAddTags, "tags", "", "Comma-separated tags matched against problem topics and tags")
+	snippetAddCmd.Flags().StringVar(&snippetAddDescription, "description", "", "One-line description")
+	snippetAddCmd.Flags().BoolVar(&snippetAddWorkspace, "workspace", false, "Save the snippet in .dsa/snippets")
+}
+
+func runSnippetAddCommand(cmd *cobra.Command, args []string) {
+	name := args[0]
+	if err := snippet.ValidateName(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2) // ExitUsageError
+	}
+
+	var code []byte
+	var err error
+	if snippetAddFile != "" {
+		code, err = os.ReadFile(snippetAddFile)
+	} else {
+		code, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to read snippet code: %v\n", err)
+		os.Exit(1)
+	}
+	if strings.TrimSpace(string(code)) == "" {
+		fmt.Fprintf(os.Stderr, "Error: snippet code is empty. Pass --file or pipe code on standard input.\n")
+		os.Exit(2) // ExitUsageError
+	}
+
+	path, err := snippet.NewStore().Add(name, snippetAddDescription, strings.Split(snippetAddTags, ","), string(code), snippetAddWorkspace)
+	if err != nil {
+		exitOnSnippetError(err)
+	}
+
+	fmt.Printf("✓ Saved snippet '%s': %s\n", name, path)
+	os.Exit(0)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/spf13/cobra"
+)
+
+var snippetInsertVariant string
+
+var snippetInsertCmd = &cobra.Command{
+	Use:   "insert <problem-slug> <name>",
+	Short: "Insert a snippet into a solution file",
+	Long: `Insert a snippet into the solution file of a problem. The snippet's
+declarations are appended to the file and its imports merged into the file's
+imports. Declarations the file already has are skipped, so inserting a
+snippet twice changes nothing. Solutions share one package, so a snippet whose
+declarations another solution file already has is refused.
+
+Examples:
+  dsa snippet insert number-of-islands union-find
+  dsa snippet insert kth-largest min-heap --variant heap`,
+	Args: cobra.ExactArgs(2),
+	Run:  runSnippetInsertCommand,
+}
+
+func init() {
+	snippetCmd.AddCommand(snippetInsertCmd)
+	snippetInsertCmd.Flags().StringVar(&snippetInsertVariant, "variant", "", "Insert into a named solution variant")
+}
+
+func runSnippetInsertCommand(cmd *cobra.Command, args []string) {
+	slug, name := args[0], args[1]
+	variant := parseVariantFlag(snippetInsertVariant)
+
+	s, err := snippet.NewStore().Get(name)
+	if err != nil {
+		exitOnSnippetError(err)
+	}
+
+	// Initialize database
+	db, err := database.Initialize()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to connect to database: %v\n", err)
+		os.Exit(3) // ExitDatabaseError
+	}
+	defer func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}()
+
+	if _, err := problem.NewService(db).GetProblemBySlug(slug); err != nil {
+		if errors.Is(err, problem.ErrProblemNotFound) {
+			fmt.Fprintf(os.Stderr, "Problem '%s' not found. Run 'dsa list' to see available problems.\n", slug)
+			os.Exit(2) // ExitUsageError
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Check if solution file exists
+	requireVariantFile(slug, variant)
+	solutionPath := solution.VariantPath(slug, variant)
+	if _, err := os.Stat(solutionPath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Solution file not found: %s\n", solutionPath)
+		fmt.Fprintf(os.Stderr, "Run 'dsa solve %s' to create a solution file.\n", slug)
+		os.Exit(1)
+	}
+
+	result, err := snippet.InsertIntoFile(solutionPath, s)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !result.Changed() {
+		fmt.Printf("%s already contains snippet '%s'\n", solutionPath, name)
+		os.Exit(0)
+	}
+
+	fmt.Printf("✓ Inserted snippet '%s' into %s%s\n", name, solutionPath, variantSuffix(variant))
+	if len(result.Imports) > 0 {
+		fmt.Printf("  Imports added:  %s\n", strings.Join(result.Imports, ", "))
+	}
+	fmt.Printf("  Added:          %s\n", strings.Join(result.Added, ", "))
+	if len(result.Skipped) > 0 {
+		fmt.Printf("  Already there:  %s\n", strings.Join(result.Skipped, ", "))
+	}
+	os.Exit(0)
+}
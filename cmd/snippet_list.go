@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+var snippetListTag string
+
+var snippetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List snippets with their tags",
+	Long: `List every snippet with its source (workspace, global or built-in),
+tags and description.
+
+Examples:
+  dsa snippet list
+  dsa snippet list --tag graphs`,
+	Args: cobra.NoArgs,
+	Run:  runSnippetListCommand,
+}
+
+func init() {
+	snippetCmd.AddCommand(snippetListCmd)
+	snippetListCmd.Flags().StringVar(&snippetListTag, "tag", "", "Only list snippets with this tag")
+}
+
+func runSnippetListCommand(cmd *cobra.Command, args []string) {
+	store := snippet.NewStore()
+
+	var snippets []*snippet.Snippet
+	var err error
+	if snippetListTag != "" {
+		snippets, err = store.Suggest([]string{snippetListTag})
+	} else {
+		snippets, err = store.List()
+	}
+	if err != nil {
+		exitOnSnippetError(err)
+	}
+
+	if len(snippets) == 0 {
+		fmt.Printf("No snippets tagged '%s'\n", snippetListTag)
+		os.Exit(0)
+	}
+
+	fmt.Printf("%-14s  %-9s  %s\n", "Snippet", "Source", "Description")
+	fmt.Printf("%-14s  %-9s  %s\n", "==============", "=========", "===========")
+	for _, s := range snippets {
+		fmt.Printf("%-14s  %-9s  %s\n", s.Name, s.Source, s.Description)
+		fmt.Printf("%-14s  %-9s  Tags: %s\n", "", "", strings.Join(s.Tags, ", "))
+	}
+
+	fmt.Println("\nUse 'dsa snippet insert <slug> <name>' to add a snippet to a solution")
+	os.Exit(0)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+var snippetShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print a snippet's code",
+	Long: `Print the code of a snippet along with its source, tags and description.
+
+Examples:
+  dsa snippet show union-find
+  dsa snippet show min-heap`,
+	Args: cobra.ExactArgs(1),
+	Run:  runSnippetShowCommand,
+}
+
+func init() {
+	snippetCmd.AddCommand(snippetShowCmd)
+}
+
+func runSnippetShowCommand(cmd *cobra.Command, args []string) {
+	s, err := snippet.NewStore().Get(args[0])
+	if err != nil {
+		exitOnSnippetError(err)
+	}
+
+	source := s.Source
+	if s.Path != "" {
+		source = fmt.Sprintf("%s (%s)", s.Source, s.Path)
+	}
+	fmt.Printf("Snippet:     %s\n", s.Name)
+	fmt.Printf("Source:      %s\n", source)
+	fmt.Printf("Tags:        %s\n", strings.Join(s.Tags, ", "))
+	fmt.Printf("Description: %s\n\n", s.Description)
+	fmt.Print(s.Code)
+	if !strings.HasSuffix(s.Code, "\n") {
+		fmt.Println()
+	}
+	os.Exit(0)
+}
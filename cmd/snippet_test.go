@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnippetCommand(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"snippet"})
+	assert.NoError(t, err)
+	assert.Equal(t, "snippet", cmd.Name())
+	assert.Contains(t, cmd.Long, ".dsa/snippets/<name>.snippet")
+	assert.Contains(t, cmd.Long, "~/.dsa/snippets/<name>.snippet")
+
+	// Every built-in snippet is described in the help
+	snippets, err := snippet.NewStoreWithDirs("", "").List()
+	require.NoError(t, err)
+	for _, s := range snippets {
+		assert.Contains(t, cmd.Long, s.Name)
+	}
+}
+
+func TestSnippetSubcommands(t *testing.T) {
+	for _, name := range []string{"list", "show", "add", "insert"} {
+		cmd, _, err := rootCmd.Find([]string{"snippet", name})
+		assert.NoError(t, err)
+		assert.Equal(t, name, cmd.Name())
+	}
+
+	list, _, _ := rootCmd.Find([]string{"snippet", "list"})
+	assert.NotNil(t, list.Flags().Lookup("tag"))
+
+	add, _, _ := rootCmd.Find([]string{"snippet", "add"})
+	for _, flag := range []string{"file", "tags", "description", "workspace"} {
+		assert.NotNil(t, add.Flags().Lookup(flag), flag)
+	}
+
+	insert, _, _ := rootCmd.Find([]string{"snippet", "insert"})
+	assert.NotNil(t, insert.Flags().Lookup("variant"))
+	assert.Error(t, insert.Args(insert, []string{"two-sum"}))
+	assert.NoError(t, insert.Args(insert, []string{"two-sum", "union-find"}))
+}
+
+func TestShowCommand_MentionsSnippets(t *testing.T) {
+	assert.Contains(t, showCmd.Long, "dsa snippet")
+}
@@ -9,12 +9,15 @@ import (
 	editorpkg "github.com/ak95asb/dsa-dojo/internal/editor"
 	"github.com/ak95asb/dsa-dojo/internal/problem"
 	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 var (
-	solveOpen  bool
-	solveForce bool
+	solveOpen      bool
+	solveWithTests bool
+	solveForce     bool
+	solveVariant   string
 )
 
 var solveCmd = &cobra.Command{
@@ -23,14 +26,30 @@ var solveCmd = &cobra.Command{
 	Long: `Generate a solution file with boilerplate code and function signature.
 
 The command creates:
-  - A solution file at solutions/<slug>.go
+  - A solution file at solutions/<name>.go, where <name> is the slug in
+    snake_case (see 'dsa doctor' for the full layout)
   - Boilerplate with function signature and helpful comments
   - Optional: Opens the file in your configured editor
 
+--open puts the cursor on the TODO line of a freshly generated stub, using
+the {line} and {column} placeholders of editor_args, and --with-tests also
+opens the problem's test file, unless the editor is a system opener such as
+xdg-open, which opens the solution alone. Terminal editors such as vim keep the
+terminal until they close; GUI editors open in the background (see
+editor_mode in 'dsa config').
+
+Practice several approaches to the same problem with named variants
+(--variant brute, --variant hashmap, ...). Each variant lives in
+solutions/variants/<variant>/<name>.go, and test, bench, submit and
+history accept the same --variant flag.
+
 Examples:
   dsa solve two-sum
   dsa solve binary-search --open
-  dsa solve merge-intervals --force`,
+  dsa solve binary-search --open --with-tests
+  dsa solve merge-intervals --force
+  dsa solve two-sum --variant brute
+  dsa solve two-sum --variant hashmap --open`,
 	Args: cobra.ExactArgs(1),
 	Run:  runSolveCommand,
 }
@@ -38,11 +57,14 @@ Examples:
 func init() {
 	rootCmd.AddCommand(solveCmd)
 	solveCmd.Flags().BoolVarP(&solveOpen, "open", "o", false, "Open solution in editor after generation")
+	solveCmd.Flags().BoolVar(&solveWithTests, "with-tests", false, "Open the problem's test file with the solution (implies --open)")
 	solveCmd.Flags().BoolVarP(&solveForce, "force", "f", false, "Overwrite existing solution without confirmation")
+	solveCmd.Flags().StringVar(&solveVariant, "variant", "", "Create a named solution variant (e.g. brute, hashmap)")
 }
 
 func runSolveCommand(cmd *cobra.Command, args []string) {
 	slug := args[0]
+	variant := parseVariantFlag(solveVariant)
 
 	// Initialize database
 	db, err := database.Initialize()
@@ -69,7 +91,12 @@ func runSolveCommand(cmd *cobra.Command, args []string) {
 
 	// Generate solution file
 	solutionSvc := solution.NewService(db)
-	solutionPath, err := solutionSvc.GenerateSolution(&prob.Problem, solveForce)
+	var solutionPath string
+	if variant != "" {
+		solutionPath, err = solutionSvc.GenerateVariant(&prob.Problem, variant, solveForce)
+	} else {
+		solutionPath, err = solutionSvc.GenerateSolution(&prob.Problem, solveForce)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating solution: %v\n", err)
 		os.Exit(1)
@@ -83,9 +110,16 @@ func runSolveCommand(cmd *cobra.Command, args []string) {
 	fmt.Printf("✓ Solution file generated: %s\n", solutionPath)
 
 	// Open in editor if requested
-	if solveOpen {
-		editorCmd := editorpkg.Detect()
-		if err := editorpkg.Launch(editorCmd, solutionPath); err != nil {
+	if solveOpen || solveWithTests {
+		target := editorpkg.Target{Path: solutionPath}
+		target.Line, target.Column = solution.TodoLine(solutionPath)
+
+		var extra []string
+		if tests := workspace.ProblemLayout(slug).TestFiles(); solveWithTests && len(tests) > 0 {
+			extra = tests[:1]
+		}
+
+		if editorCmd, err := editorpkg.Launch(target, extra...); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to open editor: %v\n", err)
 		} else {
 			fmt.Printf("✓ Opened in %s\n", editorCmd)
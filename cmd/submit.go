@@ -4,15 +4,18 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 
+	"github.com/ak95asb/dsa-dojo/internal/benchmarking"
 	"github.com/ak95asb/dsa-dojo/internal/database"
 	"github.com/ak95asb/dsa-dojo/internal/problem"
 	"github.com/ak95asb/dsa-dojo/internal/solution"
 	testingpkg "github.com/ak95asb/dsa-dojo/internal/testing"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+var submitVariant string
+
 var submitCmd = &cobra.Command{
 	Use:   "submit [problem-id]",
 	Short: "Submit and save your solution to history",
@@ -24,19 +27,30 @@ The command:
   - Records submission in database with pass/fail status
   - Displays confirmation message
 
+Submit a named solution variant with --variant; its history is kept in
+solutions/history/<problem-id>/<variant>/.
+
+With git_history enabled (dsa config set git_history true), solutions/ is a
+git repository and every submission is also committed with a message
+recording the problem, variant, verdict, tests passed and the benchmarks
+saved for the submitted code. The repository is created on first submit.
+
 Examples:
   dsa submit two-sum
-  dsa submit binary-search`,
+  dsa submit binary-search
+  dsa submit two-sum --variant hashmap`,
 	Args: cobra.ExactArgs(1),
 	Run:  runSubmitCommand,
 }
 
 func init() {
 	rootCmd.AddCommand(submitCmd)
+	submitCmd.Flags().StringVar(&submitVariant, "variant", "", "Submit a named solution variant (see 'dsa solve --variant')")
 }
 
 func runSubmitCommand(cmd *cobra.Command, args []string) {
 	slug := args[0]
+	variant := parseVariantFlag(submitVariant)
 
 	// Initialize database
 	db, err := database.Initialize()
@@ -62,7 +76,8 @@ func runSubmitCommand(cmd *cobra.Command, args []string) {
 	}
 
 	// Check if solution file exists
-	solutionPath := filepath.Join("solutions", fmt.Sprintf("%s.go", slug))
+	requireVariantFile(slug, variant)
+	solutionPath := solution.VariantPath(slug, variant)
 	if _, err := os.Stat(solutionPath); os.IsNotExist(err) {
 		fmt.Fprintf(os.Stderr, "Solution file not found: %s\n", solutionPath)
 		fmt.Fprintf(os.Stderr, "Run 'dsa solve %s' to create a solution file.\n", slug)
@@ -72,7 +87,7 @@ func runSubmitCommand(cmd *cobra.Command, args []string) {
 	// Run tests to verify solution
 	fmt.Println("Running tests...")
 	testSvc := testingpkg.NewService(db)
-	result, err := testSvc.ExecuteTests(prob, false, false)
+	result, err := testSvc.ExecuteVariantTests(prob, variant, false, false)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running tests: %v\n", err)
 		os.Exit(1)
@@ -83,8 +98,9 @@ func runSubmitCommand(cmd *cobra.Command, args []string) {
 
 	// Submit solution (regardless of pass/fail)
 	solutionSvc := solution.NewService(db)
-	record, err := solutionSvc.RecordSubmission(
+	record, err := solutionSvc.RecordVariantSubmission(
 		slug,
+		variant,
 		prob.ID,
 		solutionPath,
 		result.AllPassed,
@@ -104,6 +120,9 @@ func runSubmitCommand(cmd *cobra.Command, args []string) {
 		fmt.Printf("✓ Solution submitted (failed tests) and saved to history\n")
 	}
 	fmt.Printf("  Submission ID: %d\n", record.ID)
+	if variant != "" {
+		fmt.Printf("  Variant: %s\n", variant)
+	}
 	fmt.Printf("  Status: ")
 	if record.Passed {
 		fmt.Printf("✓ Passed (%d/%d tests)\n", result.PassedCount, result.TotalCount)
@@ -112,5 +131,33 @@ func runSubmitCommand(cmd *cobra.Command, args []string) {
 	}
 	fmt.Printf("  Timestamp: %s\n", record.CreatedAt.Format("2006-01-02 15:04:05"))
 
+	// Commit to the solutions repository in git mode
+	if viper.GetBool("git_history") {
+		commitSubmission(benchmarking.NewStorage(db), prob.ID, slug, record)
+	}
+
 	os.Exit(0)
 }
+
+// commitSubmission commits a recorded submission to the solutions repository.
+// Failures are reported as warnings since the submission is already saved.
+func commitSubmission(storage *benchmarking.Storage, problemID uint, slug string, record *solution.SubmissionRecord) {
+	repo := solution.SolutionsRepo()
+	created := !repo.Exists()
+
+	benchmarks, err := storage.GetSolutionBenchmarks(problemID, record.ID, record.Code)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to retrieve saved benchmarks: %v\n", err)
+	}
+
+	hash, err := solution.CommitSubmission(repo, slug, record, benchmarks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Submission not committed to git: %v\n", err)
+		return
+	}
+
+	if created {
+		fmt.Printf("  Initialized git repository in %s/\n", repo.Dir())
+	}
+	fmt.Printf("  Commit: %s\n", solution.ShortCommit(hash))
+}
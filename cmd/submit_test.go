@@ -48,3 +48,11 @@ func TestSubmitCommand_ShortDescription(t *testing.T) {
 
 	assert.Equal(t, "Submit and save your solution to history", cmd.Short)
 }
+
+func TestSubmitCommand_GitHistoryHelp(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"submit"})
+	assert.NoError(t, err)
+
+	assert.Contains(t, cmd.Long, "dsa config set git_history true")
+	assert.Contains(t, cmd.Long, "committed")
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage code templates for generated files",
+	Long: `List, view, customize and reset the templates used to generate files.
+
+Templates:
+  solution      Solution file created by 'dsa solve'
+  boilerplate   Problem boilerplate created by 'dsa add'
+  test          Problem test file created by 'dsa add'
+  testgen       Table-driven test file created by 'dsa test-gen'
+
+Templates are Go text/template files. A template is loaded from the first of:
+  .dsa/templates/<name>.tmpl    Workspace override (shared with your team)
+  ~/.dsa/templates/<name>.tmpl  Global override
+  built-in                      Shipped with dsa
+
+Use 'dsa template show <name> --fields' to see the data a template can use,
+such as the signature, description, tags, hints and examples of a problem.
+
+Examples:
+  dsa template list
+  dsa template show solution
+  dsa template show solution --fields
+  dsa template edit solution --workspace
+  dsa template reset solution`,
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+}
+
+// exitOnTemplateError reports a template error, exiting with a usage error
+// for unknown template names
+func exitOnTemplateError(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if errors.Is(err, templates.ErrUnknownTemplate) {
+		os.Exit(2) // ExitUsageError
+	}
+	os.Exit(1)
+}
+
+// templateScope returns the scope selected by a --workspace flag
+func templateScope(workspace bool) templates.Scope {
+	if workspace {
+		return templates.ScopeWorkspace
+	}
+	return templates.ScopeGlobal
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	editorpkg "github.com/ak95asb/dsa-dojo/internal/editor"
+	"github.com/ak95asb/dsa-dojo/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var templateEditWorkspace bool
+
+var templateEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Customize a template in your editor",
+	Long: `Open the override of a template in your editor, creating it first from
+the template it overrides. New overrides start with a comment listing the data
+the template can use; it renders as nothing.
+
+Overrides are global (~/.dsa/templates) unless --workspace is given, which
+writes .dsa/templates in the current directory so the template can be
+committed and shared. Workspace templates take precedence over global ones.
+
+Examples:
+  dsa template edit solution
+  dsa template edit solution --workspace
+  dsa template edit testgen`,
+	Args: cobra.ExactArgs(1),
+	Run:  runTemplateEditCommand,
+}
+
+func init() {
+	templateCmd.AddCommand(templateEditCmd)
+	templateEditCmd.Flags().BoolVar(&templateEditWorkspace, "workspace", false, "Edit the workspace template in .dsa/templates")
+}
+
+func runTemplateEditCommand(cmd *cobra.Command, args []string) {
+	name := args[0]
+	scope := templateScope(templateEditWorkspace)
+
+	path, created, err := templates.NewLoader().Override(name, scope)
+	if err != nil {
+		exitOnTemplateError(err)
+	}
+
+	if created {
+		fmt.Printf("✓ Created %s template: %s\n", scope, path)
+	}
+
+	editorCmd, err := editorpkg.Launch(editorpkg.Target{Path: path})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to open editor: %v\n", err)
+		fmt.Printf("Edit the template at: %s\n", path)
+		os.Exit(0)
+	}
+
+	fmt.Printf("✓ Opened %s in %s\n", path, editorCmd)
+	os.Exit(0)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List templates and where each is loaded from",
+	Long: `List every template with the scope it is loaded from: workspace
+(.dsa/templates), global (~/.dsa/templates) or built-in.
+
+Examples:
+  dsa template list`,
+	Args: cobra.NoArgs,
+	Run:  runTemplateListCommand,
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+}
+
+func runTemplateListCommand(cmd *cobra.Command, args []string) {
+	list, err := templates.NewLoader().List()
+	if err != nil {
+		exitOnTemplateError(err)
+	}
+
+	fmt.Printf("%-12s  %-9s  %s\n", "Template", "Source", "Description")
+	fmt.Printf("%-12s  %-9s  %s\n", "============", "=========", "===========")
+	for _, tmpl := range list {
+		fmt.Printf("%-12s  %-9s  %s\n", tmpl.Name, tmpl.Scope, tmpl.Description)
+		if tmpl.Path != "" {
+			fmt.Printf("%-12s  %-9s  %s\n", "", "", tmpl.Path)
+		}
+	}
+
+	fmt.Println("\nUse 'dsa template edit <name>' to customize a template")
+	os.Exit(0)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var templateResetWorkspace bool
+
+var templateResetCmd = &cobra.Command{
+	Use:   "reset <name>",
+	Short: "Remove a template override",
+	Long: `Remove the global override of a template, or the workspace override with
+--workspace. The template then falls back to the next scope: workspace, then
+global, then built-in.
+
+Examples:
+  dsa template reset solution
+  dsa template reset solution --workspace`,
+	Args: cobra.ExactArgs(1),
+	Run:  runTemplateResetCommand,
+}
+
+func init() {
+	templateCmd.AddCommand(templateResetCmd)
+	templateResetCmd.Flags().BoolVar(&templateResetWorkspace, "workspace", false, "Remove the workspace template in .dsa/templates")
+}
+
+func runTemplateResetCommand(cmd *cobra.Command, args []string) {
+	name := args[0]
+	scope := templateScope(templateResetWorkspace)
+	loader := templates.NewLoader()
+
+	removed, err := loader.Reset(name, scope)
+	if err != nil {
+		exitOnTemplateError(err)
+	}
+
+	if removed == "" {
+		fmt.Printf("No %s override of the %s template\n", scope, name)
+	} else {
+		fmt.Printf("✓ Removed %s\n", removed)
+	}
+
+	// Say which template is now in effect
+	if tmpl, err := loader.Resolve(name); err == nil {
+		if tmpl.Path != "" {
+			fmt.Printf("The %s template now comes from the %s override: %s\n", name, tmpl.Scope, tmpl.Path)
+		} else {
+			fmt.Printf("The %s template is now the built-in\n", name)
+		}
+	}
+
+	os.Exit(0)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+var (
+	templateShowBuiltin bool
+	templateShowFields  bool
+)
+
+var templateShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Print a template or the data it can use",
+	Long: `Print the source of the template that is in effect.
+
+Options:
+  --builtin   Print the built-in template, ignoring overrides
+  --fields    List the data fields and functions the template can use
+
+Examples:
+  dsa template show solution
+  dsa template show solution --builtin
+  dsa template show testgen --fields`,
+	Args: cobra.ExactArgs(1),
+	Run:  runTemplateShowCommand,
+}
+
+func init() {
+	templateCmd.AddCommand(templateShowCmd)
+	templateShowCmd.Flags().BoolVar(&templateShowBuiltin, "builtin", false, "Print the built-in template")
+	templateShowCmd.Flags().BoolVar(&templateShowFields, "fields", false, "List the data fields and functions the template can use")
+}
+
+func runTemplateShowCommand(cmd *cobra.Command, args []string) {
+	name := args[0]
+	loader := templates.NewLoader()
+
+	var tmpl *templates.Template
+	var err error
+	if templateShowBuiltin {
+		tmpl, err = loader.Builtin(name)
+	} else {
+		tmpl, err = loader.Resolve(name)
+	}
+	if err != nil {
+		exitOnTemplateError(err)
+	}
+
+	if templateShowFields {
+		printTemplateFields(name)
+		os.Exit(0)
+	}
+
+	fmt.Print(tmpl.Source)
+	os.Exit(0)
+}
+
+// printTemplateFields lists the data fields and functions of a template
+func printTemplateFields(name string) {
+	fmt.Printf("Data available to the %s template:\n\n", name)
+	for _, field := range templates.FieldsFor(name, templates.Fields) {
+		fmt.Printf("  %-18s %-10s %s\n", field.Name, field.Type, field.Doc)
+	}
+
+	fmt.Printf("\nFunctions:\n\n")
+	for _, function := range templates.FieldsFor(name, templates.Functions) {
+		fmt.Printf("  %-18s %s\n", function.Name, function.Doc)
+	}
+}
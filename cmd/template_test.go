@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/templates"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateCommand(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"template"})
+	assert.NoError(t, err)
+	assert.Equal(t, "template", cmd.Name())
+	assert.Contains(t, cmd.Long, ".dsa/templates/<name>.tmpl")
+	assert.Contains(t, cmd.Long, "~/.dsa/templates/<name>.tmpl")
+
+	// Every template is described in the help
+	for _, name := range templates.Names() {
+		assert.Contains(t, cmd.Long, name)
+	}
+}
+
+func TestTemplateSubcommands(t *testing.T) {
+	for _, name := range []string{"list", "show", "edit", "reset"} {
+		cmd, _, err := rootCmd.Find([]string{"template", name})
+		assert.NoError(t, err)
+		assert.Equal(t, name, cmd.Name())
+	}
+
+	show, _, _ := rootCmd.Find([]string{"template", "show"})
+	assert.NotNil(t, show.Flags().Lookup("builtin"))
+	assert.NotNil(t, show.Flags().Lookup("fields"))
+	assert.Error(t, show.Args(show, []string{}))
+	assert.NoError(t, show.Args(show, []string{"solution"}))
+
+	edit, _, _ := rootCmd.Find([]string{"template", "edit"})
+	assert.NotNil(t, edit.Flags().Lookup("workspace"))
+
+	reset, _, _ := rootCmd.Find([]string{"template", "reset"})
+	assert.NotNil(t, reset.Flags().Lookup("workspace"))
+}
+
+func TestTemplateScope(t *testing.T) {
+	assert.Equal(t, templates.ScopeGlobal, templateScope(false))
+	assert.Equal(t, templates.ScopeWorkspace, templateScope(true))
+}
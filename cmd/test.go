@@ -1,26 +1,40 @@
 package cmd
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"os"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/editor"
 	"github.com/ak95asb/dsa-dojo/internal/output"
 	"github.com/ak95asb/dsa-dojo/internal/problem"
 	"github.com/ak95asb/dsa-dojo/internal/progress"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
 	testingpkg "github.com/ak95asb/dsa-dojo/internal/testing"
 	"github.com/spf13/cobra"
 )
 
 var (
-	testVerbose bool
-	testRace    bool
-	testWatch   bool
+	testVerbose    bool
+	testRace       bool
+	testWatch      bool
+	testVariant    string
+	testAll        bool
+	testTopic      string
+	testDifficulty string
+	testSolved     bool
+	testJobs       int
+	testNoCache    bool
+	testCover      bool
 )
 
 var testCmd = &cobra.Command{
-	Use:   "test [problem-id]",
+	Use:   "test [problem-id] | --all",
 	Short: "Run tests for a problem solution",
 	Long: `Execute Go tests for your solution and display results.
 
@@ -29,14 +43,48 @@ The command:
   - Shows colored pass/fail status
   - Updates progress when all tests pass
   - Supports verbose and race detection modes
+  - Tests a named solution variant with --variant; the problem counts as
+    solved once any variant passes, and analytics list solved variants
+
+--cover measures the coverage of the solution file alone: the share of its
+statements the tests run, per function, and the solution source with a
+marker per line (✓ run, ✗ not run, ~ partly run), so edge branches the test
+cases miss stand out. The percentage is stored with the test run.
+
+When the code does not compile, the errors are listed by file, those in your
+solution first, each with its source line and a caret under the column. In
+a terminal, the command offers to open the editor at the first error, using
+the {file}, {line} and {column} placeholders of editor_args, e.g.:
+
+  dsa config set editor code
+  dsa config set editor_args "--goto {file}:{line}:{column}"
+
+Results are cached by the contents of the solution and test files, the
+structures helpers, go.mod and go.sum, and the Go version. Re-running unchanged code shows the cached result, marked (cached),
+without running go test or counting an attempt. --no-cache runs the tests
+anyway.
+
+With --all, every problem matching --topic, --difficulty and --solved is
+tested concurrently by --jobs workers (one per CPU by default). A row is
+printed as each problem finishes and results are recorded like single runs.
+Problems without tests are skipped. The command exits with status 1 if a
+problem that was solved before the run now fails, so it can guard refactors
+of shared helper code:
+
+  dsa test --all --solved
 
 Examples:
   dsa test two-sum
   dsa test binary-search --verbose
   dsa test merge-intervals --race
   dsa test quick-sort --watch
-  dsa test quick-sort --watch --verbose`,
-	Args: cobra.ExactArgs(1),
+  dsa test quick-sort --watch --verbose
+  dsa test two-sum --variant hashmap
+  dsa test two-sum --no-cache
+  dsa test two-sum --cover
+  dsa test --all
+  dsa test --all --topic trees --difficulty medium --jobs 4`,
+	Args: validateTestArgs,
 	Run:  runTestCommand,
 }
 
@@ -45,10 +93,36 @@ func init() {
 	testCmd.Flags().BoolVarP(&testVerbose, "verbose", "v", false, "Show detailed test output")
 	testCmd.Flags().BoolVar(&testRace, "race", false, "Run tests with race detector")
 	testCmd.Flags().BoolVarP(&testWatch, "watch", "w", false, "Watch for file changes and re-run tests")
+	testCmd.Flags().StringVar(&testVariant, "variant", "", "Test a named solution variant (see 'dsa solve --variant')")
+	testCmd.Flags().BoolVar(&testAll, "all", false, "Test every problem matching the filters")
+	testCmd.Flags().StringVarP(&testTopic, "topic", "t", "", "With --all, only test problems of a topic")
+	testCmd.Flags().StringVarP(&testDifficulty, "difficulty", "d", "", "With --all, only test problems of a difficulty (easy, medium, hard)")
+	testCmd.Flags().BoolVar(&testSolved, "solved", false, "With --all, only test solved problems")
+	testCmd.Flags().BoolVar(&testCover, "cover", false, "Measure which statements of the solution the tests run")
+	testCmd.Flags().BoolVar(&testNoCache, "no-cache", false, "Run the tests even if the code is unchanged since the last run")
+	testCmd.Flags().IntVarP(&testJobs, "jobs", "j", runtime.NumCPU(), "With --all, number of problems to test at once")
+}
+
+// validateTestArgs requires a problem unless --all is set
+func validateTestArgs(cmd *cobra.Command, args []string) error {
+	if testAll {
+		return cobra.NoArgs(cmd, args)
+	}
+	return cobra.ExactArgs(1)(cmd, args)
 }
 
 func runTestCommand(cmd *cobra.Command, args []string) {
+	if testAll {
+		runTestAll()
+		return
+	}
+	if testTopic != "" || testDifficulty != "" || testSolved {
+		fmt.Fprintln(os.Stderr, "Error: --topic, --difficulty and --solved require --all")
+		os.Exit(2) // ExitUsageError
+	}
+
 	slug := args[0]
+	variant := parseVariantFlag(testVariant)
 
 	// Initialize database
 	db, err := database.Initialize()
@@ -73,12 +147,16 @@ func runTestCommand(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	requireVariantFile(slug, variant)
+
 	// Create test service
 	testSvc := testingpkg.NewService(db)
+	testSvc.UseCache(!testNoCache)
+	testSvc.SetCover(testCover)
 
 	// Route to watch mode if --watch flag is set
 	if testWatch {
-		if err := testSvc.Watch(prob, problemSvc, testVerbose, testRace); err != nil {
+		if err := testSvc.WatchVariant(prob, problemSvc, variant, testVerbose, testRace); err != nil {
 			fmt.Fprintf(os.Stderr, "Error in watch mode: %v\n", err)
 			os.Exit(1)
 		}
@@ -86,7 +164,7 @@ func runTestCommand(cmd *cobra.Command, args []string) {
 	}
 
 	// Execute tests (normal mode)
-	result, err := testSvc.ExecuteTests(prob, testVerbose, testRace)
+	result, err := testSvc.ExecuteVariantTests(prob, variant, testVerbose, testRace)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running tests: %v\n", err)
 		os.Exit(1)
@@ -94,13 +172,27 @@ func runTestCommand(cmd *cobra.Command, args []string) {
 
 	// Display results
 	testSvc.DisplayResults(result)
+	if testCover && result.Coverage == nil {
+		fmt.Fprintf(os.Stderr, "Warning: Coverage not measured: it needs a solution file (%s) whose tests compile\n", solution.VariantPath(slug, variant))
+	}
+	if len(result.Diagnostics) > 0 {
+		offerToOpenError(result.Diagnostics[0])
+	}
+
+	// A cached result was recorded when the tests ran
+	if result.Cached {
+		if !result.AllPassed {
+			os.Exit(1) // Tests failed
+		}
+		os.Exit(0)
+	}
 
 	// Track progress (for both passed and failed tests)
 	tracker := progress.NewTracker(db)
-	filePath := fmt.Sprintf("problems/%s/solution.go", prob.Slug)
-	isFirstTimeSolve, err := tracker.TrackTestCompletion(
+	isFirstTimeSolve, err := tracker.TrackVariantTestCompletion(
 		prob.ID,
-		filePath,
+		variant,
+		solutionFilePath(slug, variant),
 		result.AllPassed,
 		result.PassedCount,
 		result.TotalCount,
@@ -108,6 +200,10 @@ func runTestCommand(cmd *cobra.Command, args []string) {
 	if err != nil {
 		// Log error but don't fail the command - progress tracking is non-critical
 		fmt.Fprintf(os.Stderr, "Warning: Failed to update progress: %v\n", err)
+	} else if result.Coverage != nil {
+		if err := tracker.RecordCoverage(prob.ID, variant, result.Coverage.Percent()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to store coverage: %v\n", err)
+		}
 	}
 
 	// Display celebration message on first-time solve
@@ -134,3 +230,125 @@ func runTestCommand(cmd *cobra.Command, args []string) {
 		os.Exit(1) // Tests failed
 	}
 }
+
+// solutionFilePath is the solution file recorded with a test run
+func solutionFilePath(slug, variant string) string {
+	if variant != "" {
+		return solution.VariantPath(slug, variant)
+	}
+	return fmt.Sprintf("problems/%s/solution.go", slug)
+}
+
+// runTestAll tests every problem matching the filters concurrently and exits
+// with status 1 if a previously solved problem regressed
+func runTestAll() {
+	if testWatch || testVariant != "" {
+		fmt.Fprintln(os.Stderr, "Error: --all cannot be combined with --watch or --variant")
+		os.Exit(2) // ExitUsageError
+	}
+	if testJobs < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --jobs must be at least 1, got %d\n", testJobs)
+		os.Exit(2)
+	}
+	if testDifficulty != "" && !problem.IsValidDifficulty(testDifficulty) {
+		fmt.Fprintf(os.Stderr, "Error: Invalid difficulty '%s'. Must be one of: easy, medium, hard\n", testDifficulty)
+		os.Exit(2)
+	}
+	if testTopic != "" && !problem.IsValidTopic(testTopic) {
+		fmt.Fprintf(os.Stderr, "Error: Invalid topic '%s'. Must be one of: arrays, linked-lists, trees, graphs, sorting, searching\n", testTopic)
+		os.Exit(2)
+	}
+
+	db, err := database.Initialize()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to connect to database: %v\n", err)
+		os.Exit(3) // ExitDatabaseError
+	}
+	defer func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}()
+
+	filters := problem.ListFilters{Difficulty: testDifficulty, Topic: testTopic}
+	if testSolved {
+		solved := true
+		filters.Solved = &solved
+	}
+	problems, err := problem.NewService(db).ListProblems(filters)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list problems: %v\n", err)
+		os.Exit(1)
+	}
+	if len(problems) == 0 {
+		fmt.Println("No problems found matching the specified filters.")
+		return
+	}
+
+	testSvc := testingpkg.NewService(db)
+	testSvc.UseCache(!testNoCache)
+	testSvc.SetCover(testCover)
+	tracker := progress.NewTracker(db)
+	workers := min(testJobs, len(problems))
+	testSvc.DisplayBatchHeader(len(problems), workers)
+
+	// Results are recorded here as they arrive rather than by the workers,
+	// so database writes never overlap
+	start := time.Now()
+	var results []*testingpkg.BatchResult
+	for result := range testSvc.RunAll(problems, workers, testVerbose, testRace) {
+		results = append(results, result)
+		testSvc.DisplayBatchRow(len(results), len(problems), result)
+
+		if result.Result == nil || result.Result.Cached {
+			continue
+		}
+		_, err := tracker.TrackTestCompletion(
+			result.Problem.ID,
+			solutionFilePath(result.Problem.Slug, ""),
+			result.Result.AllPassed,
+			result.Result.PassedCount,
+			result.Result.TotalCount,
+		)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to update progress for %s: %v\n", result.Problem.Slug, err)
+		} else if result.Result.Coverage != nil {
+			if err := tracker.RecordCoverage(result.Problem.ID, "", result.Result.Coverage.Percent()); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: Failed to store coverage for %s: %v\n", result.Problem.Slug, err)
+			}
+		}
+	}
+	testSvc.DisplayBatchSummary(results, time.Since(start))
+
+	regressed := false
+	for _, result := range results {
+		if testVerbose && result.Result != nil && !result.Result.AllPassed {
+			fmt.Printf("\n── %s ──\n", result.Problem.Slug)
+			testSvc.DisplayResults(result.Result)
+		}
+		regressed = regressed || result.Regressed()
+	}
+	if regressed {
+		os.Exit(1)
+	}
+}
+
+// offerToOpenError asks whether to open the editor at a compile error. It
+// only asks when both stdin and stdout are terminals.
+func offerToOpenError(d testingpkg.Diagnostic) {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return
+	}
+
+	fmt.Printf("\nOpen %s at line %d in your editor? [Y/n]: ", d.File, d.Line)
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		return
+	}
+	if answer := strings.ToLower(strings.TrimSpace(scanner.Text())); answer != "" && answer != "y" && answer != "yes" {
+		return
+	}
+
+	if err := editor.LaunchAt(d.File, d.Line, max(d.Column, 1)); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
@@ -239,3 +239,26 @@ func TestTestCommandFlags(t *testing.T) {
 		assert.NotNil(t, raceFlag, "race flag should exist")
 	})
 }
+
+func TestTestCommandAllFlags(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"test"})
+	assert.NoError(t, err)
+
+	for _, name := range []string{"all", "topic", "difficulty", "solved", "jobs", "no-cache", "cover"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "%s flag should exist", name)
+	}
+	assert.Equal(t, "j", cmd.Flags().Lookup("jobs").Shorthand)
+	assert.Contains(t, cmd.Long, "dsa test --all --solved")
+
+	t.Run("problem required without --all", func(t *testing.T) {
+		assert.Error(t, validateTestArgs(cmd, nil))
+		assert.NoError(t, validateTestArgs(cmd, []string{"two-sum"}))
+	})
+
+	t.Run("no problem with --all", func(t *testing.T) {
+		testAll = true
+		defer func() { testAll = false }()
+		assert.NoError(t, validateTestArgs(cmd, nil))
+		assert.Error(t, validateTestArgs(cmd, []string{"two-sum"}))
+	})
+}
@@ -14,25 +14,69 @@ import (
 var (
 	testGenAppend   bool
 	testGenFromFile string
+	testGenCompare  string
 )
 
 var testGenCmd = &cobra.Command{
 	Use:   "test-gen [problem-id]",
 	Short: "Generate test cases for a custom problem",
-	Long: `Interactively generate test cases or import from JSON file.
+	Long: `Interactively generate test cases or import them from a JSON, YAML or CSV file.
 
 The command:
   - Prompts for test case inputs interactively (default)
-  - Imports test cases from JSON file (--from-file)
-  - Appends to existing test file (--append)
+  - Imports test cases from a .json, .yaml/.yml or .csv file (--from-file)
+  - Appends to existing test file (--append), skipping cases whose inputs
+    are already in its table and keeping tests and comments written by hand
   - Generates table-driven tests with testify/assert
   - Follows Go testing conventions
 
+Values are written as literals of the function's parameter and result types,
+read from solutions/<name>.go (or the problem's boilerplate): [1,2] becomes
+[]int64{1, 2} for an []int64 parameter, null becomes nil and [] an empty
+slice. Objects fill structs and maps, and a function with several results
+expects an array of values. A value that does not fit its type is reported
+with the test case and parameter it belongs to.
+
+Linked lists, binary trees and graphs are written in LeetCode notation, as a
+string or an array: "[1,2,3]" for a list, "[3,9,20,null,null,15,7]" for a tree
+in level order and "[[2,4],[1,3],[2,4],[1,3]]" for a graph's adjacency lists.
+Any node type shaped like the ones in the workspace's structures package works,
+whether the solution imports that package or declares its own TreeNode.
+Results are compared in the same notation, so failures read the same way.
+
+Problems with more than one right answer can compare results differently:
+  exact                    The same value (default)
+  unordered                The same elements in any order
+  unordered-nested         In any order at every level, e.g. group anagrams
+  set                      The same distinct elements
+  float-tolerance:1e-6     Floats within the tolerance
+  <function>               A checker func(want, got, inputs...) bool
+A test case's "compare" wins over the file's, then --compare, then a
+"//dsa:compare <mode>" line in the doc comment of the solution's function.
+A checker missing from the solution and from problems/<name>_check_test.go is
+added there as a stub to complete.
+
+JSON file format:
+  {"compare": "unordered",
+   "tests": [{"name": "basic", "inputs": [[2,7,11,15], 9], "expected": [0,1]},
+             {"name": "exact", "inputs": [[3,3], 6], "expected": [0,1], "compare": "exact"}]}
+
+YAML files have the same fields. CSV files have a header row with name,
+expected and optionally compare columns; every other column is an input, in
+order. Cells are JSON values, or plain text for strings:
+  name,nums,target,expected
+  basic,"[2,7,11,15]",9,"[0,1]"
+
+'dsa test-gen export' writes a problem's test table back to JSON or YAML.
+
 Examples:
   dsa test-gen my-problem
   dsa test-gen my-problem --from-file tests.json
+  dsa test-gen my-problem --from-file cases.yaml
   dsa test-gen my-problem --append
-  dsa test-gen my-problem --append --from-file tests.json`,
+  dsa test-gen my-problem --append --from-file tests.json
+  dsa test-gen three-sum --from-file tests.json --compare unordered-nested
+  dsa test-gen my-problem --compare validPartition`,
 	Args: cobra.ExactArgs(1),
 	Run:  runTestGenCommand,
 }
@@ -40,7 +84,8 @@ Examples:
 func init() {
 	rootCmd.AddCommand(testGenCmd)
 	testGenCmd.Flags().BoolVarP(&testGenAppend, "append", "a", false, "Append to existing test file")
-	testGenCmd.Flags().StringVarP(&testGenFromFile, "from-file", "f", "", "Import test cases from JSON file")
+	testGenCmd.Flags().StringVarP(&testGenFromFile, "from-file", "f", "", "Import test cases from a JSON, YAML or CSV file")
+	testGenCmd.Flags().StringVar(&testGenCompare, "compare", "", "Comparison of test cases that do not name one (e.g. unordered, float-tolerance:1e-6 or a checker function)")
 }
 
 func runTestGenCommand(cmd *cobra.Command, args []string) {
@@ -71,6 +116,12 @@ func runTestGenCommand(cmd *cobra.Command, args []string) {
 
 	// Create testgen service
 	testGenSvc := testgen.NewService()
+	if testGenCompare != "" {
+		if err := testGenSvc.SetCompare(testGenCompare); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid --compare: %v\n", err)
+			os.Exit(2) // ExitUsageError
+		}
+	}
 
 	// Route to appropriate mode
 	if testGenFromFile != "" {
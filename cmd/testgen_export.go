@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ak95asb/dsa-dojo/internal/testgen"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testGenExportOutput string
+	testGenExportFormat string
+)
+
+var testGenExportCmd = &cobra.Command{
+	Use:   "export <problem-id>",
+	Short: "Write a problem's test cases to a JSON or YAML file",
+	Long: `Read the table of test cases in problems/<name>_test.go (or the reference tests
+shipped with dsa) and write it in the format 'dsa test-gen --from-file' reads,
+so shared case files and test files can be kept in sync.
+
+Values are written back as JSON values: lists, trees and graphs in LeetCode
+notation, and several expected results as an array. A comparison used by every
+case is written once for the file.
+
+The format follows the --output extension (.yaml or .yml for YAML), or
+--format; without --output the cases are printed.
+
+Examples:
+  dsa test-gen export two-sum
+  dsa test-gen export two-sum --output cases/two-sum.yaml
+  dsa test-gen export two-sum --format yaml
+
+Exit Codes:
+  0 - Success
+  1 - The test file could not be read
+  2 - Invalid format, or the problem has no tests`,
+	Args: cobra.ExactArgs(1),
+	Run:  runTestGenExportCommand,
+}
+
+func init() {
+	testGenCmd.AddCommand(testGenExportCmd)
+	testGenExportCmd.Flags().StringVarP(&testGenExportOutput, "output", "o", "", "Write the cases to this file instead of standard output")
+	testGenExportCmd.Flags().StringVar(&testGenExportFormat, "format", "", "Output format: json or yaml (default from the --output extension, else json)")
+}
+
+func runTestGenExportCommand(cmd *cobra.Command, args []string) {
+	slug := args[0]
+
+	format := testGenExportFormat
+	if format == "" {
+		format = testgen.ExportFormat(testGenExportOutput)
+	}
+	if format != testgen.FormatJSON && format != testgen.FormatYAML {
+		fmt.Fprintf(os.Stderr, "Error: invalid --format %q (use json or yaml)\n", format)
+		os.Exit(2) // ExitUsageError
+	}
+
+	var buf bytes.Buffer
+	count, err := testgen.NewService().Export(slug, &buf, format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, testgen.ErrNoTests) {
+			os.Exit(2) // ExitUsageError
+		}
+		os.Exit(1)
+	}
+
+	if testGenExportOutput == "" {
+		fmt.Print(buf.String())
+		os.Exit(0)
+	}
+	if err := os.WriteFile(testGenExportOutput, buf.Bytes(), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to write %s: %v\n", testGenExportOutput, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Exported %d test case(s) to %s\n", count, testGenExportOutput)
+	os.Exit(0)
+}
@@ -87,7 +87,7 @@ func TestTestGenCommand_HelpText(t *testing.T) {
 
 	// Verify help text contains key information
 	assert.Contains(t, cmd.Long, "Interactively generate test cases")
-	assert.Contains(t, cmd.Long, "Imports test cases from JSON file")
+	assert.Contains(t, cmd.Long, "Imports test cases from a .json, .yaml/.yml or .csv file")
 	assert.Contains(t, cmd.Long, "Appends to existing test file")
 
 	// Verify examples are present
@@ -95,3 +95,12 @@ func TestTestGenCommand_HelpText(t *testing.T) {
 	assert.Contains(t, cmd.Long, "dsa test-gen my-problem --from-file tests.json")
 	assert.Contains(t, cmd.Long, "dsa test-gen my-problem --append")
 }
+
+func TestTestGenExportCommand(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"test-gen", "export"})
+	assert.NoError(t, err)
+	assert.Equal(t, "export", cmd.Name())
+	assert.NotNil(t, cmd.Flags().Lookup("output"))
+	assert.NotNil(t, cmd.Flags().Lookup("format"))
+	assert.Error(t, cmd.Args(cmd, []string{}), "a problem is required")
+}
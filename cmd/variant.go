@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+)
+
+// parseVariantFlag validates a --variant value and returns its stored form
+// (empty for the default solution). Exits with a usage error when invalid.
+func parseVariantFlag(name string) string {
+	if err := solution.ValidateVariant(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2) // ExitUsageError
+	}
+	return solution.NormalizeVariant(name)
+}
+
+// requireVariantFile exits with an error if a named variant has no solution
+// file yet. The default solution is checked by each command as before.
+func requireVariantFile(slug, variant string) {
+	if variant == "" {
+		return
+	}
+
+	path := solution.VariantPath(slug, variant)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Solution file not found: %s\n", path)
+		fmt.Fprintf(os.Stderr, "Run 'dsa solve %s --variant %s' to create it.\n", slug, variant)
+		if variants, _ := solution.ListVariants(slug); len(variants) > 0 {
+			fmt.Fprintf(os.Stderr, "Existing variants: %s\n", strings.Join(variants, ", "))
+		}
+		os.Exit(1)
+	}
+}
+
+// variantSuffix describes a named variant in status messages
+func variantSuffix(variant string) string {
+	if variant == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (variant: %s)", variant)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariantFlag(t *testing.T) {
+	for _, name := range []string{"solve", "test", "bench", "submit", "history"} {
+		t.Run(name, func(t *testing.T) {
+			cmd, _, err := rootCmd.Find([]string{name})
+			assert.NoError(t, err)
+
+			flag := cmd.Flags().Lookup("variant")
+			assert.NotNil(t, flag, "%s should accept --variant", name)
+			assert.Equal(t, "", flag.DefValue)
+			assert.Contains(t, cmd.Long, "--variant")
+		})
+	}
+}
+
+func TestVariantSuffix(t *testing.T) {
+	assert.Equal(t, "", variantSuffix(""))
+	assert.Equal(t, " (variant: hashmap)", variantSuffix("hashmap"))
+}
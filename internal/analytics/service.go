@@ -2,6 +2,7 @@ package analytics
 
 import (
 	"fmt"
+	"sort"
 
 	"gorm.io/gorm"
 )
@@ -19,16 +20,18 @@ type AnalyticsFilter struct {
 
 // AnalyticsStats contains calculated analytics data
 type AnalyticsStats struct {
-	OverallSuccessRate      float64            `json:"overall_success_rate"`
-	SuccessRateByDifficulty map[string]float64 `json:"success_rate_by_difficulty"`
-	SuccessRateByTopic      map[string]float64 `json:"success_rate_by_topic"`
-	AvgAttemptsOverall      float64            `json:"avg_attempts_overall"`
-	AvgAttemptsByDifficulty map[string]float64 `json:"avg_attempts_by_difficulty"`
-	AvgAttemptsByTopic      map[string]float64 `json:"avg_attempts_by_topic"`
-	MostPracticedTopic      string             `json:"most_practiced_topic"`
-	LeastPracticedTopic     string             `json:"least_practiced_topic"`
-	BestDifficulty          string             `json:"best_difficulty"`
-	ChallengingDifficulty   string             `json:"challenging_difficulty"`
+	OverallSuccessRate      float64             `json:"overall_success_rate"`
+	SuccessRateByDifficulty map[string]float64  `json:"success_rate_by_difficulty"`
+	SuccessRateByTopic      map[string]float64  `json:"success_rate_by_topic"`
+	AvgAttemptsOverall      float64             `json:"avg_attempts_overall"`
+	AvgAttemptsByDifficulty map[string]float64  `json:"avg_attempts_by_difficulty"`
+	AvgAttemptsByTopic      map[string]float64  `json:"avg_attempts_by_topic"`
+	MostPracticedTopic      string              `json:"most_practiced_topic"`
+	LeastPracticedTopic     string              `json:"least_practiced_topic"`
+	BestDifficulty          string              `json:"best_difficulty"`
+	ChallengingDifficulty   string              `json:"challenging_difficulty"`
+	ApproachesByProblem     map[string][]string `json:"approaches_by_problem,omitempty"` // Problem slug -> solved variants
+	MultiApproachProblems   int                 `json:"multi_approach_problems"`         // Problems solved with 2+ variants
 }
 
 // NewAnalyticsService creates a new analytics service instance
@@ -97,6 +100,18 @@ func (s *AnalyticsService) CalculateStats(filter AnalyticsFilter) (*AnalyticsSta
 	stats.BestDifficulty = patterns.BestDifficulty
 	stats.ChallengingDifficulty = patterns.ChallengingDifficulty
 
+	// Collect solved approaches (solution variants) per problem
+	approaches, err := s.calculateApproachesByProblem(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate approaches by problem: %w", err)
+	}
+	stats.ApproachesByProblem = approaches
+	for _, variants := range approaches {
+		if len(variants) > 1 {
+			stats.MultiApproachProblems++
+		}
+	}
+
 	return stats, nil
 }
 
@@ -361,3 +376,44 @@ func (s *AnalyticsService) analyzePracticePatterns(filter AnalyticsFilter) (*Pra
 
 	return patterns, nil
 }
+
+// defaultApproach labels solutions recorded without a variant
+const defaultApproach = "default"
+
+func (s *AnalyticsService) calculateApproachesByProblem(filter AnalyticsFilter) (map[string][]string, error) {
+	type Result struct {
+		Slug    string
+		Variant string
+	}
+
+	var results []Result
+	query := s.db.Table("solutions").
+		Select("DISTINCT problems.slug, solutions.variant").
+		Joins("INNER JOIN problems ON solutions.problem_id = problems.id").
+		Where("solutions.passed = ? OR solutions.status = ?", true, "Passed")
+
+	if filter.Topic != "" {
+		query = query.Where("problems.topic = ?", filter.Topic)
+	}
+	if filter.Difficulty != "" {
+		query = query.Where("problems.difficulty = ?", filter.Difficulty)
+	}
+
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	approaches := make(map[string][]string)
+	for _, r := range results {
+		variant := r.Variant
+		if variant == "" {
+			variant = defaultApproach
+		}
+		approaches[r.Slug] = append(approaches[r.Slug], variant)
+	}
+	for _, variants := range approaches {
+		sort.Strings(variants)
+	}
+
+	return approaches, nil
+}
@@ -253,3 +253,38 @@ func TestCalculateStats_AllSolved(t *testing.T) {
 	assert.Equal(t, 100.0, stats.OverallSuccessRate)
 	assert.InDelta(t, 1.5, stats.AvgAttemptsOverall, 0.1) // (1+2)/2
 }
+
+func TestCalculateStats_ApproachesByProblem(t *testing.T) {
+	db := setupTestDB(t)
+	seedTestData(db, t)
+	service := NewAnalyticsService(db)
+
+	solutions := []database.Solution{
+		{ProblemID: 1, Passed: true, Status: "Passed"},
+		{ProblemID: 1, Passed: true, Status: "Passed", Variant: "hashmap"},
+		{ProblemID: 1, Status: "Failed", Variant: "brute"},
+		{ProblemID: 1, Status: "Passed", Variant: "hashmap"},
+		{ProblemID: 2, Status: "Passed", Variant: "stack"},
+		{ProblemID: 4, Status: "Passed", Variant: "sliding-window"},
+		{ProblemID: 4, Status: "Passed", Variant: "brute"},
+	}
+	for _, s := range solutions {
+		require.NoError(t, db.Create(&s).Error)
+	}
+
+	stats, err := service.CalculateStats(AnalyticsFilter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"default", "hashmap"}, stats.ApproachesByProblem["two-sum"])
+	assert.Equal(t, []string{"stack"}, stats.ApproachesByProblem["valid-parentheses"])
+	assert.Equal(t, []string{"brute", "sliding-window"}, stats.ApproachesByProblem["longest-substring"])
+	assert.Equal(t, 2, stats.MultiApproachProblems)
+
+	t.Run("applies filters", func(t *testing.T) {
+		stats, err := service.CalculateStats(AnalyticsFilter{Topic: "arrays"})
+		require.NoError(t, err)
+
+		assert.Len(t, stats.ApproachesByProblem, 1)
+		assert.Equal(t, 1, stats.MultiApproachProblems)
+	})
+}
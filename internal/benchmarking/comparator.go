@@ -4,45 +4,164 @@ import (
 	"github.com/ak95asb/dsa-dojo/internal/database"
 )
 
+// MinSamples is the fewest samples each side needs for the Mann-Whitney U
+// test. With fewer, e.g. against results saved before samples were kept,
+// the test cannot reach significance, so deltas are compared against the
+// threshold alone.
+const MinSamples = 4
+
 // Comparator handles comparing benchmark results
-type Comparator struct{}
+type Comparator struct {
+	Alpha float64 // Significance level for the Mann-Whitney U test
+}
 
 // NewComparator creates a new comparator
 func NewComparator() *Comparator {
-	return &Comparator{}
+	return &Comparator{Alpha: DefaultAlpha}
 }
 
-// ComparisonResult represents the comparison between current and previous benchmarks
+// ComparisonResult represents the comparison between current and previous benchmarks.
+// Deltas compare medians; p-values come from a Mann-Whitney U test over all samples.
 type ComparisonResult struct {
+	BenchmarkName      string
 	TimeDeltaPercent   float64
 	MemoryDeltaPercent float64
 	AllocsDeltaPercent float64
+	TimePValue         float64
+	MemoryPValue       float64
+	AllocsPValue       float64
+	OldSamples         int
+	NewSamples         int
+	Alpha              float64 // Significance level used; zero when no test was performed
+	TooFewSamples      bool    // The test was skipped for lack of samples
 	IsNewBest          bool
 }
 
-// Compare compares current result with previous best
+// Significant reports whether a p-value indicates a real change.
+// Without a significance level every non-zero delta is reported.
+func (c *ComparisonResult) Significant(pValue float64) bool {
+	return c.Alpha == 0 || pValue <= c.Alpha
+}
+
+// isFaster reports whether time per operation got significantly faster. Without
+// enough samples for a test no change counts as faster.
+func (c *ComparisonResult) isFaster() bool {
+	return !c.TooFewSamples && c.Significant(c.TimePValue) && c.TimeDeltaPercent < 0
+}
+
+// IsRegression reports whether time per operation got significantly slower
+// by more than thresholdPercent
+func (c *ComparisonResult) IsRegression(thresholdPercent float64) bool {
+	return c.Significant(c.TimePValue) && c.TimeDeltaPercent > thresholdPercent
+}
+
+// Compare compares the current result with a previous one, usually the most
+// recent saved run. IsNewBest only reports whether it is significantly faster
+// than that result; callers comparing against a result other than the best
+// set it themselves.
 func (c *Comparator) Compare(current *BenchmarkResult, previous *database.BenchmarkResult) *ComparisonResult {
 	if previous == nil {
 		// No previous results to compare
 		return &ComparisonResult{
-			IsNewBest: true, // First benchmark is always "best"
+			BenchmarkName: current.BenchmarkName,
+			NewSamples:    current.SampleCount(),
+			IsNewBest:     true, // First benchmark is always "best"
 		}
 	}
 
-	comparison := &ComparisonResult{}
+	oldSamples := SamplesFromModel(previous)
+	newSamples := current.Samples
+	if len(newSamples) == 0 {
+		newSamples = map[string][]float64{
+			"ns/op":     {current.NsPerOp},
+			"B/op":      {current.BytesPerOp},
+			"allocs/op": {current.AllocsPerOp},
+		}
+	}
+
+	comparison := &ComparisonResult{
+		BenchmarkName: current.BenchmarkName,
+	}
 
-	// Calculate time delta percentage
+	// Calculate deltas
 	// Positive = slower (regression), Negative = faster (improvement)
-	comparison.TimeDeltaPercent = ((current.NsPerOp - previous.NsPerOp) / previous.NsPerOp) * 100
+	comparison.TimeDeltaPercent = percentDelta(previous.NsPerOp, current.NsPerOp)
+	comparison.MemoryDeltaPercent = percentDelta(previous.BytesPerOp, current.BytesPerOp)
+	comparison.AllocsDeltaPercent = percentDelta(previous.AllocsPerOp, current.AllocsPerOp)
+	c.test(comparison, oldSamples, newSamples)
+	comparison.IsNewBest = comparison.isFaster()
 
-	// Calculate memory delta percentage
-	comparison.MemoryDeltaPercent = ((current.BytesPerOp - previous.BytesPerOp) / previous.BytesPerOp) * 100
+	return comparison
+}
 
-	// Calculate allocations delta percentage
-	comparison.AllocsDeltaPercent = ((current.AllocsPerOp - previous.AllocsPerOp) / previous.AllocsPerOp) * 100
+// test runs the Mann-Whitney U test on each metric's samples. With fewer
+// than MinSamples on either side no test is performed and the comparison is
+// marked, so deltas are reported, and gated, without significance.
+func (c *Comparator) test(comparison *ComparisonResult, oldSamples, newSamples map[string][]float64) {
+	comparison.OldSamples = len(oldSamples["ns/op"])
+	comparison.NewSamples = len(newSamples["ns/op"])
+	if comparison.OldSamples < MinSamples || comparison.NewSamples < MinSamples {
+		comparison.TooFewSamples = true
+		return
+	}
 
-	// Determine if this is a new best (faster than previous best)
-	comparison.IsNewBest = current.NsPerOp < previous.NsPerOp
+	comparison.Alpha = c.Alpha
+	_, comparison.TimePValue = MannWhitneyU(oldSamples["ns/op"], newSamples["ns/op"])
+	_, comparison.MemoryPValue = MannWhitneyU(oldSamples["B/op"], newSamples["B/op"])
+	_, comparison.AllocsPValue = MannWhitneyU(oldSamples["allocs/op"], newSamples["allocs/op"])
+}
+
+// percentDelta returns the change from old to new as a percentage of old,
+// or zero when old is zero
+func percentDelta(old, new float64) float64 {
+	if old == 0 {
+		return 0
+	}
+	return ((new - old) / old) * 100
+}
+
+// CompareResults compares two freshly measured results, treating baseline as
+// the old value. Used for head-to-head comparisons where neither is stored.
+func (c *Comparator) CompareResults(baseline, candidate *BenchmarkResult) *ComparisonResult {
+	oldSamples, newSamples := baseline.Samples, candidate.Samples
+
+	comparison := &ComparisonResult{
+		BenchmarkName: candidate.BenchmarkName,
+	}
+
+	comparison.TimeDeltaPercent = percentDelta(baseline.NsPerOp, candidate.NsPerOp)
+	comparison.MemoryDeltaPercent = percentDelta(baseline.BytesPerOp, candidate.BytesPerOp)
+	comparison.AllocsDeltaPercent = percentDelta(baseline.AllocsPerOp, candidate.AllocsPerOp)
+	c.test(comparison, oldSamples, newSamples)
+	comparison.IsNewBest = comparison.isFaster()
 
 	return comparison
 }
+
+// CompareSaved compares two sets of saved results benchmark by benchmark,
+// treating a as the baseline. Only benchmarks present in both are compared,
+// in the order of b.
+func (c *Comparator) CompareSaved(a, b []database.BenchmarkResult) []*ComparisonResult {
+	baselines := make(map[string]*database.BenchmarkResult, len(a))
+	for i := range a {
+		baselines[a[i].Name] = &a[i]
+	}
+
+	var comparisons []*ComparisonResult
+	for i := range b {
+		baseline, ok := baselines[b[i].Name]
+		if !ok {
+			continue
+		}
+		candidate := &BenchmarkResult{
+			BenchmarkName: b[i].Name,
+			NsPerOp:       b[i].NsPerOp,
+			BytesPerOp:    b[i].BytesPerOp,
+			AllocsPerOp:   b[i].AllocsPerOp,
+			Samples:       SamplesFromModel(&b[i]),
+		}
+		comparisons = append(comparisons, c.Compare(candidate, baseline))
+	}
+
+	return comparisons
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestComparator_Compare(t *testing.T) {
@@ -41,8 +42,8 @@ func TestComparator_Compare(t *testing.T) {
 
 		result := comp.Compare(current, previous)
 
-		assert.True(t, result.IsNewBest)
 		assert.Equal(t, -20.0, result.TimeDeltaPercent) // 20% faster
+		assert.False(t, result.IsNewBest, "one sample each is too few to call it faster")
 	})
 
 	t.Run("slower time shows regression", func(t *testing.T) {
@@ -102,4 +103,148 @@ func TestComparator_Compare(t *testing.T) {
 
 		assert.Equal(t, -20.0, result.AllocsDeltaPercent) // 20% fewer allocations
 	})
+
+	t.Run("repeated separated samples are significant", func(t *testing.T) {
+		comp := NewComparator()
+		current := &BenchmarkResult{
+			BenchmarkName: "TwoSum",
+			NsPerOp:       1300,
+			Samples:       map[string][]float64{"ns/op": {1250, 1280, 1300, 1320, 1350}},
+		}
+		previous := &database.BenchmarkResult{
+			Name:    "TwoSum",
+			NsPerOp: 1000,
+			Samples: `{"ns/op": [980, 990, 1000, 1010, 1020]}`,
+		}
+
+		result := comp.Compare(current, previous)
+
+		assert.Equal(t, 30.0, result.TimeDeltaPercent)
+		assert.Less(t, result.TimePValue, DefaultAlpha)
+		assert.True(t, result.Significant(result.TimePValue))
+		assert.Equal(t, 5, result.OldSamples)
+		assert.Equal(t, 5, result.NewSamples)
+		assert.True(t, result.IsRegression(10))
+		assert.False(t, result.IsRegression(50))
+		assert.False(t, result.IsNewBest)
+	})
+
+	t.Run("significantly faster samples are a new best", func(t *testing.T) {
+		comp := NewComparator()
+		current := &BenchmarkResult{
+			NsPerOp: 800,
+			Samples: map[string][]float64{"ns/op": {780, 790, 800, 810, 820}},
+		}
+		previous := &database.BenchmarkResult{
+			NsPerOp: 1000,
+			Samples: `{"ns/op": [980, 990, 1000, 1010, 1020]}`,
+		}
+
+		result := comp.Compare(current, previous)
+
+		assert.True(t, result.IsNewBest)
+	})
+
+	t.Run("overlapping samples are not significant", func(t *testing.T) {
+		comp := NewComparator()
+		current := &BenchmarkResult{
+			NsPerOp: 1010,
+			Samples: map[string][]float64{"ns/op": {950, 1010, 1060, 990, 1040}},
+		}
+		previous := &database.BenchmarkResult{
+			NsPerOp: 1000,
+			Samples: `{"ns/op": [960, 1000, 1050, 980, 1030]}`,
+		}
+
+		result := comp.Compare(current, previous)
+
+		assert.Greater(t, result.TimePValue, DefaultAlpha)
+		assert.False(t, result.Significant(result.TimePValue))
+		assert.False(t, result.IsRegression(0))
+		assert.False(t, result.IsNewBest)
+	})
+
+	t.Run("baseline without samples skips the test and gates on the threshold", func(t *testing.T) {
+		comp := NewComparator()
+		current := &BenchmarkResult{
+			NsPerOp: 1300,
+			Samples: map[string][]float64{"ns/op": {1290, 1300, 1310, 1320, 1280}},
+		}
+		previous := &database.BenchmarkResult{NsPerOp: 1000}
+
+		result := comp.Compare(current, previous)
+
+		assert.True(t, result.TooFewSamples)
+		assert.Equal(t, 1, result.OldSamples)
+		assert.Equal(t, 5, result.NewSamples)
+		assert.Zero(t, result.Alpha)
+		assert.True(t, result.IsRegression(10), "the delta alone exceeds the threshold")
+		assert.False(t, result.IsRegression(50))
+	})
+
+	t.Run("zero previous value yields zero delta", func(t *testing.T) {
+		comp := NewComparator()
+		current := &BenchmarkResult{NsPerOp: 100, BytesPerOp: 64}
+		previous := &database.BenchmarkResult{NsPerOp: 100, BytesPerOp: 0}
+
+		result := comp.Compare(current, previous)
+
+		assert.Equal(t, 0.0, result.MemoryDeltaPercent)
+	})
+}
+
+func TestComparator_CompareResults(t *testing.T) {
+	comparator := NewComparator()
+	baseline := &BenchmarkResult{
+		BenchmarkName: "Complexity/n=1000",
+		NsPerOp:       2000,
+		BytesPerOp:    1024,
+		AllocsPerOp:   4,
+		Samples: map[string][]float64{
+			"ns/op":     {1990, 2000, 2010, 2020, 1980},
+			"B/op":      {1024, 1024, 1024, 1024, 1024},
+			"allocs/op": {4, 4, 4, 4, 4},
+		},
+	}
+	candidate := &BenchmarkResult{
+		BenchmarkName: "Complexity/n=1000",
+		NsPerOp:       1000,
+		BytesPerOp:    1024,
+		AllocsPerOp:   4,
+		Samples: map[string][]float64{
+			"ns/op":     {990, 1000, 1010, 1020, 980},
+			"B/op":      {1024, 1024, 1024, 1024, 1024},
+			"allocs/op": {4, 4, 4, 4, 4},
+		},
+	}
+
+	result := comparator.CompareResults(baseline, candidate)
+
+	assert.Equal(t, "Complexity/n=1000", result.BenchmarkName)
+	assert.InDelta(t, -50.0, result.TimeDeltaPercent, 0.01)
+	assert.True(t, result.Significant(result.TimePValue))
+	assert.Equal(t, 0.0, result.MemoryDeltaPercent)
+	assert.Equal(t, 1.0, result.MemoryPValue)
+	assert.Equal(t, 5, result.OldSamples)
+	assert.Equal(t, 5, result.NewSamples)
+	assert.True(t, result.IsNewBest)
+}
+
+func TestComparator_CompareSaved(t *testing.T) {
+	comparator := NewComparator()
+	a := []database.BenchmarkResult{
+		{Name: "TwoSum/size=100", NsPerOp: 200, BytesPerOp: 64, AllocsPerOp: 2},
+		{Name: "TwoSum/size=1000", NsPerOp: 2000, BytesPerOp: 640, AllocsPerOp: 2},
+	}
+	b := []database.BenchmarkResult{
+		{Name: "TwoSum/size=1000", NsPerOp: 1000, BytesPerOp: 640, AllocsPerOp: 2},
+		{Name: "TwoSum/size=10000", NsPerOp: 10000, BytesPerOp: 6400, AllocsPerOp: 2},
+	}
+
+	comparisons := comparator.CompareSaved(a, b)
+
+	require.Len(t, comparisons, 1)
+	assert.Equal(t, "TwoSum/size=1000", comparisons[0].BenchmarkName)
+	assert.InDelta(t, -50.0, comparisons[0].TimeDeltaPercent, 0.01)
+	assert.Equal(t, 0.0, comparisons[0].MemoryDeltaPercent)
 }
@@ -0,0 +1,140 @@
+package benchmarking
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+)
+
+// Environment describes the machine and toolchain a benchmark ran on.
+// Results are only directly comparable when their environments match.
+type Environment struct {
+	GoVersion  string // e.g. "go1.25.3"
+	GOOS       string
+	GOARCH     string
+	CPU        string // CPU model as reported by go test
+	GOMAXPROCS int
+	HostHash   string // Truncated SHA-256 of the hostname, so the host is not stored
+}
+
+// String returns a one-line description of the environment
+func (e Environment) String() string {
+	cpu := e.CPU
+	if cpu == "" {
+		cpu = "unknown CPU"
+	}
+	return fmt.Sprintf("%s %s/%s, %s, GOMAXPROCS=%d", e.GoVersion, e.GOOS, e.GOARCH, cpu, e.GOMAXPROCS)
+}
+
+// Mismatches lists the fields that differ between two environments, e.g.
+// "cpu: Apple M1 vs Intel(R) Core(TM) i7". Empty means comparable.
+func (e Environment) Mismatches(other Environment) []string {
+	var mismatches []string
+	check := func(field, a, b string) {
+		if a != b {
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s vs %s", field, orUnknown(a), orUnknown(b)))
+		}
+	}
+
+	check("go", e.GoVersion, other.GoVersion)
+	check("os/arch", e.GOOS+"/"+e.GOARCH, other.GOOS+"/"+other.GOARCH)
+	check("cpu", e.CPU, other.CPU)
+	check("GOMAXPROCS", fmt.Sprint(e.GOMAXPROCS), fmt.Sprint(other.GOMAXPROCS))
+	check("host", e.HostHash, other.HostHash)
+
+	return mismatches
+}
+
+// orUnknown substitutes a placeholder for values missing from older results
+func orUnknown(value string) string {
+	if value == "" || value == "/" || value == "0" {
+		return "unknown"
+	}
+	return value
+}
+
+// EnvironmentFromModel returns the environment stored with a benchmark row
+func EnvironmentFromModel(benchmark *database.BenchmarkResult) Environment {
+	return Environment{
+		GoVersion:  benchmark.GoVersion,
+		GOOS:       benchmark.GOOS,
+		GOARCH:     benchmark.GOARCH,
+		CPU:        benchmark.CPUModel,
+		GOMAXPROCS: benchmark.GOMAXPROCS,
+		HostHash:   benchmark.HostHash,
+	}
+}
+
+// parseEnvironment reads goos, goarch and cpu from the go test header,
+// falling back to the current process for missing values. The benchmarks ran
+// with procs as GOMAXPROCS.
+func parseEnvironment(output string, procs int) Environment {
+	env := Environment{
+		GOOS:       runtime.GOOS,
+		GOARCH:     runtime.GOARCH,
+		GOMAXPROCS: procs,
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if benchmarkLinePattern.MatchString(line) {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "goos":
+			env.GOOS = value
+		case "goarch":
+			env.GOARCH = value
+		case "cpu":
+			env.CPU = value
+		}
+	}
+
+	return env
+}
+
+// goVersion returns the version of the go command used to run benchmarks
+func goVersion() string {
+	output, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return runtime.Version()
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// hostHash returns a truncated hash of the hostname
+func hostHash() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(hostname))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// contentHash returns the SHA-256 of a file's contents, or "" if it cannot be read
+func contentHash(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return CodeHash(string(data))
+}
+
+// CodeHash returns the SHA-256 of solution source as stored in SolutionHash
+func CodeHash(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,62 @@
+package benchmarking
+
+import (
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	t.Run("reads go test header", func(t *testing.T) {
+		output := `goos: darwin
+goarch: arm64
+pkg: github.com/ak95asb/dsa-dojo/problems
+cpu: Apple M2
+BenchmarkTwoSum/size=100-8    	  500000	      2100 ns/op
+PASS`
+
+		env := parseEnvironment(output, 8)
+
+		assert.Equal(t, "darwin", env.GOOS)
+		assert.Equal(t, "arm64", env.GOARCH)
+		assert.Equal(t, "Apple M2", env.CPU)
+		assert.Equal(t, 8, env.GOMAXPROCS)
+	})
+
+	t.Run("GOMAXPROCS is not read from sub-benchmark names", func(t *testing.T) {
+		env := parseEnvironment(`BenchmarkTwoSum/size-100    	  500000	      2100 ns/op`, 1)
+
+		assert.Equal(t, 1, env.GOMAXPROCS)
+		assert.Empty(t, env.CPU)
+	})
+}
+
+func TestEnvironment_Mismatches(t *testing.T) {
+	env := Environment{GoVersion: "go1.25.3", GOOS: "linux", GOARCH: "amd64", CPU: "A", GOMAXPROCS: 8, HostHash: "h1"}
+
+	t.Run("identical environments match", func(t *testing.T) {
+		assert.Empty(t, env.Mismatches(env))
+	})
+
+	t.Run("lists every differing field", func(t *testing.T) {
+		other := env
+		other.GoVersion = "go1.24.0"
+		other.CPU = "B"
+
+		assert.Equal(t, []string{"go: go1.25.3 vs go1.24.0", "cpu: A vs B"}, env.Mismatches(other))
+	})
+
+	t.Run("older results without metadata are unknown", func(t *testing.T) {
+		legacy := EnvironmentFromModel(&database.BenchmarkResult{})
+
+		mismatches := env.Mismatches(legacy)
+
+		assert.Len(t, mismatches, 5)
+		assert.Contains(t, mismatches, "os/arch: linux/amd64 vs unknown")
+	})
+}
+
+func TestContentHash(t *testing.T) {
+	assert.Empty(t, contentHash("does/not/exist.go"))
+}
@@ -2,46 +2,120 @@ package benchmarking
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 )
 
 // Executor handles benchmark execution using go test
-type Executor struct{}
+type Executor struct {
+	procs int // GOMAXPROCS of the benchmarks, which inherit it from this process
+}
 
 // NewExecutor creates a new benchmark executor
 func NewExecutor() *Executor {
-	return &Executor{}
+	return &Executor{procs: runtime.GOMAXPROCS(0)}
 }
 
-// BenchmarkResult represents parsed benchmark results
+// BenchmarkResult represents the parsed results of one named benchmark.
+// When the benchmark ran several times (-count), metric fields hold the
+// median and Samples holds every individual measurement.
 type BenchmarkResult struct {
-	BenchmarkName string
-	Iterations    int
+	BenchmarkName string // Name without "Benchmark" prefix and GOMAXPROCS suffix, e.g. "TwoSum/size=100"
+	Iterations    int    // Average iterations per sample
 	NsPerOp       float64
 	BytesPerOp    float64
 	AllocsPerOp   float64
-	RawOutput     string
+	HasMemStats   bool                 // Whether B/op and allocs/op were reported (-benchmem or b.ReportAllocs)
+	Metrics       map[string]float64   // Other metrics keyed by unit, e.g. "MB/s" or custom b.ReportMetric units
+	Samples       map[string][]float64 // Every measurement keyed by unit, including ns/op, B/op and allocs/op
+}
+
+// SampleCount returns the number of times the benchmark ran
+func (r *BenchmarkResult) SampleCount() int {
+	if n := len(r.Samples["ns/op"]); n > 0 {
+		return n
+	}
+	return 1
+}
+
+// summarize sets the metric fields to the median of the collected samples
+func (r *BenchmarkResult) summarize() {
+	for unit, values := range r.Samples {
+		value := median(values)
+		switch unit {
+		case "ns/op":
+			r.NsPerOp = value
+		case "B/op":
+			r.BytesPerOp = value
+		case "allocs/op":
+			r.AllocsPerOp = value
+		default:
+			if r.Metrics == nil {
+				r.Metrics = make(map[string]float64)
+			}
+			r.Metrics[unit] = value
+		}
+	}
+}
+
+// RunResult contains every benchmark reported by one go test invocation
+type RunResult struct {
+	Results      []*BenchmarkResult // In output order
+	Environment  Environment
+	SolutionHash string // SHA-256 of the solution file, empty if it does not exist
+	Variant      string // Solution variant benchmarked, empty for the default solution
+	RawOutput    string
 }
 
 // ExecuteOptions contains options for benchmark execution
 type ExecuteOptions struct {
-	MemProfile bool
-	CPUProfile string
+	Count          int // Number of times to run each benchmark (-count)
+	MemProfile     bool
+	CPUProfile     string
 	MemProfilePath string
+	Variant        string // Named solution variant to benchmark instead of the default
 }
 
 // Execute runs benchmarks for a problem and returns parsed results
-func (e *Executor) Execute(prob *problem.ProblemDetails, opts ExecuteOptions) (*BenchmarkResult, error) {
+func (e *Executor) Execute(prob *problem.ProblemDetails, opts ExecuteOptions) (*RunResult, error) {
 	// Construct test file path
-	testFilePath := filepath.Join("problems", "templates", fmt.Sprintf("%s_test.go", prob.Slug))
+	layout := workspace.ProblemLayout(prob.Slug)
+	testFiles := layout.TestFiles()
+	if len(testFiles) == 0 {
+		return nil, fmt.Errorf("no benchmarks found for %s: expected %s or %s", prob.Slug, layout.Tests(), layout.Benchmarks())
+	}
+	solutionPath := layout.Variant(opts.Variant)
+	files := testFiles
+
+	// The solution is staged together with the benchmarks so both compile as
+	// one package; without a default solution the benchmarks run on their own
+	if _, err := os.Stat(solutionPath); err == nil || opts.Variant != "" {
+		stageDir, err := solution.StageSolution(testFiles[0], solutionPath, testFiles[1:]...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stage %s: %w", solutionPath, err)
+		}
+		defer os.RemoveAll(stageDir)
+		files = []string{filepath.Join(stageDir, "solution.go")}
+		for _, testFile := range testFiles {
+			files = append(files, filepath.Join(stageDir, filepath.Base(testFile)))
+		}
+	}
 
 	// Build go test command
-	args := []string{"test", "-bench=.", "-benchmem", testFilePath}
+	args := append([]string{"test", "-bench=.", "-benchmem"}, files...)
+
+	if opts.Count > 1 {
+		args = append(args, fmt.Sprintf("-count=%d", opts.Count))
+	}
 
 	// Add profiling flags if requested
 	if opts.CPUProfile != "" {
@@ -59,38 +133,118 @@ func (e *Executor) Execute(prob *problem.ProblemDetails, opts ExecuteOptions) (*
 	}
 
 	// Parse benchmark output
-	result, err := e.parseBenchmarkOutput(string(output))
+	results, err := e.parseBenchmarkOutput(string(output))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse benchmark output: %w", err)
 	}
 
-	result.RawOutput = string(output)
-	return result, nil
+	env := parseEnvironment(string(output), e.procs)
+	env.GoVersion = goVersion()
+	env.HostHash = hostHash()
+
+	return &RunResult{
+		Results:      results,
+		Environment:  env,
+		SolutionHash: contentHash(solutionPath),
+		Variant:      opts.Variant,
+		RawOutput:    string(output),
+	}, nil
 }
 
-// parseBenchmarkOutput extracts benchmark metrics from go test output
-func (e *Executor) parseBenchmarkOutput(output string) (*BenchmarkResult, error) {
-	// Regex pattern for benchmark output:
-	// Benchmark<Name>-<GOMAXPROCS>  <iterations>  <ns/op> ns/op  <B/op> B/op  <allocs/op> allocs/op
-	pattern := `Benchmark(\w+)-\d+\s+(\d+)\s+([\d.]+) ns/op\s+([\d.]+) B/op\s+([\d.]+) allocs/op`
-	re := regexp.MustCompile(pattern)
+// benchmarkLinePattern matches a benchmark result line:
+// Benchmark<Name>[/<Sub>...][-<GOMAXPROCS>]  <iterations>  <value> <unit>  [<value> <unit> ...]
+var benchmarkLinePattern = regexp.MustCompile(`^Benchmark(\S+)\s+(\d+)\s+(.+)$`)
+
+// parseBenchmarkOutput extracts metrics for every benchmark and sub-benchmark
+// in go test output. Repeated lines for the same benchmark (-count) are
+// merged into one result holding all samples.
+func (e *Executor) parseBenchmarkOutput(output string) ([]*BenchmarkResult, error) {
+	var results []*BenchmarkResult
+	byName := make(map[string]*BenchmarkResult)
+	totalIterations := make(map[string]int)
+
+	for _, line := range strings.Split(output, "\n") {
+		sample := parseBenchmarkLine(strings.TrimSpace(line), e.procs)
+		if sample == nil {
+			continue
+		}
+
+		name := sample.BenchmarkName
+		totalIterations[name] += sample.Iterations
+
+		result, ok := byName[name]
+		if !ok {
+			byName[name] = sample
+			results = append(results, sample)
+			continue
+		}
+
+		result.HasMemStats = result.HasMemStats || sample.HasMemStats
+		for unit, values := range sample.Samples {
+			result.Samples[unit] = append(result.Samples[unit], values...)
+		}
+	}
 
-	matches := re.FindStringSubmatch(output)
-	if len(matches) < 6 {
+	if len(results) == 0 {
 		return nil, fmt.Errorf("no benchmark results found in output")
 	}
 
-	// Parse values
-	iterations, _ := strconv.Atoi(matches[2])
-	nsPerOp, _ := strconv.ParseFloat(matches[3], 64)
-	bytesPerOp, _ := strconv.ParseFloat(matches[4], 64)
-	allocsPerOp, _ := strconv.ParseFloat(matches[5], 64)
+	for _, result := range results {
+		result.Iterations = totalIterations[result.BenchmarkName] / result.SampleCount()
+		result.summarize()
+	}
 
-	return &BenchmarkResult{
-		BenchmarkName: matches[1],
+	return results, nil
+}
+
+// trimProcs removes the -<GOMAXPROCS> suffix go test appends to benchmark
+// names. go test leaves it out when GOMAXPROCS is 1, so only a suffix equal to
+// procs is removed; a sub-benchmark such as size-100 keeps its digits.
+func trimProcs(name string, procs int) string {
+	if procs <= 1 {
+		return name
+	}
+	return strings.TrimSuffix(name, "-"+strconv.Itoa(procs))
+}
+
+// parseBenchmarkLine parses one benchmark line of benchmarks run with procs
+// as GOMAXPROCS into a single-sample result, returning nil if the line is not
+// a benchmark result
+func parseBenchmarkLine(line string, procs int) *BenchmarkResult {
+	matches := benchmarkLinePattern.FindStringSubmatch(line)
+	if matches == nil {
+		return nil
+	}
+
+	// Metrics come in "<value> <unit>" pairs
+	fields := strings.Fields(matches[3])
+	if len(fields) < 2 || len(fields)%2 != 0 {
+		return nil
+	}
+
+	iterations, _ := strconv.Atoi(matches[2])
+	result := &BenchmarkResult{
+		BenchmarkName: trimProcs(matches[1], procs),
 		Iterations:    iterations,
-		NsPerOp:       nsPerOp,
-		BytesPerOp:    bytesPerOp,
-		AllocsPerOp:   allocsPerOp,
-	}, nil
+		Samples:       make(map[string][]float64),
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return nil
+		}
+
+		unit := fields[i+1]
+		if unit == "B/op" || unit == "allocs/op" {
+			result.HasMemStats = true
+		}
+		result.Samples[unit] = append(result.Samples[unit], value)
+	}
+
+	if _, ok := result.Samples["ns/op"]; !ok {
+		return nil
+	}
+
+	return result
 }
@@ -4,21 +4,23 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExecutor_ParseBenchmarkOutput(t *testing.T) {
 	t.Run("parses valid benchmark output", func(t *testing.T) {
-		executor := NewExecutor()
+		executor := &Executor{procs: 8}
 		output := `goos: darwin
 goarch: amd64
 BenchmarkTwoSum-8    	1000000	      1234 ns/op	     512 B/op	       5 allocs/op
 PASS
 ok  	github.com/ak95asb/dsa-dojo/problems	1.234s`
 
-		result, err := executor.parseBenchmarkOutput(output)
+		results, err := executor.parseBenchmarkOutput(output)
 
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		result := results[0]
 		assert.Equal(t, "TwoSum", result.BenchmarkName)
 		assert.Equal(t, 1000000, result.Iterations)
 		assert.Equal(t, 1234.0, result.NsPerOp)
@@ -27,12 +29,14 @@ ok  	github.com/ak95asb/dsa-dojo/problems	1.234s`
 	})
 
 	t.Run("parses output with decimal values", func(t *testing.T) {
-		executor := NewExecutor()
+		executor := &Executor{procs: 8}
 		output := `BenchmarkBinarySearch-8    	5000000	       234.5 ns/op	      64.0 B/op	       2.0 allocs/op`
 
-		result, err := executor.parseBenchmarkOutput(output)
+		results, err := executor.parseBenchmarkOutput(output)
 
-		assert.NoError(t, err)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		result := results[0]
 		assert.Equal(t, "BinarySearch", result.BenchmarkName)
 		assert.Equal(t, 5000000, result.Iterations)
 		assert.Equal(t, 234.5, result.NsPerOp)
@@ -44,20 +48,112 @@ ok  	github.com/ak95asb/dsa-dojo/problems	1.234s`
 		executor := NewExecutor()
 		output := `No benchmark output here`
 
-		result, err := executor.parseBenchmarkOutput(output)
+		results, err := executor.parseBenchmarkOutput(output)
 
 		assert.Error(t, err)
-		assert.Nil(t, result)
+		assert.Nil(t, results)
 		assert.Contains(t, err.Error(), "no benchmark results found")
 	})
 
 	t.Run("handles different GOMAXPROCS values", func(t *testing.T) {
-		executor := NewExecutor()
+		executor := &Executor{procs: 12}
 		output := `BenchmarkQuickSort-12    	2000000	       500 ns/op	     256 B/op	       3 allocs/op`
 
-		result, err := executor.parseBenchmarkOutput(output)
+		results, err := executor.parseBenchmarkOutput(output)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "QuickSort", results[0].BenchmarkName)
+	})
+
+	t.Run("parses every benchmark and sub-benchmark", func(t *testing.T) {
+		executor := &Executor{procs: 8}
+		output := `goos: linux
+goarch: amd64
+BenchmarkTwoSum/size=100-8      	  500000	      2100 ns/op	    1024 B/op	       4 allocs/op
+BenchmarkTwoSum/size=1000-8     	   50000	     21000 ns/op	   16384 B/op	      12 allocs/op
+BenchmarkTwoSumBrute-8          	   10000	    120000 ns/op	       0 B/op	       0 allocs/op
+PASS`
+
+		results, err := executor.parseBenchmarkOutput(output)
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, "TwoSum/size=100", results[0].BenchmarkName)
+		assert.Equal(t, 2100.0, results[0].NsPerOp)
+		assert.Equal(t, "TwoSum/size=1000", results[1].BenchmarkName)
+		assert.Equal(t, 16384.0, results[1].BytesPerOp)
+		assert.Equal(t, "TwoSumBrute", results[2].BenchmarkName)
+		assert.True(t, results[2].HasMemStats)
+	})
+
+	t.Run("parses output without benchmem columns", func(t *testing.T) {
+		executor := &Executor{procs: 4}
+		output := `BenchmarkMergeSort-4    	  100000	     15000 ns/op`
+
+		results, err := executor.parseBenchmarkOutput(output)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 15000.0, results[0].NsPerOp)
+		assert.False(t, results[0].HasMemStats)
+	})
+
+	t.Run("parses custom metrics", func(t *testing.T) {
+		executor := &Executor{procs: 8}
+		output := `BenchmarkLRU/cap=64-8    	  200000	      6000 ns/op	        0.9500 hit-rate	  85.33 MB/s	      48 B/op	       1 allocs/op`
+
+		results, err := executor.parseBenchmarkOutput(output)
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "LRU/cap=64", results[0].BenchmarkName)
+		assert.Equal(t, 0.95, results[0].Metrics["hit-rate"])
+		assert.Equal(t, 85.33, results[0].Metrics["MB/s"])
+		assert.Equal(t, 48.0, results[0].BytesPerOp)
+	})
+
+	t.Run("parses names without GOMAXPROCS suffix", func(t *testing.T) {
+		executor := &Executor{procs: 1}
+		output := `BenchmarkReverse/len=10    	 1000000	       100 ns/op`
+
+		results, err := executor.parseBenchmarkOutput(output)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Reverse/len=10", results[0].BenchmarkName)
+	})
+
+	t.Run("keeps trailing digits of sub-benchmark names", func(t *testing.T) {
+		output := `BenchmarkX/size-100    	 1000000	       100 ns/op
+BenchmarkX/size-1000   	  100000	      1000 ns/op`
+
+		results, err := (&Executor{procs: 1}).parseBenchmarkOutput(output)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "X/size-100", results[0].BenchmarkName)
+		assert.Equal(t, "X/size-1000", results[1].BenchmarkName)
+
+		results, err = (&Executor{procs: 8}).parseBenchmarkOutput("BenchmarkX/size-100-8    	 1000000	       100 ns/op")
+		require.NoError(t, err)
+		assert.Equal(t, "X/size-100", results[0].BenchmarkName)
+	})
+
+	t.Run("merges repeated runs into samples with medians", func(t *testing.T) {
+		executor := &Executor{procs: 8}
+		output := `BenchmarkTwoSum-8    	1000	      1000 ns/op	     512 B/op	       5 allocs/op
+BenchmarkTwoSum-8    	3000	      1200 ns/op	     512 B/op	       5 allocs/op
+BenchmarkTwoSum-8    	2000	      1100 ns/op	     512 B/op	       5 allocs/op
+BenchmarkReverse-8   	5000	       300 ns/op	       0 B/op	       0 allocs/op`
+
+		results, err := executor.parseBenchmarkOutput(output)
 
-		assert.NoError(t, err)
-		assert.Equal(t, "QuickSort", result.BenchmarkName)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "TwoSum", results[0].BenchmarkName)
+		assert.Equal(t, 3, results[0].SampleCount())
+		assert.Equal(t, []float64{1000, 1200, 1100}, results[0].Samples["ns/op"])
+		assert.Equal(t, 1100.0, results[0].NsPerOp)
+		assert.Equal(t, 2000, results[0].Iterations)
+		assert.Equal(t, 1, results[1].SampleCount())
 	})
 }
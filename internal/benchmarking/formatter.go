@@ -2,7 +2,12 @@ package benchmarking
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
 )
 
 // Formatter handles formatting and displaying benchmark results
@@ -26,47 +31,217 @@ func (f *Formatter) FormatResult(result *BenchmarkResult) string {
 	return output.String()
 }
 
-// FormatComparison formats a comparison between current and previous results
-func (f *Formatter) FormatComparison(comparison *ComparisonResult) string {
-	if comparison == nil {
-		return "\nNo previous benchmark results for comparison.\n"
+// FormatResults formats every benchmark of a run as a table, one row per
+// benchmark name, with an extra column for each custom metric unit.
+// Values are medians; time shows its variation when there are several samples.
+func (f *Formatter) FormatResults(results []*BenchmarkResult) string {
+	// Collect custom metric units across all rows
+	unitSet := make(map[string]bool)
+	for _, result := range results {
+		for unit := range result.Metrics {
+			unitSet[unit] = true
+		}
+	}
+	units := make([]string, 0, len(unitSet))
+	for unit := range unitSet {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+
+	header := append([]string{"Benchmark", "Iterations", "Time/op", "Memory/op", "Allocs/op"}, units...)
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		memory, allocs := "-", "-"
+		if result.HasMemStats {
+			memory = f.formatBytes(result.BytesPerOp)
+			allocs = fmt.Sprintf("%.0f", result.AllocsPerOp)
+		}
+
+		timePerOp := f.formatTime(result.NsPerOp)
+		if result.SampleCount() > 1 {
+			timePerOp += fmt.Sprintf(" ±%.0f%%", spreadPercent(result.Samples["ns/op"]))
+		}
+
+		row := []string{
+			result.BenchmarkName,
+			f.formatNumber(result.Iterations),
+			timePerOp,
+			memory,
+			allocs,
+		}
+		for _, unit := range units {
+			if value, ok := result.Metrics[unit]; ok {
+				row = append(row, strconv.FormatFloat(value, 'g', 6, 64))
+			} else {
+				row = append(row, "-")
+			}
+		}
+		rows = append(rows, row)
 	}
 
 	var output strings.Builder
-	output.WriteString("\nComparison with previous best:\n")
+	output.WriteString("\nResults:\n")
+	f.writeTable(&output, header, rows)
 
-	// Time comparison
-	if comparison.TimeDeltaPercent != 0 {
-		if comparison.TimeDeltaPercent < 0 {
-			// Faster (improvement)
-			output.WriteString(fmt.Sprintf("  🚀 %.1f%% faster than previous best!\n", -comparison.TimeDeltaPercent))
-		} else {
-			// Slower (regression)
-			output.WriteString(fmt.Sprintf("  ⚠️  %.1f%% slower than previous best\n", comparison.TimeDeltaPercent))
+	return output.String()
+}
+
+// sparkTicks are the block characters used by Sparkline, lowest first
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters scaled
+// between their minimum and maximum
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
 		}
 	}
 
-	// Memory comparison
-	if comparison.MemoryDeltaPercent != 0 {
-		if comparison.MemoryDeltaPercent < 0 {
-			// Less memory (improvement)
-			output.WriteString(fmt.Sprintf("  💾 %.1f%% less memory than previous best!\n", -comparison.MemoryDeltaPercent))
-		} else {
-			// More memory (regression)
-			output.WriteString(fmt.Sprintf("  ⚠️  %.1f%% more memory than previous best\n", comparison.MemoryDeltaPercent))
+	var line strings.Builder
+	for _, v := range values {
+		tick := len(sparkTicks) / 2 // Flat series sit in the middle
+		if hi > lo {
+			tick = int((v - lo) / (hi - lo) * float64(len(sparkTicks)-1))
 		}
+		line.WriteRune(sparkTicks[tick])
 	}
+	return line.String()
+}
 
-	// Allocations comparison
-	if comparison.AllocsDeltaPercent != 0 {
-		if comparison.AllocsDeltaPercent < 0 {
-			// Fewer allocations (improvement)
-			output.WriteString(fmt.Sprintf("  ✨ %.1f%% fewer allocations than previous best!\n", -comparison.AllocsDeltaPercent))
+// FormatHistory formats benchmark history, one section per benchmark name with
+// sparklines for time, memory and allocations followed by every stored result
+func (f *Formatter) FormatHistory(points []HistoryPoint) string {
+	var output strings.Builder
+
+	names, series := GroupByName(points)
+	for _, name := range names {
+		group := series[name]
+		if name == "" {
+			name = "(unnamed)"
+		}
+		noun := "results"
+		if len(group) == 1 {
+			noun = "result"
+		}
+		output.WriteString(fmt.Sprintf("\n%s (%d %s)\n", name, len(group), noun))
+
+		var times, bytes, allocs []float64
+		for _, point := range group {
+			times = append(times, point.NsPerOp)
+			bytes = append(bytes, point.BytesPerOp)
+			allocs = append(allocs, point.AllocsPerOp)
+		}
+		first, last := group[0], group[len(group)-1]
+		output.WriteString(fmt.Sprintf("  time/op    %s  %s → %s\n", Sparkline(times), f.formatTime(first.NsPerOp), f.formatTime(last.NsPerOp)))
+		output.WriteString(fmt.Sprintf("  memory/op  %s  %s → %s\n", Sparkline(bytes), f.formatBytes(first.BytesPerOp), f.formatBytes(last.BytesPerOp)))
+		output.WriteString(fmt.Sprintf("  allocs/op  %s  %.0f → %.0f\n\n", Sparkline(allocs), first.AllocsPerOp, last.AllocsPerOp))
+
+		header := []string{"Date & Time", "Time/op", "Memory/op", "Allocs/op", "Runs", "Submission"}
+		rows := make([][]string, 0, len(group))
+		for _, point := range group {
+			rows = append(rows, []string{
+				point.CreatedAt.Format("2006-01-02 15:04:05"),
+				f.formatTime(point.NsPerOp),
+				f.formatBytes(point.BytesPerOp),
+				fmt.Sprintf("%.0f", point.AllocsPerOp),
+				fmt.Sprintf("%d", point.Count),
+				FormatSubmission(point),
+			})
+		}
+		f.writeTable(&output, header, rows)
+	}
+
+	return output.String()
+}
+
+// FormatSubmission describes the submission linked to a history point
+func FormatSubmission(point HistoryPoint) string {
+	if point.SubmissionIndex == 0 {
+		return "-"
+	}
+	if point.SubmissionPassed {
+		return fmt.Sprintf("#%d ✓", point.SubmissionIndex)
+	}
+	return fmt.Sprintf("#%d ✗", point.SubmissionIndex)
+}
+
+// writeTable writes a header, separator and rows with aligned columns
+func (f *Formatter) writeTable(output *strings.Builder, header []string, rows [][]string) {
+	widths := make([]int, len(header))
+	for i, cell := range header {
+		widths[i] = utf8.RuneCountInString(cell)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := utf8.RuneCountInString(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	f.writeTableRow(output, header, widths)
+	separator := make([]string, len(widths))
+	for i, w := range widths {
+		separator[i] = strings.Repeat("-", w)
+	}
+	f.writeTableRow(output, separator, widths)
+	for _, row := range rows {
+		f.writeTableRow(output, row, widths)
+	}
+}
+
+// writeTableRow writes one table row, left-aligning the name column and
+// right-aligning numeric columns
+func (f *Formatter) writeTableRow(output *strings.Builder, cells []string, widths []int) {
+	output.WriteString(" ")
+	for i, cell := range cells {
+		padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		if i == 0 {
+			output.WriteString(" " + cell + padding)
 		} else {
-			// More allocations (regression)
-			output.WriteString(fmt.Sprintf("  ⚠️  %.1f%% more allocations than previous best\n", comparison.AllocsDeltaPercent))
+			output.WriteString("  " + padding + cell)
 		}
 	}
+	output.WriteString("\n")
+}
+
+// FormatComparison formats a comparison between current and previous results
+func (f *Formatter) FormatComparison(comparison *ComparisonResult) string {
+	if comparison == nil {
+		return "\nNo previous benchmark results for comparison.\n"
+	}
+
+	var output strings.Builder
+	if comparison.BenchmarkName != "" {
+		output.WriteString(fmt.Sprintf("\nComparison with previous run (%s):\n", comparison.BenchmarkName))
+	} else {
+		output.WriteString("\nComparison with previous run:\n")
+	}
+	if comparison.TooFewSamples {
+		output.WriteString(fmt.Sprintf("  ⚠️  Too few samples for a significance test (n=%d+%d, need %d each); changes are compared with the threshold alone\n",
+			comparison.OldSamples, comparison.NewSamples, MinSamples))
+	}
+
+	// Time comparison
+	f.writeDelta(&output, comparison, "time/op", comparison.TimeDeltaPercent, comparison.TimePValue,
+		"  🚀 %.1f%% faster than previous run!", "  ⚠️  %.1f%% slower than previous run")
+
+	// Memory comparison
+	f.writeDelta(&output, comparison, "memory/op", comparison.MemoryDeltaPercent, comparison.MemoryPValue,
+		"  💾 %.1f%% less memory than previous run!", "  ⚠️  %.1f%% more memory than previous run")
+
+	// Allocations comparison
+	f.writeDelta(&output, comparison, "allocs/op", comparison.AllocsDeltaPercent, comparison.AllocsPValue,
+		"  ✨ %.1f%% fewer allocations than previous run!", "  ⚠️  %.1f%% more allocations than previous run")
 
 	// New best indicator
 	if comparison.IsNewBest {
@@ -76,6 +251,52 @@ func (f *Formatter) FormatComparison(comparison *ComparisonResult) string {
 	return output.String()
 }
 
+// FormatEnvironmentWarning explains that the previous run of a benchmark was
+// recorded in a different environment, and whether it was compared anyway
+func (f *Formatter) FormatEnvironmentWarning(name string, mismatches []string, compared bool) string {
+	var output strings.Builder
+
+	if compared {
+		output.WriteString(fmt.Sprintf("\n⚠️  Comparing %s across environments; results may not be comparable:\n", name))
+	} else {
+		output.WriteString(fmt.Sprintf("\n⚠️  Skipping comparison for %s: previous results were recorded in a different environment:\n", name))
+	}
+	for _, mismatch := range mismatches {
+		output.WriteString(fmt.Sprintf("    %s\n", mismatch))
+	}
+	if !compared {
+		output.WriteString("  Use --cross-env to compare anyway.\n")
+	}
+
+	return output.String()
+}
+
+// writeDelta writes one metric's change, or "~" when the change is not
+// statistically significant. Negative deltas are improvements.
+func (f *Formatter) writeDelta(output *strings.Builder, comparison *ComparisonResult, metric string, delta, pValue float64, improvedFormat, regressedFormat string) {
+	if delta == 0 {
+		return
+	}
+
+	// Sample sizes and p-value are only meaningful when a test was performed
+	var stats string
+	if comparison.Alpha > 0 {
+		stats = fmt.Sprintf(" (p=%.3f n=%d+%d)", pValue, comparison.OldSamples, comparison.NewSamples)
+	}
+
+	if !comparison.Significant(pValue) {
+		output.WriteString(fmt.Sprintf("  %s: ~ (no significant change)%s\n", metric, stats))
+		return
+	}
+
+	if delta < 0 {
+		output.WriteString(fmt.Sprintf(improvedFormat, -delta))
+	} else {
+		output.WriteString(fmt.Sprintf(regressedFormat, delta))
+	}
+	output.WriteString(stats + "\n")
+}
+
 // formatNumber formats an integer with comma separators
 func (f *Formatter) formatNumber(n int) string {
 	s := fmt.Sprintf("%d", n)
@@ -120,3 +341,113 @@ func (f *Formatter) formatBytes(b float64) string {
 		return fmt.Sprintf("%.2f GB", b/(1024*1024*1024))
 	}
 }
+
+// FormatHeadToHead formats a head-to-head comparison as a side-by-side table
+// followed by a verdict for every benchmark. Deltas are B relative to A and
+// are shown as "~" when not statistically significant.
+func (f *Formatter) FormatHeadToHead(result *HeadToHeadResult) string {
+	var output strings.Builder
+
+	a, b := result.A.Label, result.B.Label
+	byName := make(map[string]*BenchmarkResult)
+	for _, r := range result.ResultsA {
+		byName[r.BenchmarkName] = r
+	}
+	candidates := make(map[string]*BenchmarkResult)
+	for _, r := range result.ResultsB {
+		candidates[r.BenchmarkName] = r
+	}
+
+	header := []string{
+		"Benchmark",
+		a + " time/op", b + " time/op", "Δ time",
+		a + " memory/op", b + " memory/op", "Δ memory",
+		a + " allocs/op", b + " allocs/op", "Δ allocs",
+	}
+	rows := make([][]string, 0, len(result.Comparisons))
+	for _, comparison := range result.Comparisons {
+		ra, rb := byName[comparison.BenchmarkName], candidates[comparison.BenchmarkName]
+		rows = append(rows, []string{
+			comparison.BenchmarkName,
+			f.formatTime(ra.NsPerOp) + fmt.Sprintf(" ±%.0f%%", spreadPercent(ra.Samples["ns/op"])),
+			f.formatTime(rb.NsPerOp) + fmt.Sprintf(" ±%.0f%%", spreadPercent(rb.Samples["ns/op"])),
+			f.formatSignificantDelta(comparison, comparison.TimeDeltaPercent, comparison.TimePValue),
+			f.formatBytes(ra.BytesPerOp),
+			f.formatBytes(rb.BytesPerOp),
+			f.formatSignificantDelta(comparison, comparison.MemoryDeltaPercent, comparison.MemoryPValue),
+			fmt.Sprintf("%.0f", ra.AllocsPerOp),
+			fmt.Sprintf("%.0f", rb.AllocsPerOp),
+			f.formatSignificantDelta(comparison, comparison.AllocsDeltaPercent, comparison.AllocsPValue),
+		})
+	}
+
+	output.WriteString(fmt.Sprintf("\nA = %s, B = %s (Δ is B relative to A)\n\n", a, b))
+	f.writeTable(&output, header, rows)
+
+	output.WriteString("\nVerdict:\n")
+	for _, comparison := range result.Comparisons {
+		name := comparison.BenchmarkName
+		stats := fmt.Sprintf("(p=%.3f n=%d+%d)", comparison.TimePValue, comparison.OldSamples, comparison.NewSamples)
+		switch {
+		case comparison.TooFewSamples:
+			output.WriteString(fmt.Sprintf("  %s: ~ (too few samples) (n=%d+%d)\n", name, comparison.OldSamples, comparison.NewSamples))
+		case comparison.TimeDeltaPercent == 0 || !comparison.Significant(comparison.TimePValue):
+			output.WriteString(fmt.Sprintf("  %s: ~ no significant difference %s\n", name, stats))
+		case comparison.TimeDeltaPercent < 0:
+			output.WriteString(fmt.Sprintf("  %s: %s is %.2fx faster than %s %s\n", name, b, speedup(comparison.TimeDeltaPercent), a, stats))
+		default:
+			output.WriteString(fmt.Sprintf("  %s: %s is %.2fx faster than %s %s\n", name, a, 1+comparison.TimeDeltaPercent/100, b, stats))
+		}
+	}
+
+	return output.String()
+}
+
+// formatSignificantDelta formats a percentage change, or "~" when the change
+// is zero, not statistically significant, or there are too few samples to
+// tell
+func (f *Formatter) formatSignificantDelta(comparison *ComparisonResult, delta, pValue float64) string {
+	if delta == 0 || comparison.TooFewSamples || !comparison.Significant(pValue) {
+		return "~"
+	}
+	return fmt.Sprintf("%+.1f%%", delta)
+}
+
+// speedup converts a negative time delta percentage into a speedup factor,
+// e.g. -50% is 2x faster
+func speedup(deltaPercent float64) float64 {
+	return 1 / (1 + deltaPercent/100)
+}
+
+// FormatSavedComparison formats saved results of two solutions side by side.
+// Deltas are b relative to a and are shown as "~" when not statistically
+// significant.
+func (f *Formatter) FormatSavedComparison(labelA, labelB string, a, b []database.BenchmarkResult, comparisons []*ComparisonResult) string {
+	var output strings.Builder
+
+	byName := make(map[string]*database.BenchmarkResult)
+	for i := range a {
+		byName[a[i].Name] = &a[i]
+	}
+	candidates := make(map[string]*database.BenchmarkResult)
+	for i := range b {
+		candidates[b[i].Name] = &b[i]
+	}
+
+	header := []string{"Benchmark", labelA + " time/op", labelB + " time/op", "Δ time", "Δ memory", "Δ allocs"}
+	rows := make([][]string, 0, len(comparisons))
+	for _, comparison := range comparisons {
+		ra, rb := byName[comparison.BenchmarkName], candidates[comparison.BenchmarkName]
+		rows = append(rows, []string{
+			comparison.BenchmarkName,
+			f.formatTime(ra.NsPerOp),
+			f.formatTime(rb.NsPerOp),
+			f.formatSignificantDelta(comparison, comparison.TimeDeltaPercent, comparison.TimePValue),
+			f.formatSignificantDelta(comparison, comparison.MemoryDeltaPercent, comparison.MemoryPValue),
+			f.formatSignificantDelta(comparison, comparison.AllocsDeltaPercent, comparison.AllocsPValue),
+		})
+	}
+	f.writeTable(&output, header, rows)
+
+	return output.String()
+}
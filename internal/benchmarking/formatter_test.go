@@ -3,6 +3,9 @@ package benchmarking
 import (
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -26,6 +29,39 @@ func TestFormatter_FormatResult(t *testing.T) {
 	})
 }
 
+func TestFormatter_FormatResults(t *testing.T) {
+	t.Run("formats one row per benchmark", func(t *testing.T) {
+		formatter := NewFormatter()
+		results := []*BenchmarkResult{
+			{BenchmarkName: "TwoSum/size=100", Iterations: 500000, NsPerOp: 2100, BytesPerOp: 1024, AllocsPerOp: 4, HasMemStats: true},
+			{BenchmarkName: "TwoSum/size=1000", Iterations: 50000, NsPerOp: 21000, BytesPerOp: 16384, AllocsPerOp: 12, HasMemStats: true},
+		}
+
+		output := formatter.FormatResults(results)
+
+		assert.Contains(t, output, "Benchmark")
+		assert.Contains(t, output, "TwoSum/size=100")
+		assert.Contains(t, output, "TwoSum/size=1000")
+		assert.Contains(t, output, "500,000")
+		assert.Contains(t, output, "16.00 KB")
+	})
+
+	t.Run("adds a column per custom metric", func(t *testing.T) {
+		formatter := NewFormatter()
+		results := []*BenchmarkResult{
+			{BenchmarkName: "LRU", Iterations: 1000, NsPerOp: 500, Metrics: map[string]float64{"hit-rate": 0.95}},
+			{BenchmarkName: "LRUCold", Iterations: 1000, NsPerOp: 700},
+		}
+
+		output := formatter.FormatResults(results)
+
+		assert.Contains(t, output, "hit-rate")
+		assert.Contains(t, output, "0.95")
+		// Missing memory stats and metrics render as placeholders
+		assert.Contains(t, output, " -")
+	})
+}
+
 func TestFormatter_FormatComparison(t *testing.T) {
 	t.Run("shows no comparison message when nil", func(t *testing.T) {
 		formatter := NewFormatter()
@@ -67,6 +103,21 @@ func TestFormatter_FormatComparison(t *testing.T) {
 		assert.NotContains(t, output, "New personal best")
 	})
 
+	t.Run("warns when there are too few samples to test", func(t *testing.T) {
+		formatter := NewFormatter()
+		comparison := &ComparisonResult{
+			TimeDeltaPercent: 20.0,
+			OldSamples:       1,
+			NewSamples:       5,
+			TooFewSamples:    true,
+		}
+
+		output := formatter.FormatComparison(comparison)
+
+		assert.Contains(t, output, "Too few samples for a significance test (n=1+5, need 4 each)")
+		assert.Contains(t, output, "20.0% slower than previous run")
+	})
+
 	t.Run("shows memory improvement", func(t *testing.T) {
 		formatter := NewFormatter()
 		comparison := &ComparisonResult{
@@ -96,6 +147,159 @@ func TestFormatter_FormatComparison(t *testing.T) {
 		assert.Contains(t, output, "✨")
 		assert.Contains(t, output, "25.0% fewer allocations")
 	})
+
+	t.Run("shows tilde for insignificant change", func(t *testing.T) {
+		formatter := NewFormatter()
+		comparison := &ComparisonResult{
+			TimeDeltaPercent: 3.2,
+			TimePValue:       0.42,
+			OldSamples:       5,
+			NewSamples:       5,
+			Alpha:            0.05,
+		}
+
+		output := formatter.FormatComparison(comparison)
+
+		assert.Contains(t, output, "~ (no significant change)")
+		assert.Contains(t, output, "p=0.420 n=5+5")
+		assert.NotContains(t, output, "slower")
+	})
+
+	t.Run("shows p-value for significant change", func(t *testing.T) {
+		formatter := NewFormatter()
+		comparison := &ComparisonResult{
+			TimeDeltaPercent: 25.0,
+			TimePValue:       0.008,
+			OldSamples:       5,
+			NewSamples:       5,
+			Alpha:            0.05,
+		}
+
+		output := formatter.FormatComparison(comparison)
+
+		assert.Contains(t, output, "25.0% slower")
+		assert.Contains(t, output, "p=0.008 n=5+5")
+	})
+}
+
+func TestSparkline(t *testing.T) {
+	t.Run("scales between min and max", func(t *testing.T) {
+		assert.Equal(t, "▁▄█", Sparkline([]float64{10, 15, 20}))
+	})
+
+	t.Run("flat series uses middle tick", func(t *testing.T) {
+		assert.Equal(t, "▅▅", Sparkline([]float64{7, 7}))
+	})
+
+	t.Run("empty series is empty", func(t *testing.T) {
+		assert.Equal(t, "", Sparkline(nil))
+	})
+}
+
+func TestFormatter_FormatHistory(t *testing.T) {
+	formatter := NewFormatter()
+	now := time.Now()
+	points := []HistoryPoint{
+		{BenchmarkResult: database.BenchmarkResult{Name: "TwoSum", NsPerOp: 2000, BytesPerOp: 512, AllocsPerOp: 4, Count: 5, CreatedAt: now.Add(-time.Hour)}},
+		{BenchmarkResult: database.BenchmarkResult{Name: "TwoSum", NsPerOp: 1000, BytesPerOp: 256, AllocsPerOp: 2, Count: 5, CreatedAt: now}, SubmissionIndex: 1, SubmissionPassed: true},
+	}
+
+	output := formatter.FormatHistory(points)
+
+	assert.Contains(t, output, "TwoSum (2 results)")
+	assert.Contains(t, output, "time/op    █▁")
+	assert.Contains(t, output, "2.000 µs → 1.000 µs")
+	assert.Contains(t, output, "#1 ✓")
+	assert.Contains(t, output, now.Format("2006-01-02 15:04:05"))
+}
+
+func TestFormatter_FormatHeadToHead(t *testing.T) {
+	formatter := NewFormatter()
+	a := &BenchmarkResult{BenchmarkName: "TwoSum/size=1000", NsPerOp: 2000, BytesPerOp: 1024, AllocsPerOp: 4,
+		Samples: map[string][]float64{"ns/op": {1990, 2000, 2000, 2010}}}
+	b := &BenchmarkResult{BenchmarkName: "TwoSum/size=1000", NsPerOp: 1000, BytesPerOp: 1024, AllocsPerOp: 4,
+		Samples: map[string][]float64{"ns/op": {990, 1000, 1000, 1010}}}
+	c := &BenchmarkResult{BenchmarkName: "TwoSum/size=10", NsPerOp: 50, Samples: map[string][]float64{"ns/op": {50, 50, 50, 50}}}
+
+	result := &HeadToHeadResult{
+		A:        Version{Label: "#2"},
+		B:        Version{Label: "current"},
+		ResultsA: []*BenchmarkResult{c, a},
+		ResultsB: []*BenchmarkResult{c, b},
+		Comparisons: []*ComparisonResult{
+			{BenchmarkName: "TwoSum/size=10", TimePValue: 1, OldSamples: 4, NewSamples: 4, Alpha: 0.05},
+			{BenchmarkName: "TwoSum/size=1000", TimeDeltaPercent: -50, TimePValue: 0.01, MemoryPValue: 1, AllocsPValue: 1, OldSamples: 4, NewSamples: 4, Alpha: 0.05},
+		},
+	}
+
+	t.Run("significant and insignificant differences", func(t *testing.T) {
+		output := formatter.FormatHeadToHead(result)
+
+		assert.Contains(t, output, "A = #2, B = current")
+		assert.Contains(t, output, "#2 time/op")
+		assert.Contains(t, output, "current time/op")
+		assert.Contains(t, output, "2.000 µs ±0%")
+		assert.Contains(t, output, "-50.0%")
+		assert.Contains(t, output, "TwoSum/size=1000: current is 2.00x faster than #2 (p=0.010 n=4+4)")
+		assert.Contains(t, output, "TwoSum/size=10: ~ no significant difference")
+	})
+
+	t.Run("too few samples are never significant", func(t *testing.T) {
+		result.Comparisons = []*ComparisonResult{
+			{BenchmarkName: "TwoSum/size=1000", TimeDeltaPercent: 26.1, OldSamples: 2, NewSamples: 2, TooFewSamples: true},
+		}
+
+		output := formatter.FormatHeadToHead(result)
+
+		assert.Contains(t, output, "TwoSum/size=1000: ~ (too few samples) (n=2+2)")
+		assert.NotContains(t, output, "+26.1%")
+		assert.NotContains(t, output, "faster than")
+		assert.NotContains(t, output, "p=")
+	})
+}
+
+func TestFormatter_FormatSavedComparison(t *testing.T) {
+	formatter := NewFormatter()
+	a := []database.BenchmarkResult{{Name: "TwoSum/size=1000", NsPerOp: 2000}}
+	b := []database.BenchmarkResult{{Name: "TwoSum/size=1000", NsPerOp: 1000}}
+	comparisons := []*ComparisonResult{
+		{BenchmarkName: "TwoSum/size=1000", TimeDeltaPercent: -50, TimePValue: 0.01, MemoryPValue: 1, AllocsPValue: 1, Alpha: 0.05},
+	}
+
+	output := formatter.FormatSavedComparison("A", "B", a, b, comparisons)
+
+	assert.Contains(t, output, "A time/op")
+	assert.Contains(t, output, "TwoSum/size=1000")
+	assert.Contains(t, output, "2.000 µs")
+	assert.Contains(t, output, "1.000 µs")
+	assert.Contains(t, output, "-50.0%")
+	assert.Contains(t, output, "~")
+}
+
+func TestFormatter_FormatEnvironmentWarning(t *testing.T) {
+	formatter := NewFormatter()
+	mismatches := []string{"cpu: A vs B"}
+
+	t.Run("refused comparison suggests cross-env", func(t *testing.T) {
+		output := formatter.FormatEnvironmentWarning("TwoSum", mismatches, false)
+
+		assert.Contains(t, output, "Skipping comparison for TwoSum")
+		assert.Contains(t, output, "cpu: A vs B")
+		assert.Contains(t, output, "--cross-env")
+	})
+
+	t.Run("cross-env comparison warns", func(t *testing.T) {
+		output := formatter.FormatEnvironmentWarning("TwoSum", mismatches, true)
+
+		assert.Contains(t, output, "across environments")
+		assert.NotContains(t, output, "--cross-env")
+	})
+}
+
+func TestFormatSubmission(t *testing.T) {
+	assert.Equal(t, "-", FormatSubmission(HistoryPoint{}))
+	assert.Equal(t, "#2 ✗", FormatSubmission(HistoryPoint{SubmissionIndex: 2}))
+	assert.Equal(t, "#1 ✓", FormatSubmission(HistoryPoint{SubmissionIndex: 1, SubmissionPassed: true}))
 }
 
 func TestFormatter_FormatNumber(t *testing.T) {
@@ -0,0 +1,175 @@
+package benchmarking
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+)
+
+// Version is one solution version taking part in a head-to-head comparison
+type Version struct {
+	Label string // How the version is shown, e.g. "#3" or "current"
+	Code  string // Full source of the solution file
+}
+
+// HeadToHeadOptions controls a head-to-head comparison
+type HeadToHeadOptions struct {
+	Count     int     // Number of interleaved rounds
+	BenchTime string  // Per-benchmark duration passed to -test.benchtime
+	Alpha     float64 // Significance level; DefaultAlpha when zero
+}
+
+// HeadToHeadResult holds the results for both versions of a head-to-head
+// comparison. Comparisons treat A as the baseline and B as the candidate.
+type HeadToHeadResult struct {
+	A, B        Version
+	ResultsA    []*BenchmarkResult
+	ResultsB    []*BenchmarkResult
+	Comparisons []*ComparisonResult // One per benchmark name, in output order
+	Environment Environment
+}
+
+// HeadToHead runs a problem's own benchmarks on two solution versions. Each
+// version is staged with the problem's test files inside the workspace
+// module, as dsa bench does, and compiled once; the test binaries then run
+// in alternating order for every round so drift on the machine affects both
+// versions equally.
+func (e *Executor) HeadToHead(slug string, a, b Version, opts HeadToHeadOptions) (*HeadToHeadResult, error) {
+	layout := workspace.ProblemLayout(slug)
+	testFiles := layout.TestFiles()
+	if len(testFiles) == 0 {
+		return nil, fmt.Errorf("no benchmarks found for %s: expected %s or %s", slug, layout.Tests(), layout.Benchmarks())
+	}
+	rounds := opts.Count
+	if rounds < 1 {
+		rounds = 1
+	}
+
+	dirA, err := stageVersion(a, testFiles)
+	if err != nil {
+		return nil, fmt.Errorf("version %s: %w", a.Label, err)
+	}
+	defer os.RemoveAll(dirA)
+
+	dirB, err := stageVersion(b, testFiles)
+	if err != nil {
+		return nil, fmt.Errorf("version %s: %w", b.Label, err)
+	}
+	defer os.RemoveAll(dirB)
+
+	args := []string{"-test.run=^$", "-test.bench=.", "-test.benchmem"}
+	if opts.BenchTime != "" {
+		args = append(args, fmt.Sprintf("-test.benchtime=%s", opts.BenchTime))
+	}
+
+	var outputA, outputB strings.Builder
+	for round := 0; round < rounds; round++ {
+		// Alternate which version runs first
+		first, second := dirA, dirB
+		firstOut, secondOut := &outputA, &outputB
+		if round%2 == 1 {
+			first, second = dirB, dirA
+			firstOut, secondOut = &outputB, &outputA
+		}
+		if err := runTestBinary(first, args, firstOut); err != nil {
+			return nil, err
+		}
+		if err := runTestBinary(second, args, secondOut); err != nil {
+			return nil, err
+		}
+	}
+
+	resultsA, err := e.parseBenchmarkOutput(outputA.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse benchmark output for %s: %w", a.Label, err)
+	}
+	resultsB, err := e.parseBenchmarkOutput(outputB.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse benchmark output for %s: %w", b.Label, err)
+	}
+
+	env := parseEnvironment(outputA.String(), e.procs)
+	env.GoVersion = goVersion()
+	env.HostHash = hostHash()
+
+	result := &HeadToHeadResult{
+		A:           a,
+		B:           b,
+		ResultsA:    resultsA,
+		ResultsB:    resultsB,
+		Environment: env,
+	}
+
+	comparator := NewComparator()
+	if opts.Alpha > 0 {
+		comparator.Alpha = opts.Alpha
+	}
+	byName := make(map[string]*BenchmarkResult)
+	for _, r := range resultsA {
+		byName[r.BenchmarkName] = r
+	}
+	for _, r := range resultsB {
+		if baseline, ok := byName[r.BenchmarkName]; ok {
+			result.Comparisons = append(result.Comparisons, comparator.CompareResults(baseline, r))
+		}
+	}
+
+	return result, nil
+}
+
+// headToHeadBinary is the name of the compiled test binary in each package
+const headToHeadBinary = "bench.test"
+
+// stageVersion stages a version with the problem's test files and compiles
+// the test binary into the stage. The caller removes the returned directory.
+func stageVersion(version Version, testFiles []string) (string, error) {
+	// StageSolution copies the solution from a file
+	source, err := os.CreateTemp("", "dsa-bench-*.go")
+	if err != nil {
+		return "", fmt.Errorf("failed to write solution: %w", err)
+	}
+	defer os.Remove(source.Name())
+	_, err = source.WriteString(version.Code)
+	if closeErr := source.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to write solution: %w", err)
+	}
+
+	stageDir, err := solution.StageSolution(testFiles[0], source.Name(), testFiles[1:]...)
+	if err != nil {
+		return "", err
+	}
+
+	args := []string{"test", "-c", "-o", headToHeadBinary, "solution.go"}
+	for _, testFile := range testFiles {
+		args = append(args, filepath.Base(testFile))
+	}
+	cmd := exec.Command("go", args...)
+	cmd.Dir = stageDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.RemoveAll(stageDir)
+		return "", fmt.Errorf("failed to compile benchmarks: %w\nOutput: %s", err, string(output))
+	}
+
+	return stageDir, nil
+}
+
+// runTestBinary runs a compiled test binary once and appends its output
+func runTestBinary(dir string, args []string, output *strings.Builder) error {
+	// The binary runs in its stage, where the tests find their testdata
+	cmd := exec.Command("."+string(filepath.Separator)+headToHeadBinary, args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("benchmark execution failed: %w\nOutput: %s", err, string(out))
+	}
+	output.Write(out)
+	return nil
+}
@@ -0,0 +1,134 @@
+package benchmarking
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The versions use the structures helpers and the benchmarks use testify,
+// both of which only resolve inside the workspace module
+const bruteForceSum = `package solutions
+
+import "dsa-workspace/structures"
+
+func SumList(head *structures.ListNode) int {
+	total := 0
+	for a := head; a != nil; a = a.Next {
+		for b := head; b != nil; b = b.Next {
+			total += a.Val * b.Val
+		}
+	}
+	return total
+}
+`
+
+const linearSum = `package solutions
+
+import "dsa-workspace/structures"
+
+func SumList(head *structures.ListNode) int {
+	sum := 0
+	for n := head; n != nil; n = n.Next {
+		sum += n.Val
+	}
+	return sum * sum
+}
+`
+
+const sumListBenchmarks = `package problems
+
+import (
+	"fmt"
+	"testing"
+
+	"dsa-workspace/structures"
+	"github.com/stretchr/testify/require"
+)
+
+func BenchmarkSumList(b *testing.B) {
+	for _, size := range []int{8, 512} {
+		vals := make([]int, size)
+		for i := range vals {
+			vals[i] = i
+		}
+		head := structures.NewList(vals...)
+		require.NotNil(b, head)
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				SumList(head)
+			}
+		})
+	}
+}
+`
+
+func TestExecutor_HeadToHead(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiles and runs benchmarks")
+	}
+
+	tmpDir := t.TempDir()
+	_, err := workspace.Init(tmpDir, "")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "problems", "sum_list_test.go"), []byte(sumListBenchmarks), 0644))
+	originalDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalDir)
+
+	executor := NewExecutor()
+
+	t.Run("runs the problem's benchmarks on both versions", func(t *testing.T) {
+		result, err := executor.HeadToHead("sum-list",
+			Version{Label: "#2", Code: bruteForceSum},
+			Version{Label: "current", Code: linearSum},
+			HeadToHeadOptions{Count: 4, BenchTime: "200x"},
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, "#2", result.A.Label)
+		require.Len(t, result.ResultsA, 2)
+		require.Len(t, result.ResultsB, 2)
+		require.Len(t, result.Comparisons, 2)
+
+		large := result.Comparisons[1]
+		assert.Equal(t, "SumList/size=512", large.BenchmarkName)
+		assert.Equal(t, 4, large.OldSamples)
+		assert.Equal(t, 4, large.NewSamples)
+		assert.Less(t, large.TimeDeltaPercent, -50.0, "linear version should be much faster")
+		assert.Equal(t, DefaultAlpha, large.Alpha)
+		assert.NotEmpty(t, result.Environment.GOOS)
+
+		entries, err := os.ReadDir("problems")
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "stages are removed")
+	})
+
+	t.Run("reports compile errors with the version label", func(t *testing.T) {
+		broken := "package solutions\n\nfunc SumList(head any) int { return undefined }\n"
+
+		_, err := executor.HeadToHead("sum-list",
+			Version{Label: "#3", Code: broken},
+			Version{Label: "current", Code: linearSum},
+			HeadToHeadOptions{Count: 1, BenchTime: "1x"},
+		)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "version #3")
+	})
+
+	t.Run("requires benchmarks", func(t *testing.T) {
+		_, err := executor.HeadToHead("two-sum",
+			Version{Label: "#1", Code: linearSum},
+			Version{Label: "current", Code: linearSum},
+			HeadToHeadOptions{Count: 1, BenchTime: "1x"},
+		)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no benchmarks found")
+	})
+}
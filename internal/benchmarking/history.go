@@ -0,0 +1,69 @@
+package benchmarking
+
+import (
+	"sort"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+)
+
+// HistoryPoint is a stored benchmark result annotated with the submission
+// that produced it
+type HistoryPoint struct {
+	database.BenchmarkResult
+	SubmissionIndex  int  // 1-based index as shown by 'dsa history' (1 = most recent), 0 if unlinked
+	SubmissionPassed bool // Whether the linked submission passed its tests
+}
+
+// BuildHistory orders benchmarks oldest first and links each one to its
+// submission. submissions must be ordered most recent first, as returned by
+// solution.Service.GetHistory, so indexes match 'dsa history'.
+func BuildHistory(benchmarks []database.BenchmarkResult, submissions []solution.SubmissionRecord) []HistoryPoint {
+	type submissionRef struct {
+		index  int
+		passed bool
+	}
+	refs := make(map[uint]submissionRef, len(submissions))
+	for i, sub := range submissions {
+		refs[sub.ID] = submissionRef{index: i + 1, passed: sub.Passed}
+	}
+
+	points := make([]HistoryPoint, len(benchmarks))
+	for i, benchmark := range benchmarks {
+		point := HistoryPoint{BenchmarkResult: benchmark}
+		if benchmark.SolutionID != nil {
+			if ref, ok := refs[*benchmark.SolutionID]; ok {
+				point.SubmissionIndex = ref.index
+				point.SubmissionPassed = ref.passed
+			}
+		}
+		points[i] = point
+	}
+
+	// Oldest first
+	sort.SliceStable(points, func(i, j int) bool { return pointBefore(points[i], points[j]) })
+
+	return points
+}
+
+// pointBefore reports whether a was recorded before b
+func pointBefore(a, b HistoryPoint) bool {
+	if a.CreatedAt.Equal(b.CreatedAt) {
+		return a.ID < b.ID
+	}
+	return a.CreatedAt.Before(b.CreatedAt)
+}
+
+// GroupByName splits points into per-benchmark series, keeping the order
+// in which each name first appears
+func GroupByName(points []HistoryPoint) ([]string, map[string][]HistoryPoint) {
+	var names []string
+	series := make(map[string][]HistoryPoint)
+	for _, point := range points {
+		if _, ok := series[point.Name]; !ok {
+			names = append(names, point.Name)
+		}
+		series[point.Name] = append(series[point.Name], point)
+	}
+	return names, series
+}
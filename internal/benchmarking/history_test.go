@@ -0,0 +1,59 @@
+package benchmarking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildHistory(t *testing.T) {
+	t.Run("orders oldest first and links submissions", func(t *testing.T) {
+		now := time.Now()
+		linked := uint(20)
+		missing := uint(99)
+		benchmarks := []database.BenchmarkResult{
+			{ID: 3, Name: "TwoSum", NsPerOp: 900, SolutionID: &linked, CreatedAt: now},
+			{ID: 2, Name: "TwoSum", NsPerOp: 1100, SolutionID: &missing, CreatedAt: now.Add(-time.Hour)},
+			{ID: 1, Name: "TwoSum", NsPerOp: 1000, CreatedAt: now.Add(-2 * time.Hour)},
+		}
+		// Most recent first, as returned by solution.Service.GetHistory
+		submissions := []solution.SubmissionRecord{
+			{ID: 21, Passed: false},
+			{ID: 20, Passed: true},
+		}
+
+		points := BuildHistory(benchmarks, submissions)
+
+		require.Len(t, points, 3)
+		assert.Equal(t, 1000.0, points[0].NsPerOp)
+		assert.Equal(t, 0, points[0].SubmissionIndex)
+		assert.Equal(t, 0, points[1].SubmissionIndex) // Submission no longer exists
+		assert.Equal(t, 900.0, points[2].NsPerOp)
+		assert.Equal(t, 2, points[2].SubmissionIndex)
+		assert.True(t, points[2].SubmissionPassed)
+	})
+
+	t.Run("returns empty history for no benchmarks", func(t *testing.T) {
+		points := BuildHistory(nil, nil)
+
+		assert.Empty(t, points)
+	})
+}
+
+func TestGroupByName(t *testing.T) {
+	points := []HistoryPoint{
+		{BenchmarkResult: database.BenchmarkResult{Name: "B"}},
+		{BenchmarkResult: database.BenchmarkResult{Name: "A"}},
+		{BenchmarkResult: database.BenchmarkResult{Name: "B"}},
+	}
+
+	names, series := GroupByName(points)
+
+	assert.Equal(t, []string{"B", "A"}, names)
+	assert.Len(t, series["B"], 2)
+	assert.Len(t, series["A"], 1)
+}
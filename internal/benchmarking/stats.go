@@ -0,0 +1,152 @@
+package benchmarking
+
+import (
+	"math"
+	"sort"
+)
+
+// DefaultAlpha is the significance level below which a change is reported
+const DefaultAlpha = 0.05
+
+// maxExactSamples bounds the sample size for which the exact U distribution
+// is computed; larger samples use the normal approximation
+const maxExactSamples = 50
+
+// median returns the median of values, or 0 for an empty slice
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// spreadPercent returns half the range of values as a percentage of their
+// median, matching the "± x%" variation reported by benchstat
+func spreadPercent(values []float64) float64 {
+	m := median(values)
+	if len(values) < 2 || m == 0 {
+		return 0
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values[1:] {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	return (hi - lo) / 2 / m * 100
+}
+
+// MannWhitneyU performs a two-sided Mann-Whitney U test on two independent
+// samples and returns the U statistic of x and the p-value.
+//
+// The exact distribution of U is used for small samples without ties, which
+// is the common case for benchmark runs with -count; otherwise the normal
+// approximation with tie and continuity correction is used. Identical
+// samples yield p = 1.
+func MannWhitneyU(x, y []float64) (u float64, p float64) {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 0, 1
+	}
+
+	// Rank the pooled samples, giving tied values their average rank
+	type obs struct {
+		value float64
+		fromX bool
+	}
+	pooled := make([]obs, 0, n1+n2)
+	for _, v := range x {
+		pooled = append(pooled, obs{v, true})
+	}
+	for _, v := range y {
+		pooled = append(pooled, obs{v, false})
+	}
+	sort.Slice(pooled, func(i, j int) bool { return pooled[i].value < pooled[j].value })
+
+	var rankSumX, tieCorrection float64
+	hasTies := false
+	for i := 0; i < len(pooled); {
+		j := i
+		for j < len(pooled) && pooled[j].value == pooled[i].value {
+			j++
+		}
+		avgRank := float64(i+j+1) / 2 // ranks are 1-based: (i+1 + j) / 2
+		for k := i; k < j; k++ {
+			if pooled[k].fromX {
+				rankSumX += avgRank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			hasTies = true
+			tieCorrection += t*t*t - t
+		}
+		i = j
+	}
+
+	u = rankSumX - float64(n1*(n1+1))/2
+	uMin := math.Min(u, float64(n1*n2)-u)
+
+	if !hasTies && n1 <= maxExactSamples && n2 <= maxExactSamples {
+		return u, math.Min(1, 2*exactUCDF(int(uMin), n1, n2))
+	}
+
+	// Normal approximation
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance <= 0 {
+		return u, 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		return u, 1
+	}
+	return u, math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactUCDF returns P(U <= k) for sample sizes n1 and n2 under the null
+// hypothesis, counting rank arrangements with the recurrence
+// c(m, n, u) = c(m-1, n, u-n) + c(m, n-1, u)
+func exactUCDF(k, n1, n2 int) float64 {
+	// counts[n][u] holds c(m, n, u) for the current m
+	maxU := n1 * n2
+	counts := make([][]float64, n2+1)
+	for n := range counts {
+		counts[n] = make([]float64, maxU+1)
+		counts[n][0] = 1 // c(0, n, 0) = 1
+	}
+
+	for m := 1; m <= n1; m++ {
+		next := make([][]float64, n2+1)
+		next[0] = make([]float64, maxU+1)
+		next[0][0] = 1 // c(m, 0, 0) = 1
+		for n := 1; n <= n2; n++ {
+			next[n] = make([]float64, maxU+1)
+			for u := 0; u <= m*n; u++ {
+				if u >= n {
+					next[n][u] += counts[n][u-n]
+				}
+				next[n][u] += next[n-1][u]
+			}
+		}
+		counts = next
+	}
+
+	var total, cumulative float64
+	for u, c := range counts[n2] {
+		total += c
+		if u <= k {
+			cumulative += c
+		}
+	}
+	return cumulative / total
+}
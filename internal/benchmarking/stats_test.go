@@ -0,0 +1,60 @@
+package benchmarking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, 0.0, median(nil))
+	assert.Equal(t, 3.0, median([]float64{5, 1, 3}))
+	assert.Equal(t, 2.5, median([]float64{4, 1, 3, 2}))
+}
+
+func TestSpreadPercent(t *testing.T) {
+	assert.Equal(t, 0.0, spreadPercent([]float64{100}))
+	assert.InDelta(t, 10.0, spreadPercent([]float64{90, 100, 110}), 1e-9)
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	t.Run("completely separated samples are significant", func(t *testing.T) {
+		u, p := MannWhitneyU([]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10})
+
+		assert.Equal(t, 0.0, u)
+		assert.InDelta(t, 2.0/252.0, p, 1e-12) // Exact: 2 of C(10,5) arrangements
+	})
+
+	t.Run("interleaved samples are not significant", func(t *testing.T) {
+		_, p := MannWhitneyU([]float64{1, 3, 5, 7, 9}, []float64{2, 4, 6, 8, 10})
+
+		assert.Greater(t, p, 0.5)
+	})
+
+	t.Run("single samples can never be significant", func(t *testing.T) {
+		_, p := MannWhitneyU([]float64{100}, []float64{200})
+
+		assert.Equal(t, 1.0, p)
+	})
+
+	t.Run("identical samples have p of one", func(t *testing.T) {
+		_, p := MannWhitneyU([]float64{5, 5, 5}, []float64{5, 5, 5})
+
+		assert.Equal(t, 1.0, p)
+	})
+
+	t.Run("uses normal approximation with ties", func(t *testing.T) {
+		x := []float64{10, 10, 11, 11, 12, 12, 13, 13}
+		y := []float64{20, 20, 21, 21, 22, 22, 23, 23}
+
+		_, p := MannWhitneyU(x, y)
+
+		assert.Less(t, p, 0.01)
+	})
+
+	t.Run("empty sample has p of one", func(t *testing.T) {
+		_, p := MannWhitneyU(nil, []float64{1, 2})
+
+		assert.Equal(t, 1.0, p)
+	})
+}
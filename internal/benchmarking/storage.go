@@ -1,7 +1,9 @@
 package benchmarking
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
 	"gorm.io/gorm"
@@ -19,18 +21,85 @@ func NewStorage(db *gorm.DB) *Storage {
 
 // SaveBenchmark saves a benchmark result to the database
 func (s *Storage) SaveBenchmark(problemID uint, result *BenchmarkResult) error {
+	return s.SaveRun(problemID, nil, &RunResult{Results: []*BenchmarkResult{result}})
+}
+
+// SaveRun saves every benchmark of a run as a separate row in one transaction,
+// together with the run's environment, solution hash and variant.
+// solutionID links the rows to the submission that was benchmarked and may be nil.
+func (s *Storage) SaveRun(problemID uint, solutionID *uint, run *RunResult) error {
+	benchmarks := make([]database.BenchmarkResult, 0, len(run.Results))
+	for _, result := range run.Results {
+		benchmark, err := toModel(problemID, result)
+		if err != nil {
+			return err
+		}
+		benchmark.SolutionID = solutionID
+		benchmark.SolutionHash = run.SolutionHash
+		benchmark.GoVersion = run.Environment.GoVersion
+		benchmark.GOOS = run.Environment.GOOS
+		benchmark.GOARCH = run.Environment.GOARCH
+		benchmark.CPUModel = run.Environment.CPU
+		benchmark.GOMAXPROCS = run.Environment.GOMAXPROCS
+		benchmark.HostHash = run.Environment.HostHash
+		benchmark.Variant = run.Variant
+		benchmarks = append(benchmarks, *benchmark)
+	}
+
+	if len(benchmarks) == 0 {
+		return nil
+	}
+
+	if err := s.db.Create(&benchmarks).Error; err != nil {
+		return fmt.Errorf("failed to save benchmark: %w", err)
+	}
+
+	return nil
+}
+
+// toModel converts a parsed result into its database row
+func toModel(problemID uint, result *BenchmarkResult) (*database.BenchmarkResult, error) {
 	benchmark := &database.BenchmarkResult{
 		ProblemID:   problemID,
+		Name:        result.BenchmarkName,
+		Iterations:  result.Iterations,
 		NsPerOp:     result.NsPerOp,
 		AllocsPerOp: result.AllocsPerOp,
 		BytesPerOp:  result.BytesPerOp,
+		Count:       result.SampleCount(),
 	}
 
-	if err := s.db.Create(benchmark).Error; err != nil {
-		return fmt.Errorf("failed to save benchmark: %w", err)
+	if len(result.Samples) > 0 {
+		samples, err := json.Marshal(result.Samples)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode samples for %s: %w", result.BenchmarkName, err)
+		}
+		benchmark.Samples = string(samples)
 	}
 
-	return nil
+	if len(result.Metrics) > 0 {
+		metrics, err := json.Marshal(result.Metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode metrics for %s: %w", result.BenchmarkName, err)
+		}
+		benchmark.Metrics = string(metrics)
+	}
+
+	return benchmark, nil
+}
+
+// SamplesFromModel returns the stored samples of a benchmark row keyed by unit.
+// Rows saved before samples were recorded yield their single stored value.
+func SamplesFromModel(benchmark *database.BenchmarkResult) map[string][]float64 {
+	samples := make(map[string][]float64)
+	if benchmark.Samples != "" && json.Unmarshal([]byte(benchmark.Samples), &samples) == nil && len(samples) > 0 {
+		return samples
+	}
+
+	samples["ns/op"] = []float64{benchmark.NsPerOp}
+	samples["B/op"] = []float64{benchmark.BytesPerOp}
+	samples["allocs/op"] = []float64{benchmark.AllocsPerOp}
+	return samples
 }
 
 // GetBestBenchmark retrieves the best (fastest) benchmark result for a problem
@@ -52,6 +121,77 @@ func (s *Storage) GetBestBenchmark(problemID uint) (*database.BenchmarkResult, e
 	return &benchmark, nil
 }
 
+// GetBestBenchmarkByName retrieves the fastest result recorded for one named benchmark
+func (s *Storage) GetBestBenchmarkByName(problemID uint, name string) (*database.BenchmarkResult, error) {
+	var benchmark database.BenchmarkResult
+
+	err := s.db.Where("problem_id = ? AND name = ?", problemID, name).
+		Order("ns_per_op ASC").
+		First(&benchmark).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // No previous benchmarks with this name
+		}
+		return nil, fmt.Errorf("failed to query best benchmark: %w", err)
+	}
+
+	return &benchmark, nil
+}
+
+// FindBaseline retrieves the most recent saved result to compare a benchmark
+// against. Only results of the same solution variant are considered, so
+// different approaches to a problem are never compared with each other here.
+//
+// Only results recorded in the same environment are used unless allowCrossEnv
+// is set. When the latest available result comes from another environment the
+// differences are returned; the baseline is nil if the comparison was refused.
+func (s *Storage) FindBaseline(problemID uint, name, variant string, env Environment, allowCrossEnv bool) (*database.BenchmarkResult, []string, error) {
+	return s.findResult(problemID, name, variant, env, allowCrossEnv, "created_at DESC, id DESC")
+}
+
+// FindBest retrieves the fastest saved result of a benchmark, under the same
+// rules as FindBaseline
+func (s *Storage) FindBest(problemID uint, name, variant string, env Environment, allowCrossEnv bool) (*database.BenchmarkResult, error) {
+	best, _, err := s.findResult(problemID, name, variant, env, allowCrossEnv, "ns_per_op ASC")
+	return best, err
+}
+
+// findResult retrieves the first result of a benchmark in order, preferring
+// results from the same environment
+func (s *Storage) findResult(problemID uint, name, variant string, env Environment, allowCrossEnv bool, order string) (*database.BenchmarkResult, []string, error) {
+	var benchmark database.BenchmarkResult
+
+	err := s.db.Where("problem_id = ? AND name = ? AND variant = ?", problemID, name, variant).
+		Where("go_version = ? AND goos = ? AND goarch = ? AND cpu_model = ? AND gomaxprocs = ? AND host_hash = ?",
+			env.GoVersion, env.GOOS, env.GOARCH, env.CPU, env.GOMAXPROCS, env.HostHash).
+		Order(order).
+		First(&benchmark).Error
+	if err == nil {
+		return &benchmark, nil, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, nil, fmt.Errorf("failed to query previous benchmark: %w", err)
+	}
+
+	// No result from this environment; check for results from others
+	err = s.db.Where("problem_id = ? AND name = ? AND variant = ?", problemID, name, variant).
+		Order(order).
+		First(&benchmark).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query previous benchmark: %w", err)
+	}
+
+	mismatches := env.Mismatches(EnvironmentFromModel(&benchmark))
+	if !allowCrossEnv {
+		return nil, mismatches, nil
+	}
+	return &benchmark, mismatches, nil
+}
+
 // GetBenchmarkHistory retrieves all benchmark results for a problem
 func (s *Storage) GetBenchmarkHistory(problemID uint) ([]database.BenchmarkResult, error) {
 	var benchmarks []database.BenchmarkResult
@@ -66,3 +206,34 @@ func (s *Storage) GetBenchmarkHistory(problemID uint) ([]database.BenchmarkResul
 
 	return benchmarks, nil
 }
+
+// GetSolutionBenchmarks retrieves the most recent saved result of every
+// benchmark run on a solution, matched by linked submission or by the hash of
+// its code. A zero solutionID matches by code only. Results keep the order in
+// which they were saved.
+func (s *Storage) GetSolutionBenchmarks(problemID, solutionID uint, code string) ([]database.BenchmarkResult, error) {
+	var benchmarks []database.BenchmarkResult
+
+	query := s.db.Where("problem_id = ?", problemID)
+	if solutionID != 0 {
+		query = query.Where("solution_id = ? OR solution_hash = ?", solutionID, CodeHash(code))
+	} else {
+		query = query.Where("solution_hash = ?", CodeHash(code))
+	}
+	if err := query.Order("created_at DESC, id DESC").Find(&benchmarks).Error; err != nil {
+		return nil, fmt.Errorf("failed to query solution benchmarks: %w", err)
+	}
+
+	// Keep the latest result per name
+	seen := make(map[string]bool)
+	latest := make([]database.BenchmarkResult, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		if !seen[benchmark.Name] {
+			seen[benchmark.Name] = true
+			latest = append(latest, benchmark)
+		}
+	}
+	sort.SliceStable(latest, func(i, j int) bool { return latest[i].ID < latest[j].ID })
+
+	return latest, nil
+}
@@ -48,6 +48,188 @@ func TestStorage_SaveBenchmark(t *testing.T) {
 	})
 }
 
+func TestStorage_SaveRun(t *testing.T) {
+	t.Run("saves each benchmark as a separate row", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+
+		results := []*BenchmarkResult{
+			{BenchmarkName: "TwoSum/size=100", Iterations: 500000, NsPerOp: 2100},
+			{BenchmarkName: "TwoSum/size=1000", Iterations: 50000, NsPerOp: 21000, Metrics: map[string]float64{"MB/s": 85.5}},
+		}
+
+		err := storage.SaveRun(1, nil, &RunResult{Results: results})
+		require.NoError(t, err)
+
+		var saved []database.BenchmarkResult
+		db.Order("id ASC").Find(&saved)
+		require.Len(t, saved, 2)
+		assert.Equal(t, "TwoSum/size=100", saved[0].Name)
+		assert.Equal(t, 500000, saved[0].Iterations)
+		assert.Empty(t, saved[0].Metrics)
+		assert.Equal(t, "TwoSum/size=1000", saved[1].Name)
+		assert.JSONEq(t, `{"MB/s": 85.5}`, saved[1].Metrics)
+	})
+
+	t.Run("stores environment and solution hash on every row", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+		solutionID := uint(7)
+
+		run := &RunResult{
+			Results:      []*BenchmarkResult{{BenchmarkName: "TwoSum", NsPerOp: 1000}},
+			Environment:  testEnvironment(),
+			SolutionHash: "abc123",
+		}
+
+		err := storage.SaveRun(1, &solutionID, run)
+		require.NoError(t, err)
+
+		var saved database.BenchmarkResult
+		db.First(&saved)
+		assert.Equal(t, "go1.25.3", saved.GoVersion)
+		assert.Equal(t, "linux", saved.GOOS)
+		assert.Equal(t, "amd64", saved.GOARCH)
+		assert.Equal(t, "Test CPU @ 3.00GHz", saved.CPUModel)
+		assert.Equal(t, 8, saved.GOMAXPROCS)
+		assert.Equal(t, "host1", saved.HostHash)
+		assert.Equal(t, "abc123", saved.SolutionHash)
+		require.NotNil(t, saved.SolutionID)
+		assert.Equal(t, uint(7), *saved.SolutionID)
+	})
+
+	t.Run("does nothing for empty results", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+
+		err := storage.SaveRun(1, nil, &RunResult{})
+
+		assert.NoError(t, err)
+	})
+}
+
+// testEnvironment returns a fixed environment for storage tests
+func testEnvironment() Environment {
+	return Environment{
+		GoVersion:  "go1.25.3",
+		GOOS:       "linux",
+		GOARCH:     "amd64",
+		CPU:        "Test CPU @ 3.00GHz",
+		GOMAXPROCS: 8,
+		HostHash:   "host1",
+	}
+}
+
+func TestStorage_FindBaseline(t *testing.T) {
+	saveIn := func(t *testing.T, storage *Storage, env Environment, nsPerOp float64) {
+		run := &RunResult{
+			Results:     []*BenchmarkResult{{BenchmarkName: "TwoSum", NsPerOp: nsPerOp}},
+			Environment: env,
+		}
+		require.NoError(t, storage.SaveRun(1, nil, run))
+	}
+
+	t.Run("prefers results from the same environment", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+		other := testEnvironment()
+		other.CPU = "Other CPU"
+		saveIn(t, storage, testEnvironment(), 1500)
+		saveIn(t, storage, other, 500)
+
+		baseline, mismatches, err := storage.FindBaseline(1, "TwoSum", "", testEnvironment(), false)
+
+		assert.NoError(t, err)
+		require.NotNil(t, baseline)
+		assert.Equal(t, 1500.0, baseline.NsPerOp)
+		assert.Empty(t, mismatches)
+	})
+
+	t.Run("uses the most recent run, not the fastest", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+		saveIn(t, storage, testEnvironment(), 900)
+		saveIn(t, storage, testEnvironment(), 1200)
+
+		baseline, _, err := storage.FindBaseline(1, "TwoSum", "", testEnvironment(), false)
+		assert.NoError(t, err)
+		require.NotNil(t, baseline)
+		assert.Equal(t, 1200.0, baseline.NsPerOp)
+
+		best, err := storage.FindBest(1, "TwoSum", "", testEnvironment(), false)
+		assert.NoError(t, err)
+		require.NotNil(t, best)
+		assert.Equal(t, 900.0, best.NsPerOp)
+	})
+
+	t.Run("refuses cross-environment baseline by default", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+		other := testEnvironment()
+		other.GOMAXPROCS = 4
+		saveIn(t, storage, other, 500)
+
+		baseline, mismatches, err := storage.FindBaseline(1, "TwoSum", "", testEnvironment(), false)
+
+		assert.NoError(t, err)
+		assert.Nil(t, baseline)
+		assert.Equal(t, []string{"GOMAXPROCS: 8 vs 4"}, mismatches)
+	})
+
+	t.Run("uses cross-environment baseline when allowed", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+		other := testEnvironment()
+		other.HostHash = "host2"
+		saveIn(t, storage, other, 500)
+
+		baseline, mismatches, err := storage.FindBaseline(1, "TwoSum", "", testEnvironment(), true)
+
+		assert.NoError(t, err)
+		require.NotNil(t, baseline)
+		assert.Equal(t, 500.0, baseline.NsPerOp)
+		assert.Equal(t, []string{"host: host1 vs host2"}, mismatches)
+	})
+
+	t.Run("returns nothing without previous results", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+
+		baseline, mismatches, err := storage.FindBaseline(1, "TwoSum", "", testEnvironment(), false)
+
+		assert.NoError(t, err)
+		assert.Nil(t, baseline)
+		assert.Empty(t, mismatches)
+	})
+}
+
+func TestStorage_GetBestBenchmarkByName(t *testing.T) {
+	t.Run("returns fastest result for the named benchmark only", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+
+		db.Create(&database.BenchmarkResult{ProblemID: 1, Name: "TwoSum/size=100", NsPerOp: 2000})
+		db.Create(&database.BenchmarkResult{ProblemID: 1, Name: "TwoSum/size=100", NsPerOp: 1800})
+		db.Create(&database.BenchmarkResult{ProblemID: 1, Name: "TwoSum/size=10", NsPerOp: 200})
+
+		best, err := storage.GetBestBenchmarkByName(1, "TwoSum/size=100")
+
+		assert.NoError(t, err)
+		require.NotNil(t, best)
+		assert.Equal(t, 1800.0, best.NsPerOp)
+	})
+
+	t.Run("returns nil when name has no results", func(t *testing.T) {
+		db := setupTestDB(t)
+		storage := NewStorage(db)
+
+		best, err := storage.GetBestBenchmarkByName(1, "Missing")
+
+		assert.NoError(t, err)
+		assert.Nil(t, best)
+	})
+}
+
 func TestStorage_GetBestBenchmark(t *testing.T) {
 	t.Run("returns fastest benchmark", func(t *testing.T) {
 		db := setupTestDB(t)
@@ -135,3 +317,40 @@ func TestStorage_GetBenchmarkHistory(t *testing.T) {
 		assert.Len(t, history, 0)
 	})
 }
+
+func TestStorage_GetSolutionBenchmarks(t *testing.T) {
+	db := setupTestDB(t)
+	storage := NewStorage(db)
+
+	code := "package solutions\n\nfunc TwoSum() {}\n"
+	linkedID := uint(7)
+	now := time.Now()
+	benchmarks := []database.BenchmarkResult{
+		{ProblemID: 1, Name: "TwoSum/size=100", NsPerOp: 300, SolutionHash: CodeHash(code), CreatedAt: now.Add(-2 * time.Hour)},
+		{ProblemID: 1, Name: "TwoSum/size=1000", NsPerOp: 3000, SolutionHash: CodeHash(code), CreatedAt: now.Add(-2 * time.Hour)},
+		{ProblemID: 1, Name: "TwoSum/size=100", NsPerOp: 200, SolutionHash: CodeHash(code), CreatedAt: now.Add(-time.Hour)},
+		{ProblemID: 1, Name: "TwoSum/size=100", NsPerOp: 100, SolutionHash: CodeHash("other"), CreatedAt: now},
+		{ProblemID: 1, Name: "TwoSum/size=10000", NsPerOp: 30000, SolutionID: &linkedID, CreatedAt: now},
+		{ProblemID: 2, Name: "TwoSum/size=100", NsPerOp: 50, SolutionHash: CodeHash(code), CreatedAt: now},
+	}
+	for i := range benchmarks {
+		require.NoError(t, db.Create(&benchmarks[i]).Error)
+	}
+
+	t.Run("matches by code hash", func(t *testing.T) {
+		results, err := storage.GetSolutionBenchmarks(1, 0, code)
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "TwoSum/size=1000", results[0].Name)
+		assert.Equal(t, "TwoSum/size=100", results[1].Name)
+		assert.Equal(t, 200.0, results[1].NsPerOp) // Latest result per name
+	})
+
+	t.Run("also matches linked submission", func(t *testing.T) {
+		results, err := storage.GetSolutionBenchmarks(1, linkedID, code)
+
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+	})
+}
@@ -0,0 +1,105 @@
+// Package complexity estimates the empirical time complexity of a solution
+// by benchmarking it across a ladder of input sizes and fitting the timings
+// against common complexity models.
+package complexity
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Class is an asymptotic time complexity class, ordered from best to worst
+type Class int
+
+const (
+	Constant     Class = iota // O(1)
+	Logarithmic               // O(log n)
+	Linear                    // O(n)
+	Linearithmic              // O(n log n)
+	Quadratic                 // O(n^2)
+	Exponential               // O(2^n)
+)
+
+// AllClasses returns every supported class from best to worst
+func AllClasses() []Class {
+	return []Class{Constant, Logarithmic, Linear, Linearithmic, Quadratic, Exponential}
+}
+
+// String returns the big-O notation for the class
+func (c Class) String() string {
+	switch c {
+	case Constant:
+		return "O(1)"
+	case Logarithmic:
+		return "O(log n)"
+	case Linear:
+		return "O(n)"
+	case Linearithmic:
+		return "O(n log n)"
+	case Quadratic:
+		return "O(n^2)"
+	case Exponential:
+		return "O(2^n)"
+	default:
+		return fmt.Sprintf("Class(%d)", int(c))
+	}
+}
+
+// WorseThan reports whether c grows faster than other
+func (c Class) WorseThan(other Class) bool {
+	return c > other
+}
+
+// ParseClass parses big-O notation such as "O(n log n)", "o(n^2)" or "O(n²)"
+func ParseClass(s string) (Class, error) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(s), ""))
+	normalized = strings.ReplaceAll(normalized, "²", "^2")
+	normalized = strings.ReplaceAll(normalized, "*", "")
+	normalized = strings.TrimPrefix(normalized, "o(")
+	normalized = strings.TrimSuffix(normalized, ")")
+
+	switch normalized {
+	case "1":
+		return Constant, nil
+	case "logn", "lgn":
+		return Logarithmic, nil
+	case "n":
+		return Linear, nil
+	case "nlogn", "nlgn":
+		return Linearithmic, nil
+	case "n^2", "n2", "nn":
+		return Quadratic, nil
+	case "2^n":
+		return Exponential, nil
+	default:
+		return 0, fmt.Errorf("unknown complexity class %q (supported: O(1), O(log n), O(n), O(n log n), O(n^2), O(2^n))", s)
+	}
+}
+
+// scaled evaluates the class growth function at n, divided by its value at
+// nMax. Normalizing keeps O(2^n) finite for large input sizes.
+func (c Class) scaled(n, nMax float64) float64 {
+	switch c {
+	case Constant:
+		return 0
+	case Logarithmic:
+		if nMax <= 1 {
+			return 0
+		}
+		return math.Log2(n) / math.Log2(nMax)
+	case Linear:
+		return n / nMax
+	case Linearithmic:
+		if nMax <= 1 {
+			return 0
+		}
+		return (n * math.Log2(n)) / (nMax * math.Log2(nMax))
+	case Quadratic:
+		return (n * n) / (nMax * nMax)
+	case Exponential:
+		return math.Exp2(n - nMax)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,52 @@
+package complexity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClass(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Class
+	}{
+		{"O(1)", Constant},
+		{"O(log n)", Logarithmic},
+		{"o(logn)", Logarithmic},
+		{"O(n)", Linear},
+		{"O(n log n)", Linearithmic},
+		{"O(n * log n)", Linearithmic},
+		{"O(n^2)", Quadratic},
+		{"O(n²)", Quadratic},
+		{"O(2^n)", Exponential},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			class, err := ParseClass(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, class)
+		})
+	}
+
+	t.Run("rejects unknown class", func(t *testing.T) {
+		_, err := ParseClass("O(n!)")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown complexity class")
+	})
+}
+
+func TestClass_StringRoundTrip(t *testing.T) {
+	for _, class := range AllClasses() {
+		parsed, err := ParseClass(class.String())
+		assert.NoError(t, err)
+		assert.Equal(t, class, parsed)
+	}
+}
+
+func TestClass_WorseThan(t *testing.T) {
+	assert.True(t, Quadratic.WorseThan(Linear))
+	assert.False(t, Linear.WorseThan(Linear))
+	assert.False(t, Logarithmic.WorseThan(Linearithmic))
+}
@@ -0,0 +1,111 @@
+package complexity
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultSizes is the input size ladder used when none is specified
+var DefaultSizes = []int{128, 256, 512, 1024, 2048, 4096, 8192, 16384}
+
+// Options controls an estimation run
+type Options struct {
+	Sizes     []int  // Input sizes to benchmark
+	BenchTime string // Per-size benchmark duration passed to -benchtime
+}
+
+// Estimator benchmarks a solution across input sizes and fits the timings
+type Estimator struct{}
+
+// NewEstimator creates a new complexity estimator
+func NewEstimator() *Estimator {
+	return &Estimator{}
+}
+
+// Result is the outcome of an estimation run
+type Result struct {
+	*Estimate
+	Signature *Signature
+	RawOutput string
+}
+
+// Estimate generates a benchmark harness next to the solution file, runs it
+// for every input size, and fits the measured ns/op against all classes.
+func (e *Estimator) Estimate(solutionPath, funcName string, opts Options) (*Result, error) {
+	sizes := opts.Sizes
+	if len(sizes) == 0 {
+		sizes = DefaultSizes
+	}
+
+	sig, err := ParseSignature(solutionPath, funcName)
+	if err != nil {
+		return nil, err
+	}
+
+	harness, err := GenerateHarness(sig, sizes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The harness must live in the solution's directory so both files form
+	// one package when passed to go test as a file list
+	base := strings.TrimSuffix(filepath.Base(solutionPath), ".go")
+	harnessPath := filepath.Join(filepath.Dir(solutionPath), base+"_complexity_test.go")
+	if err := os.WriteFile(harnessPath, harness, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write benchmark harness: %w", err)
+	}
+	defer os.Remove(harnessPath)
+
+	args := []string{"test", "-run=^$", fmt.Sprintf("-bench=^%s$", HarnessFuncName)}
+	if opts.BenchTime != "" {
+		args = append(args, fmt.Sprintf("-benchtime=%s", opts.BenchTime))
+	}
+	args = append(args, solutionPath, harnessPath)
+
+	cmd := exec.Command("go", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("benchmark execution failed: %w\nOutput: %s", err, string(output))
+	}
+
+	samples, err := parseSamples(string(output))
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := Fit(samples)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Estimate:  estimate,
+		Signature: sig,
+		RawOutput: string(output),
+	}, nil
+}
+
+// samplePattern matches harness sub-benchmark lines:
+// BenchmarkComplexity/n=1024-8    123456    987.6 ns/op
+var samplePattern = regexp.MustCompile(HarnessFuncName + `/n=(\d+)(?:-\d+)?\s+\d+\s+([\d.]+) ns/op`)
+
+// parseSamples extracts per-size timings from benchmark output
+func parseSamples(output string) ([]Sample, error) {
+	var samples []Sample
+	for _, match := range samplePattern.FindAllStringSubmatch(output, -1) {
+		n, _ := strconv.Atoi(match[1])
+		nsPerOp, _ := strconv.ParseFloat(match[2], 64)
+		samples = append(samples, Sample{N: n, NsPerOp: nsPerOp})
+	}
+
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no benchmark results found in output")
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,171 @@
+package complexity
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Sample is a single timing measurement at one input size
+type Sample struct {
+	N       int
+	NsPerOp float64
+}
+
+// ModelFit describes how well one complexity class explains the samples.
+// Each model is fitted as t(n) = Intercept + Slope*f(n) by least squares,
+// where f is the class growth function normalized to 1 at the largest size.
+type ModelFit struct {
+	Class     Class
+	Intercept float64
+	Slope     float64
+	RSS       float64 // Residual sum of squares
+	RSquared  float64 // Coefficient of determination
+	BIC       float64 // Bayesian information criterion (lower is better)
+	Weight    float64 // Relative likelihood of this model among all fits (0.0 - 1.0)
+}
+
+// Estimate is the result of fitting samples against all complexity classes
+type Estimate struct {
+	Class      Class      // Best-fit class
+	Confidence float64    // Weight of the best-fit model (0.0 - 1.0)
+	Fits       []ModelFit // All model fits, best first
+	Samples    []Sample   // Samples sorted by input size
+}
+
+// minDistinctSizes is the minimum number of input sizes required for a fit
+const minDistinctSizes = 3
+
+// maxExponentialSize is the largest input size for which O(2^n) is fitted.
+// Beyond it 2^(n-nMax) vanishes for every size but the largest, so the
+// model would fit a single slow sample exactly.
+const maxExponentialSize = 30
+
+// Fit selects the complexity class that best explains the samples.
+//
+// Models are compared with the Bayesian information criterion so that the
+// constant model (one parameter) is not beaten by a two-parameter model that
+// merely fits noise. Confidence is the Schwarz weight of the best model.
+func Fit(samples []Sample) (*Estimate, error) {
+	sorted := make([]Sample, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].N < sorted[j].N })
+
+	distinct := 0
+	for i, s := range sorted {
+		if s.N <= 0 {
+			return nil, fmt.Errorf("input sizes must be positive, got %d", s.N)
+		}
+		if i == 0 || s.N != sorted[i-1].N {
+			distinct++
+		}
+	}
+	if distinct < minDistinctSizes {
+		return nil, fmt.Errorf("need at least %d distinct input sizes to estimate complexity, got %d", minDistinctSizes, distinct)
+	}
+
+	nMax := float64(sorted[len(sorted)-1].N)
+	m := float64(len(sorted))
+
+	// Floor for RSS so a perfect fit doesn't produce log(0)
+	var sumSquares, mean float64
+	for _, s := range sorted {
+		sumSquares += s.NsPerOp * s.NsPerOp
+		mean += s.NsPerOp
+	}
+	mean /= m
+	rssFloor := sumSquares*1e-12 + math.SmallestNonzeroFloat64
+
+	var tss float64
+	for _, s := range sorted {
+		tss += (s.NsPerOp - mean) * (s.NsPerOp - mean)
+	}
+
+	fits := make([]ModelFit, 0, len(AllClasses()))
+	for _, class := range AllClasses() {
+		if class == Exponential && nMax > maxExponentialSize {
+			continue
+		}
+		fit := fitModel(class, sorted, nMax)
+
+		params := 2.0
+		if class == Constant {
+			params = 1.0
+		}
+		fit.BIC = m*math.Log(math.Max(fit.RSS, rssFloor)/m) + params*math.Log(m)
+
+		// A growth model fitted with zero slope is just the constant model
+		// with an extra parameter; it carries no evidence for its class
+		if class != Constant && fit.Slope == 0 {
+			fit.BIC = math.Inf(1)
+		}
+
+		if tss > 0 {
+			fit.RSquared = 1 - fit.RSS/tss
+		} else {
+			fit.RSquared = 1
+		}
+
+		fits = append(fits, fit)
+	}
+
+	// Best model first; ties go to the simpler class
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].BIC < fits[j].BIC })
+
+	var totalWeight float64
+	for i := range fits {
+		fits[i].Weight = math.Exp(-(fits[i].BIC - fits[0].BIC) / 2)
+		totalWeight += fits[i].Weight
+	}
+	for i := range fits {
+		fits[i].Weight /= totalWeight
+	}
+
+	return &Estimate{
+		Class:      fits[0].Class,
+		Confidence: fits[0].Weight,
+		Fits:       fits,
+		Samples:    sorted,
+	}, nil
+}
+
+// fitModel performs a least-squares fit of t = a + b*f(n) with b >= 0
+func fitModel(class Class, samples []Sample, nMax float64) ModelFit {
+	m := float64(len(samples))
+
+	var meanX, meanY float64
+	xs := make([]float64, len(samples))
+	for i, s := range samples {
+		xs[i] = class.scaled(float64(s.N), nMax)
+		meanX += xs[i]
+		meanY += s.NsPerOp
+	}
+	meanX /= m
+	meanY /= m
+
+	var covXY, varX float64
+	for i, s := range samples {
+		covXY += (xs[i] - meanX) * (s.NsPerOp - meanY)
+		varX += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+
+	// A decreasing or flat curve degenerates to the constant model
+	intercept, slope := meanY, 0.0
+	if varX > 0 && covXY > 0 {
+		slope = covXY / varX
+		intercept = meanY - slope*meanX
+	}
+
+	var rss float64
+	for i, s := range samples {
+		residual := s.NsPerOp - (intercept + slope*xs[i])
+		rss += residual * residual
+	}
+
+	return ModelFit{
+		Class:     class,
+		Intercept: intercept,
+		Slope:     slope,
+		RSS:       rss,
+	}
+}
@@ -0,0 +1,95 @@
+package complexity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// samplesFor generates noise-free samples following f(n) with a fixed overhead
+func samplesFor(sizes []int, f func(n float64) float64) []Sample {
+	samples := make([]Sample, len(sizes))
+	for i, n := range sizes {
+		samples[i] = Sample{N: n, NsPerOp: 50 + f(float64(n))}
+	}
+	return samples
+}
+
+func TestFit_IdentifiesClasses(t *testing.T) {
+	sizes := []int{128, 256, 512, 1024, 2048, 4096, 8192}
+
+	tests := []struct {
+		name     string
+		f        func(n float64) float64
+		expected Class
+	}{
+		{"constant", func(n float64) float64 { return 0 }, Constant},
+		{"logarithmic", func(n float64) float64 { return 20 * math.Log2(n) }, Logarithmic},
+		{"linear", func(n float64) float64 { return 3 * n }, Linear},
+		{"linearithmic", func(n float64) float64 { return 2 * n * math.Log2(n) }, Linearithmic},
+		{"quadratic", func(n float64) float64 { return 0.5 * n * n }, Quadratic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est, err := Fit(samplesFor(sizes, tt.f))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, est.Class)
+			assert.Greater(t, est.Confidence, 0.9)
+		})
+	}
+
+	t.Run("exponential", func(t *testing.T) {
+		est, err := Fit(samplesFor([]int{10, 12, 14, 16, 18, 20}, math.Exp2))
+		require.NoError(t, err)
+		assert.Equal(t, Exponential, est.Class)
+	})
+}
+
+func TestFit_NoisyConstantPrefersConstant(t *testing.T) {
+	samples := []Sample{
+		{N: 100, NsPerOp: 101}, {N: 200, NsPerOp: 99}, {N: 400, NsPerOp: 100},
+		{N: 800, NsPerOp: 98}, {N: 1600, NsPerOp: 102}, {N: 3200, NsPerOp: 100},
+	}
+
+	est, err := Fit(samples)
+
+	require.NoError(t, err)
+	assert.Equal(t, Constant, est.Class)
+}
+
+func TestFit_WeightsSumToOne(t *testing.T) {
+	est, err := Fit(samplesFor([]int{100, 200, 400, 800}, func(n float64) float64 { return n }))
+	require.NoError(t, err)
+
+	var total float64
+	for _, fit := range est.Fits {
+		total += fit.Weight
+	}
+	assert.InDelta(t, 1.0, total, 1e-9)
+	assert.Equal(t, est.Fits[0].Class, est.Class)
+}
+
+func TestFit_RequiresDistinctSizes(t *testing.T) {
+	_, err := Fit([]Sample{{N: 100, NsPerOp: 1}, {N: 100, NsPerOp: 2}, {N: 200, NsPerOp: 3}})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "at least 3 distinct input sizes")
+}
+
+func TestFit_LinearWithOutlierIsNotExponential(t *testing.T) {
+	// On a ladder of large sizes 2^n vanishes everywhere but the largest
+	// size, so it would fit a single slow sample exactly
+	samples := samplesFor([]int{128, 256, 512, 1024, 2048, 4096, 8192}, func(n float64) float64 { return 3 * n })
+	samples[len(samples)-1].NsPerOp *= 4
+
+	est, err := Fit(samples)
+
+	require.NoError(t, err)
+	assert.NotEqual(t, Exponential, est.Class)
+	for _, fit := range est.Fits {
+		assert.NotEqual(t, Exponential, fit.Class, "exponential is not fitted to large sizes")
+	}
+}
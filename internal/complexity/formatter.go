@@ -0,0 +1,70 @@
+package complexity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Formatter handles formatting complexity estimates for display
+type Formatter struct{}
+
+// NewFormatter creates a new estimate formatter
+func NewFormatter() *Formatter {
+	return &Formatter{}
+}
+
+// FormatEstimate formats the measured timings and model fits
+func (f *Formatter) FormatEstimate(est *Estimate) string {
+	var output strings.Builder
+
+	output.WriteString("\nMeasurements:\n")
+	output.WriteString(fmt.Sprintf("  %10s  %14s\n", "n", "time/op"))
+	for _, s := range est.Samples {
+		output.WriteString(fmt.Sprintf("  %10d  %14s\n", s.N, formatNs(s.NsPerOp)))
+	}
+
+	output.WriteString("\nModel fits (best first):\n")
+	output.WriteString(fmt.Sprintf("  %-12s  %8s  %10s\n", "Class", "R²", "Weight"))
+	for _, fit := range est.Fits {
+		output.WriteString(fmt.Sprintf("  %-12s  %8.4f  %9.1f%%\n", fit.Class, fit.RSquared, fit.Weight*100))
+	}
+
+	output.WriteString(fmt.Sprintf("\nEstimated complexity: %s (%.0f%% confidence, %s)\n",
+		est.Class, est.Confidence*100, ConfidenceLabel(est.Confidence)))
+
+	return output.String()
+}
+
+// FormatTargetCheck compares the estimate against a target class
+func (f *Formatter) FormatTargetCheck(est *Estimate, target Class) string {
+	if est.Class.WorseThan(target) {
+		return fmt.Sprintf("⚠️  Measured %s is worse than target %s\n", est.Class, target)
+	}
+	return fmt.Sprintf("✓ Meets target complexity %s\n", target)
+}
+
+// ConfidenceLabel buckets a confidence value into a human-readable label
+func ConfidenceLabel(confidence float64) string {
+	switch {
+	case confidence >= 0.9:
+		return "high"
+	case confidence >= 0.6:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// formatNs formats nanoseconds to an appropriate time unit
+func formatNs(ns float64) string {
+	switch {
+	case ns < 1000:
+		return fmt.Sprintf("%.2f ns", ns)
+	case ns < 1000000:
+		return fmt.Sprintf("%.3f µs", ns/1000)
+	case ns < 1000000000:
+		return fmt.Sprintf("%.3f ms", ns/1000000)
+	default:
+		return fmt.Sprintf("%.3f s", ns/1000000000)
+	}
+}
@@ -0,0 +1,268 @@
+package complexity
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// Signature describes the solution function the harness calls
+type Signature struct {
+	Package  string
+	FuncName string
+	Params   []Param
+	Results  []string // Result type expressions
+}
+
+// Param is a single function parameter
+type Param struct {
+	Name string
+	Type string
+}
+
+// ParseSignature finds the named function in a Go source file. If funcName is
+// empty or not found, the first exported top-level function is used instead.
+func ParseSignature(filePath, funcName string) (*Signature, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read solution file: %w", err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse solution file: %w", err)
+	}
+
+	var target, fallback *ast.FuncDecl
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		if fn.Name.Name == funcName {
+			target = fn
+			break
+		}
+		if fallback == nil && fn.Name.IsExported() {
+			fallback = fn
+		}
+	}
+	if target == nil {
+		target = fallback
+	}
+	if target == nil {
+		return nil, fmt.Errorf("no exported function found in %s", filePath)
+	}
+
+	sig := &Signature{
+		Package:  file.Name.Name,
+		FuncName: target.Name.Name,
+	}
+
+	for i, field := range target.Type.Params.List {
+		typeExpr := exprString(fset, src, field.Type)
+		if len(field.Names) == 0 {
+			sig.Params = append(sig.Params, Param{Name: fmt.Sprintf("arg%d", i), Type: typeExpr})
+			continue
+		}
+		for _, name := range field.Names {
+			sig.Params = append(sig.Params, Param{Name: name.Name, Type: typeExpr})
+		}
+	}
+
+	if target.Type.Results != nil {
+		for _, field := range target.Type.Results.List {
+			typeExpr := exprString(fset, src, field.Type)
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				sig.Results = append(sig.Results, typeExpr)
+			}
+		}
+	}
+
+	return sig, nil
+}
+
+// exprString returns the source text of an expression
+func exprString(fset *token.FileSet, src []byte, expr ast.Expr) string {
+	start := fset.Position(expr.Pos()).Offset
+	end := fset.Position(expr.End()).Offset
+	return string(src[start:end])
+}
+
+// generators maps supported parameter types to the harness helper that
+// builds a random value of size n
+var generators = map[string]string{
+	"int":       "1 + r.Intn(n)",
+	"int64":     "int64(1 + r.Intn(n))",
+	"int32":     "int32(1 + r.Intn(n))",
+	"float64":   "r.Float64() * float64(n)",
+	"bool":      "r.Intn(2) == 1",
+	"string":    "dsaComplexityString(r, n)",
+	"[]int":     "dsaComplexityInts(r, n)",
+	"[]int64":   "dsaComplexityInt64s(r, n)",
+	"[]float64": "dsaComplexityFloats(r, n)",
+	"[]string":  "dsaComplexityStrings(r, n)",
+	"[]byte":    "[]byte(dsaComplexityString(r, n))",
+	"[][]int":   "dsaComplexityIntervals(r, n)",
+	"[][]byte":  "dsaComplexityGrid(r, n)",
+}
+
+// HarnessFuncName is the name of the generated benchmark function
+const HarnessFuncName = "BenchmarkComplexity"
+
+// GenerateHarness renders a benchmark that calls the solution once per
+// iteration for every input size, as sub-benchmarks named "n=<size>".
+// Inputs are generated once per size with a deterministic seed.
+func GenerateHarness(sig *Signature, sizes []int) ([]byte, error) {
+	type arg struct {
+		Name string
+		Expr string
+	}
+
+	args := make([]arg, len(sig.Params))
+	for i, p := range sig.Params {
+		expr, ok := generators[strings.ReplaceAll(p.Type, " ", "")]
+		if !ok {
+			return nil, fmt.Errorf("parameter %s has unsupported type %s for input generation", p.Name, p.Type)
+		}
+		args[i] = arg{Name: "dsaArg" + p.Name, Expr: expr}
+	}
+
+	sinkType := ""
+	if len(sig.Results) > 0 {
+		sinkType = sig.Results[0]
+	}
+
+	data := struct {
+		Package   string
+		FuncName  string
+		HarnessFn string
+		Sizes     []int
+		Args      []arg
+		SinkType  string
+		Extra     int
+	}{
+		Package:   sig.Package,
+		FuncName:  sig.FuncName,
+		HarnessFn: HarnessFuncName,
+		Sizes:     sizes,
+		Args:      args,
+		SinkType:  sinkType,
+		Extra:     len(sig.Results) - 1,
+	}
+
+	var buf bytes.Buffer
+	if err := harnessTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute harness template: %w", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format harness: %w", err)
+	}
+
+	return formatted, nil
+}
+
+var harnessTemplate = template.Must(template.New("harness").Funcs(template.FuncMap{
+	"blanks": func(n int) string { return strings.Repeat(", _", n) },
+}).Parse(`// Code generated by dsa complexity. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+{{if .SinkType}}var dsaComplexitySink {{.SinkType}}
+{{end}}
+func {{.HarnessFn}}(b *testing.B) {
+	for _, n := range []int{ {{range $i, $s := .Sizes}}{{if $i}}, {{end}}{{$s}}{{end}} } {
+		r := rand.New(rand.NewSource(int64(n)))
+{{range .Args}}		{{.Name}} := {{.Expr}}
+{{end}}
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				{{if .SinkType}}dsaComplexitySink{{blanks .Extra}} = {{end}}{{.FuncName}}({{range $i, $a := .Args}}{{if $i}}, {{end}}{{$a.Name}}{{end}})
+			}
+		})
+	}
+}
+
+func dsaComplexityInts(r *rand.Rand, n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = r.Intn(2*n+1) - n
+	}
+	return out
+}
+
+func dsaComplexityInt64s(r *rand.Rand, n int) []int64 {
+	out := make([]int64, n)
+	for i := range out {
+		out[i] = int64(r.Intn(2*n+1) - n)
+	}
+	return out
+}
+
+func dsaComplexityFloats(r *rand.Rand, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = r.Float64() * float64(n)
+	}
+	return out
+}
+
+func dsaComplexityString(r *rand.Rand, n int) string {
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = byte('a' + r.Intn(26))
+	}
+	return string(out)
+}
+
+func dsaComplexityStrings(r *rand.Rand, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = dsaComplexityString(r, 5)
+	}
+	return out
+}
+
+func dsaComplexityIntervals(r *rand.Rand, n int) [][]int {
+	out := make([][]int, n)
+	for i := range out {
+		start := r.Intn(10 * n)
+		out[i] = []int{start, start + r.Intn(10)}
+	}
+	return out
+}
+
+func dsaComplexityGrid(r *rand.Rand, n int) [][]byte {
+	side := 1
+	for side*side < n {
+		side++
+	}
+	out := make([][]byte, side)
+	for i := range out {
+		out[i] = make([]byte, side)
+		for j := range out[i] {
+			out[i][j] = byte('0' + r.Intn(2))
+		}
+	}
+	return out
+}
+`))
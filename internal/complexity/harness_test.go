@@ -0,0 +1,115 @@
+package complexity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const twoSumSource = `package solutions
+
+// helper is unexported and must be skipped
+func helper() {}
+
+// TwoSum returns indices of two numbers that add up to target
+func TwoSum(nums []int, target int) []int {
+	return nil
+}
+`
+
+func writeSource(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "two_sum.go")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestParseSignature(t *testing.T) {
+	t.Run("finds named function", func(t *testing.T) {
+		sig, err := ParseSignature(writeSource(t, twoSumSource), "TwoSum")
+
+		require.NoError(t, err)
+		assert.Equal(t, "solutions", sig.Package)
+		assert.Equal(t, "TwoSum", sig.FuncName)
+		assert.Equal(t, []Param{{Name: "nums", Type: "[]int"}, {Name: "target", Type: "int"}}, sig.Params)
+		assert.Equal(t, []string{"[]int"}, sig.Results)
+	})
+
+	t.Run("falls back to first exported function", func(t *testing.T) {
+		sig, err := ParseSignature(writeSource(t, twoSumSource), "Missing")
+
+		require.NoError(t, err)
+		assert.Equal(t, "TwoSum", sig.FuncName)
+	})
+
+	t.Run("expands grouped parameters", func(t *testing.T) {
+		sig, err := ParseSignature(writeSource(t, "package p\n\nfunc Add(a, b int) (x, y int) { return }\n"), "Add")
+
+		require.NoError(t, err)
+		assert.Len(t, sig.Params, 2)
+		assert.Equal(t, []string{"int", "int"}, sig.Results)
+	})
+
+	t.Run("errors without exported functions", func(t *testing.T) {
+		_, err := ParseSignature(writeSource(t, "package p\n\nfunc helper() {}\n"), "")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no exported function")
+	})
+}
+
+func TestGenerateHarness(t *testing.T) {
+	t.Run("renders sub-benchmarks for each size", func(t *testing.T) {
+		sig := &Signature{
+			Package:  "solutions",
+			FuncName: "TwoSum",
+			Params:   []Param{{Name: "nums", Type: "[]int"}, {Name: "target", Type: "int"}},
+			Results:  []string{"[]int"},
+		}
+
+		code, err := GenerateHarness(sig, []int{10, 20})
+
+		require.NoError(t, err)
+		src := string(code)
+		assert.Contains(t, src, "package solutions")
+		assert.Contains(t, src, "func BenchmarkComplexity(b *testing.B)")
+		assert.Contains(t, src, "[]int{10, 20}")
+		assert.Contains(t, src, "dsaComplexitySink = TwoSum(dsaArgnums, dsaArgtarget)")
+	})
+
+	t.Run("discards extra results", func(t *testing.T) {
+		sig := &Signature{Package: "p", FuncName: "Split", Params: []Param{{Name: "s", Type: "string"}}, Results: []string{"string", "error"}}
+
+		code, err := GenerateHarness(sig, []int{1, 2, 3})
+
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "dsaComplexitySink, _ = Split(dsaArgs)")
+	})
+
+	t.Run("rejects unsupported parameter types", func(t *testing.T) {
+		sig := &Signature{Package: "p", FuncName: "F", Params: []Param{{Name: "root", Type: "*TreeNode"}}}
+
+		_, err := GenerateHarness(sig, []int{1, 2, 3})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported type *TreeNode")
+	})
+}
+
+func TestParseSamples(t *testing.T) {
+	output := `goos: linux
+BenchmarkComplexity/n=128-8     	 1000000	       105.2 ns/op
+BenchmarkComplexity/n=256-8     	  500000	       210.9 ns/op
+BenchmarkComplexity/n=512       	  250000	       421.0 ns/op
+PASS`
+
+	samples, err := parseSamples(output)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Sample{{N: 128, NsPerOp: 105.2}, {N: 256, NsPerOp: 210.9}, {N: 512, NsPerOp: 421.0}}, samples)
+
+	_, err = parseSamples("FAIL")
+	assert.Error(t, err)
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/spf13/viper"
+
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 )
 
 // Config holds all configuration settings
@@ -32,6 +34,7 @@ func InitConfig() error {
 
 	viper.SetDefault("editor", defaultEditor)
 	viper.SetDefault("editor_args", "")
+	viper.SetDefault("editor_mode", "auto")
 	viper.SetDefault("output_format", "text")
 	viper.SetDefault("no_color", false)
 	if home != "" && err == nil {
@@ -42,6 +45,7 @@ func InitConfig() error {
 	viper.SetDefault("status_format", "table")
 	viper.SetDefault("output_style", "normal")
 	viper.SetDefault("color_scheme", "default")
+	viper.SetDefault("git_history", false)
 
 	// Check for active profile
 	var activeConfigFile string
@@ -66,7 +70,7 @@ func InitConfig() error {
 	}
 
 	// Read project config and merge (project config overrides global/profile)
-	projectConfigFile := filepath.Join(".dsa", "config.yaml")
+	projectConfigFile := workspace.Settings("config.yaml")
 	if _, err := os.Stat(projectConfigFile); err == nil {
 		viper.SetConfigFile(projectConfigFile)
 		if err := viper.MergeInConfig(); err != nil {
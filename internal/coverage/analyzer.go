@@ -0,0 +1,265 @@
+// Package coverage summarizes cover profiles written by go test for a
+// solution file: how many of its statements the tests run, per function and
+// per line, so untested branches can be found without go tool cover.
+package coverage
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LineStatus tells whether the tests ran the statements on a line
+type LineStatus string
+
+const (
+	LineNone      LineStatus = ""          // No statements
+	LineCovered   LineStatus = "covered"   // Every statement ran
+	LineUncovered LineStatus = "uncovered" // No statement ran
+	LinePartial   LineStatus = "partial"   // Some statements ran
+)
+
+// Block is a run of statements recorded in a cover profile
+type Block struct {
+	StartLine, StartCol int
+	EndLine, EndCol     int
+	Statements          int
+	Count               int // Times the block ran (0 or 1 in set mode)
+}
+
+// Function is the coverage of one function
+type Function struct {
+	Name       string
+	Line       int
+	Statements int
+	Covered    int
+}
+
+// Percent returns the share of the function's statements the tests ran
+func (f Function) Percent() float64 {
+	return percent(f.Covered, f.Statements)
+}
+
+// Line is a source line with its coverage
+type Line struct {
+	Number int
+	Source string
+	Status LineStatus
+}
+
+// Report is the coverage of one source file
+type Report struct {
+	File       string
+	Statements int
+	Covered    int
+	Functions  []Function
+	Lines      []Line
+}
+
+// Percent returns the share of the file's statements the tests ran
+func (r *Report) Percent() float64 {
+	return percent(r.Covered, r.Statements)
+}
+
+// Uncovered returns the lines with statements the tests did not run
+func (r *Report) Uncovered() []Line {
+	var lines []Line
+	for _, line := range r.Lines {
+		if line.Status == LineUncovered || line.Status == LinePartial {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Analyze reads a cover profile and reports the coverage of a source file.
+// Blocks are matched to the file by absolute path or by path suffix; aliases
+// are further path suffixes under which the same source was compiled, e.g.
+// when it was copied to a staging directory.
+func Analyze(profilePath, sourcePath string, aliases ...string) (*Report, error) {
+	blocks, err := readProfile(profilePath, matcher(sourcePath, aliases))
+	if err != nil {
+		return nil, err
+	}
+	src, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source file: %w", err)
+	}
+	return report(sourcePath, src, blocks)
+}
+
+// matcher returns whether a profile's file name is the source file
+func matcher(sourcePath string, aliases []string) func(string) bool {
+	absPath, _ := filepath.Abs(sourcePath)
+	suffixes := []string{"/" + filepath.ToSlash(filepath.Clean(sourcePath))}
+	for _, alias := range aliases {
+		suffixes = append(suffixes, "/"+filepath.ToSlash(filepath.Clean(alias)))
+	}
+	return func(file string) bool {
+		if file == absPath {
+			return true
+		}
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(filepath.ToSlash(file), suffix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// readProfile reads the blocks of the matching file from a cover profile.
+// A block listed more than once, e.g. by several test binaries, counts as
+// run if any of them ran it.
+func readProfile(path string, matches func(string) bool) ([]Block, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open cover profile: %w", err)
+	}
+	defer file.Close()
+
+	var blocks []Block
+	index := map[[4]int]int{}
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		// file.go:startLine.startCol,endLine.endCol statements count
+		colon := strings.LastIndex(line, ":")
+		if colon < 0 {
+			return nil, fmt.Errorf("invalid cover profile line %d: %q", n, line)
+		}
+		if !matches(line[:colon]) {
+			continue
+		}
+		var b Block
+		if _, err := fmt.Sscanf(line[colon+1:], "%d.%d,%d.%d %d %d",
+			&b.StartLine, &b.StartCol, &b.EndLine, &b.EndCol, &b.Statements, &b.Count); err != nil {
+			return nil, fmt.Errorf("invalid cover profile line %d: %q", n, line)
+		}
+
+		key := [4]int{b.StartLine, b.StartCol, b.EndLine, b.EndCol}
+		if i, ok := index[key]; ok {
+			blocks[i].Count += b.Count
+			continue
+		}
+		index[key] = len(blocks)
+		blocks = append(blocks, b)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read cover profile: %w", err)
+	}
+	return blocks, nil
+}
+
+// report attributes blocks to the functions and lines of the source
+func report(path string, src []byte, blocks []Block) (*Report, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	r := &Report{File: path}
+	var ends []int // Last line of each function
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			r.Functions = append(r.Functions, Function{Name: funcName(fn), Line: fset.Position(fn.Pos()).Line})
+			ends = append(ends, fset.Position(fn.End()).Line)
+		}
+	}
+
+	sourceLines := strings.Split(strings.TrimRight(string(src), "\n"), "\n")
+	ran := make([]map[bool]bool, len(sourceLines))
+	for _, b := range blocks {
+		r.Statements += b.Statements
+		if b.Count > 0 {
+			r.Covered += b.Statements
+		}
+		for i := range r.Functions {
+			if b.StartLine >= r.Functions[i].Line && b.StartLine <= ends[i] {
+				r.Functions[i].Statements += b.Statements
+				if b.Count > 0 {
+					r.Functions[i].Covered += b.Statements
+				}
+				break
+			}
+		}
+
+		// A line belongs to the block if the block spans code on it, not
+		// just the braces that open or close it
+		for n := b.StartLine; n <= b.EndLine && n <= len(sourceLines); n++ {
+			text := sourceLines[n-1]
+			start, end := 0, len(text)
+			if n == b.StartLine {
+				start = min(b.StartCol-1, len(text))
+			}
+			if n == b.EndLine {
+				end = min(max(b.EndCol-1, start), len(text))
+			}
+			if strings.Trim(text[start:end], " \t{}") == "" {
+				continue
+			}
+			if ran[n-1] == nil {
+				ran[n-1] = map[bool]bool{}
+			}
+			ran[n-1][b.Count > 0] = true
+		}
+	}
+
+	for i, text := range sourceLines {
+		line := Line{Number: i + 1, Source: text}
+		switch {
+		case ran[i][true] && ran[i][false]:
+			line.Status = LinePartial
+		case ran[i][true]:
+			line.Status = LineCovered
+		case ran[i][false]:
+			line.Status = LineUncovered
+		}
+		r.Lines = append(r.Lines, line)
+	}
+	return r, nil
+}
+
+// funcName returns a function's name, qualified by its receiver type for
+// methods, e.g. "(*LRUCache).Get"
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	recv, pointer := fn.Recv.List[0].Type, false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv, pointer = star.X, true
+	}
+	switch index := recv.(type) {
+	case *ast.IndexExpr:
+		recv = index.X
+	case *ast.IndexListExpr:
+		recv = index.X
+	}
+	ident, ok := recv.(*ast.Ident)
+	if !ok {
+		return fn.Name.Name
+	}
+	if pointer {
+		return fmt.Sprintf("(*%s).%s", ident.Name, fn.Name.Name)
+	}
+	return fmt.Sprintf("%s.%s", ident.Name, fn.Name.Name)
+}
+
+// percent returns part as a percentage of total
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
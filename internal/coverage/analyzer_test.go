@@ -0,0 +1,94 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSolution is the source file the test profiles point into
+const testSolution = `package solutions
+
+type Stack[T any] struct{ items []T }
+
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 { return zero, false }
+	top := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return top, true
+}
+
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+`
+
+// writeTestFiles writes the solution and a cover profile of it, recorded as
+// the staged copy solution.go, with each block listed by two test binaries
+func writeTestFiles(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "abs.go")
+	require.NoError(t, os.WriteFile(source, []byte(testSolution), 0644))
+
+	profile := filepath.Join(dir, "cover.out")
+	require.NoError(t, os.WriteFile(profile, []byte(`mode: set
+/work/problems/.dsa-stage-1/solution.go:5.35,7.24 2 1
+/work/problems/.dsa-stage-1/solution.go:7.24,7.45 1 0
+/work/problems/.dsa-stage-1/solution.go:8.2,10.18 3 1
+/work/problems/.dsa-stage-1/solution.go:13.21,14.11 1 1
+/work/problems/.dsa-stage-1/solution.go:14.11,16.3 1 0
+/work/problems/.dsa-stage-1/solution.go:14.11,16.3 1 1
+/work/problems/.dsa-stage-1/solution.go:17.2,17.10 1 0
+/work/problems/.dsa-stage-1/two_sum_test.go:3.1,4.2 1 1
+`), 0644))
+	return source, profile
+}
+
+func TestAnalyze(t *testing.T) {
+	source, profile := writeTestFiles(t)
+
+	report, err := Analyze(profile, source, "solution.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, 9, report.Statements, "blocks of other files are left out")
+	assert.Equal(t, 7, report.Covered, "a block any test binary ran counts as run")
+	assert.InDelta(t, 77.8, report.Percent(), 0.05)
+
+	require.Len(t, report.Functions, 2)
+	assert.Equal(t, Function{Name: "(*Stack).Pop", Line: 5, Statements: 6, Covered: 5}, report.Functions[0])
+	assert.Equal(t, Function{Name: "Abs", Line: 13, Statements: 3, Covered: 2}, report.Functions[1])
+
+	status := map[int]LineStatus{}
+	for _, line := range report.Lines {
+		status[line.Number] = line.Status
+	}
+	assert.Equal(t, LineNone, status[1])
+	assert.Equal(t, LinePartial, status[7], "the empty check runs but its return does not")
+	assert.Equal(t, LineCovered, status[15])
+	assert.Equal(t, LineNone, status[16], "a closing brace ending a block has no statements")
+	assert.Equal(t, LineUncovered, status[17])
+
+	var uncovered []int
+	for _, line := range report.Uncovered() {
+		uncovered = append(uncovered, line.Number)
+	}
+	assert.Equal(t, []int{7, 17}, uncovered)
+}
+
+func TestAnalyze_Errors(t *testing.T) {
+	source, profile := writeTestFiles(t)
+
+	_, err := Analyze(filepath.Join(t.TempDir(), "missing.out"), source)
+	assert.ErrorContains(t, err, "failed to open cover profile")
+
+	require.NoError(t, os.WriteFile(profile, []byte("mode: set\n/work/abs.go:bad\n"), 0644))
+	_, err = Analyze(profile, source, "abs.go")
+	assert.ErrorContains(t, err, "invalid cover profile line 2")
+}
@@ -0,0 +1,80 @@
+package coverage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Formatter handles formatting coverage reports for display
+type Formatter struct{}
+
+// NewFormatter creates a new report formatter
+func NewFormatter() *Formatter {
+	return &Formatter{}
+}
+
+// FormatReport formats the coverage of each function and the source with a
+// marker per line: ✓ covered, ✗ not run and ~ partly run. Lines the tests
+// did not run are highlighted.
+func (f *Formatter) FormatReport(report *Report) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("\nCoverage of %s: %.1f%% of statements (%d/%d)\n",
+		report.File, report.Percent(), report.Covered, report.Statements))
+	if report.Statements == 0 {
+		output.WriteString("  No statements recorded for this file.\n")
+		return output.String()
+	}
+
+	output.WriteString("\nFunctions:\n")
+	width := 0
+	for _, fn := range report.Functions {
+		width = max(width, len(fn.Name))
+	}
+	for _, fn := range report.Functions {
+		if fn.Statements == 0 {
+			continue
+		}
+		output.WriteString(fmt.Sprintf("  %-*s  %5.1f%%  (%d/%d)\n", width, fn.Name, fn.Percent(), fn.Covered, fn.Statements))
+	}
+
+	output.WriteString("\nLines:\n")
+	uncovered := color.New(color.FgRed)
+	partial := color.New(color.FgYellow)
+	for _, line := range report.Lines {
+		text := fmt.Sprintf("%s %4d  %s", lineMarker(line.Status), line.Number, line.Source)
+		switch line.Status {
+		case LineUncovered:
+			text = uncovered.Sprint(text)
+		case LinePartial:
+			text = partial.Sprint(text)
+		}
+		output.WriteString("  " + text + "\n")
+	}
+
+	if lines := report.Uncovered(); len(lines) > 0 {
+		numbers := make([]string, len(lines))
+		for i, line := range lines {
+			numbers[i] = fmt.Sprint(line.Number)
+		}
+		output.WriteString(fmt.Sprintf("\nLines not run by any test: %s\n", strings.Join(numbers, ", ")))
+	}
+
+	return output.String()
+}
+
+// lineMarker returns the gutter marker of a line
+func lineMarker(status LineStatus) string {
+	switch status {
+	case LineCovered:
+		return "✓"
+	case LineUncovered:
+		return "✗"
+	case LinePartial:
+		return "~"
+	default:
+		return " "
+	}
+}
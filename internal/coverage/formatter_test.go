@@ -0,0 +1,30 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatter_FormatReport(t *testing.T) {
+	color.NoColor = true
+	source, profile := writeTestFiles(t)
+	report, err := Analyze(profile, source, "solution.go")
+	require.NoError(t, err)
+
+	output := NewFormatter().FormatReport(report)
+	assert.Contains(t, output, "77.8% of statements (7/9)")
+	assert.Contains(t, output, "  (*Stack).Pop   83.3%  (5/6)\n")
+	assert.Contains(t, output, "  Abs            66.7%  (2/3)\n")
+	assert.Contains(t, output, "  ~    7  \tif len(s.items) == 0 { return zero, false }\n")
+	assert.Contains(t, output, "  ✓   15  \t\treturn -x\n")
+	assert.Contains(t, output, "  ✗   17  \treturn x\n")
+	assert.Contains(t, output, "Lines not run by any test: 7, 17")
+}
+
+func TestFormatter_FormatReport_NoStatements(t *testing.T) {
+	output := NewFormatter().FormatReport(&Report{File: "solutions/abs.go"})
+	assert.Contains(t, output, "No statements recorded for this file.")
+}
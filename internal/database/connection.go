@@ -38,7 +38,7 @@ func Initialize() (*gorm.DB, error) {
 	}
 
 	// Run AutoMigrate for all models
-	if err := db.AutoMigrate(&Problem{}, &Solution{}, &Progress{}, &BenchmarkResult{}); err != nil {
+	if err := db.AutoMigrate(&Problem{}, &Solution{}, &Progress{}, &BenchmarkResult{}, &CodeBlob{}, &TestCache{}); err != nil {
 		return nil, fmt.Errorf("failed to run database migrations: %w", err)
 	}
 
@@ -22,6 +22,8 @@ type Problem struct {
 	Description string    `gorm:"type:text" json:"description"`
 	Tags        string    `gorm:"type:varchar(255)" json:"tags"` // Comma-separated tags (e.g., "bfs,dfs,recursion")
 	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	TargetComplexity string `gorm:"type:varchar(20)" json:"target_complexity,omitempty"` // Expected time complexity, e.g. "O(n)"
 }
 
 // Solution represents a developer's solution attempt for a problem.
@@ -30,7 +32,7 @@ type Problem struct {
 type Solution struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
 	ProblemID   uint      `gorm:"index:idx_solutions_problem_id;not null" json:"problem_id"`
-	Code        string    `gorm:"type:text" json:"code"`
+	Code        string    `gorm:"type:text" json:"code"` // Inline code of older submissions; newer ones set CodeHash instead
 	Language    string    `gorm:"type:varchar(20);default:'go'" json:"language"`
 	Passed      bool      `gorm:"default:false" json:"passed"`
 	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
@@ -39,6 +41,21 @@ type Solution struct {
 	Status      string    `gorm:"type:varchar(20);not null;default:'InProgress'" json:"status"` // Passed, Failed, InProgress
 	TestsPassed int       `gorm:"default:0" json:"tests_passed"`
 	TestsTotal  int       `gorm:"default:0" json:"tests_total"`
+
+	Variant              string   `gorm:"type:varchar(50);index;default:''" json:"variant,omitempty"` // Named approach, e.g. "hashmap"; empty for the default solution
+	CodeHash             string   `gorm:"type:varchar(64);index" json:"code_hash,omitempty"`          // SHA-256 of the code, stored once in CodeBlob
+	ComplexityClass      string   `gorm:"type:varchar(20)" json:"complexity_class,omitempty"`         // Empirically measured class, e.g. "O(n log n)"
+	ComplexityConfidence float64  `gorm:"default:0" json:"complexity_confidence,omitempty"`           // 0.0 - 1.0
+	Coverage             *float64 `json:"coverage,omitempty"`                                         // Percentage of the solution's statements run by its tests, if measured
+}
+
+// CodeBlob stores solution code once per distinct content, keyed by the
+// SHA-256 of the uncompressed code. Solution rows reference it by CodeHash.
+type CodeBlob struct {
+	Hash      string    `gorm:"primaryKey;type:varchar(64)" json:"hash"`
+	Data      []byte    `gorm:"not null" json:"-"`     // gzip-compressed code
+	Size      int       `gorm:"default:0" json:"size"` // Uncompressed size in bytes
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
 
 // Progress tracks a developer's progress on each problem.
@@ -59,13 +76,39 @@ type Progress struct {
 
 // BenchmarkResult represents a benchmark run result for a problem solution.
 // Stores performance metrics including timing and memory allocations.
+// Each benchmark and sub-benchmark of a run is stored as its own row, keyed by Name.
 type BenchmarkResult struct {
-	ID          uint      `gorm:"primaryKey" json:"id"`
-	ProblemID   uint      `gorm:"index:idx_benchmarks_problem_id;not null" json:"problem_id"`
-	NsPerOp     float64   `gorm:"not null" json:"ns_per_op"`     // Nanoseconds per operation
-	AllocsPerOp float64   `gorm:"not null" json:"allocs_per_op"` // Allocations per operation
-	BytesPerOp  float64   `gorm:"not null" json:"bytes_per_op"`  // Bytes allocated per operation
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ProblemID    uint      `gorm:"index:idx_benchmarks_problem_id;not null" json:"problem_id"`
+	SolutionID   *uint     `gorm:"index" json:"solution_id,omitempty"`           // Submission whose code was benchmarked, if any
+	Name         string    `gorm:"type:varchar(255);index" json:"name"`          // Benchmark name, e.g. "TwoSum/size=100"
+	Iterations   int       `gorm:"default:0" json:"iterations"`                  // Number of iterations run
+	Metrics      string    `gorm:"type:text" json:"metrics,omitempty"`           // JSON-encoded custom metrics keyed by unit
+	Count        int       `gorm:"default:1" json:"count"`                       // Number of samples (-count)
+	Samples      string    `gorm:"type:text" json:"samples,omitempty"`           // JSON-encoded samples keyed by unit
+	NsPerOp      float64   `gorm:"not null" json:"ns_per_op"`                    // Nanoseconds per operation (median across samples)
+	AllocsPerOp  float64   `gorm:"not null" json:"allocs_per_op"`                // Allocations per operation
+	BytesPerOp   float64   `gorm:"not null" json:"bytes_per_op"`                 // Bytes allocated per operation
+	GoVersion    string    `gorm:"type:varchar(50)" json:"go_version,omitempty"` // Go toolchain version
+	GOOS         string    `gorm:"column:goos;type:varchar(20)" json:"goos,omitempty"`
+	GOARCH       string    `gorm:"column:goarch;type:varchar(20)" json:"goarch,omitempty"`
+	CPUModel     string    `gorm:"type:varchar(255)" json:"cpu_model,omitempty"` // CPU model reported by go test
+	GOMAXPROCS   int       `gorm:"column:gomaxprocs;default:0" json:"gomaxprocs,omitempty"`
+	HostHash     string    `gorm:"type:varchar(64)" json:"host_hash,omitempty"`                // Truncated SHA-256 of the hostname
+	SolutionHash string    `gorm:"type:varchar(64)" json:"solution_hash,omitempty"`            // SHA-256 of the benchmarked solution
+	Variant      string    `gorm:"type:varchar(50);index;default:''" json:"variant,omitempty"` // Named approach benchmarked; empty for the default solution
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TestCache stores the latest test result of each problem and variant,
+// keyed by a hash of the solution, its test files and the Go version, so
+// re-running unchanged code returns the result without running go test.
+type TestCache struct {
+	Key       string    `gorm:"primaryKey;type:varchar(64)" json:"key"`
+	ProblemID uint      `gorm:"index:idx_test_caches_problem;not null" json:"problem_id"`
+	Variant   string    `gorm:"type:varchar(50);index:idx_test_caches_problem;default:''" json:"variant,omitempty"`
+	Result    string    `gorm:"type:text" json:"result"` // JSON-encoded test result
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
 
 // ValidateStatus checks if the Solution status is valid
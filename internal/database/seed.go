@@ -19,8 +19,14 @@ func SeedProblems(db *gorm.DB) (int, error) {
 		var existing Problem
 		result := db.Where("slug = ?", seed.Slug).First(&existing)
 
-		// Skip if already exists
+		// Skip if already exists, backfilling the target complexity for
+		// problems seeded before it was tracked
 		if result.Error == nil {
+			if existing.TargetComplexity == "" && seed.TargetComplexity != "" {
+				if err := db.Model(&existing).Update("target_complexity", seed.TargetComplexity).Error; err != nil {
+					return seededCount, fmt.Errorf("failed to backfill target complexity for '%s': %w", seed.Slug, err)
+				}
+			}
 			continue
 		}
 
@@ -36,6 +42,8 @@ func SeedProblems(db *gorm.DB) (int, error) {
 			Description: seed.Description,
 			Difficulty:  seed.Difficulty,
 			Topic:       seed.Topic,
+
+			TargetComplexity: seed.TargetComplexity,
 		}
 
 		if err := db.Create(&problem).Error; err != nil {
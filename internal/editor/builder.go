@@ -3,14 +3,30 @@ package editor
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/viper"
 )
 
-// BuildCommand constructs the editor command with argument substitution
-func BuildCommand(filePath string, line, column int) []string {
+// Target is a file to open and the position to open it at. Line and Column
+// are 0 when there is no position, which opens the file at its first line.
+type Target struct {
+	Path   string
+	Line   int
+	Column int
+}
+
+// BuildCommand constructs the editor command with argument substitution.
+// The editor and editor_args settings are split into words like a shell
+// would, so quoted arguments such as -c 'normal {line}G' stay whole, and the
+// {file}, {line} and {column} placeholders are replaced within each word, so
+// paths with spaces stay single arguments. Extra files are opened as well,
+// after the target, without a position, unless the editor is a system opener
+// such as xdg-open, which takes a single file.
+func BuildCommand(target Target, extra ...string) ([]string, error) {
 	editor := viper.GetString("editor")
 	editorArgs := viper.GetString("editor_args")
 
@@ -19,33 +35,101 @@ func BuildCommand(filePath string, line, column int) []string {
 		editor = GetFallbackEditor()
 	}
 
-	// If no editor_args configured, use simple "editor file" format
-	if editorArgs == "" {
-		return []string{editor, filePath}
+	// The editor may carry its own flags, e.g. "code --wait"
+	cmd, err := SplitArgs(editor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid editor %q: %w", editor, err)
+	}
+	if len(cmd) == 0 {
+		return nil, fmt.Errorf("no editor configured")
 	}
 
-	// Check if {file} placeholder exists in editor_args
-	hasFilePlaceholder := strings.Contains(editorArgs, "{file}")
-
-	// Replace placeholders in editor_args
-	args := strings.ReplaceAll(editorArgs, "{file}", filePath)
-	args = strings.ReplaceAll(args, "{line}", fmt.Sprintf("%d", line))
-	args = strings.ReplaceAll(args, "{column}", fmt.Sprintf("%d", column))
-
-	// Parse arguments (simple space split for now)
-	argList := strings.Fields(args)
+	args, err := SplitArgs(editorArgs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid editor_args %q: %w", editorArgs, err)
+	}
 
-	// Build final command: editor + parsed args
-	cmd := append([]string{editor}, argList...)
+	replacer := strings.NewReplacer(
+		"{file}", target.Path,
+		"{line}", strconv.Itoa(max(target.Line, 1)),
+		"{column}", strconv.Itoa(max(target.Column, 1)),
+	)
+	hasFilePlaceholder := false
+	for _, arg := range args {
+		hasFilePlaceholder = hasFilePlaceholder || strings.Contains(arg, "{file}")
+		cmd = append(cmd, replacer.Replace(arg))
+	}
 
 	// If {file} placeholder was not in args, append file path at the end
 	if !hasFilePlaceholder {
-		cmd = append(cmd, filePath)
+		cmd = append(cmd, target.Path)
 	}
 
-	return cmd
+	if systemOpeners[strings.TrimSuffix(filepath.Base(cmd[0]), ".exe")] {
+		return cmd, nil
+	}
+	return append(cmd, extra...), nil
 }
 
+// SplitArgs splits a command line into words the way a POSIX shell does,
+// without expanding variables or globs: whitespace separates words, single
+// quotes keep everything literal, double quotes keep whitespace, and a
+// backslash escapes the next character outside single quotes.
+func SplitArgs(s string) ([]string, error) {
+	var (
+		words   []string
+		word    strings.Builder
+		inWord  bool
+		quote   rune // The open quote, or 0
+		escaped bool
+	)
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			// Within double quotes a backslash only escapes \, " and $
+			if quote == '"' && r != '\\' && r != '"' && r != '$' {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// systemOpeners open a file in its default application and accept only one
+var systemOpeners = map[string]bool{"open": true, "xdg-open": true, "start": true}
+
 // GetFallbackEditor returns editor from $EDITOR or OS-specific default
 func GetFallbackEditor() string {
 	// Try $EDITOR environment variable first
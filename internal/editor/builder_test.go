@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildCommand(t *testing.T) {
@@ -15,7 +16,8 @@ func TestBuildCommand(t *testing.T) {
 		viper.Set("editor", "vim")
 		viper.Set("editor_args", "")
 
-		cmd := BuildCommand("/path/file.go", 1, 1)
+		cmd, err := BuildCommand(Target{Path: "/path/file.go", Line: 1, Column: 1})
+		require.NoError(t, err)
 
 		assert.Equal(t, []string{"vim", "/path/file.go"}, cmd)
 	})
@@ -25,7 +27,8 @@ func TestBuildCommand(t *testing.T) {
 		viper.Set("editor", "code")
 		viper.Set("editor_args", "--goto {file}:{line}")
 
-		cmd := BuildCommand("/path/file.go", 10, 5)
+		cmd, err := BuildCommand(Target{Path: "/path/file.go", Line: 10, Column: 5})
+		require.NoError(t, err)
 
 		assert.Equal(t, []string{"code", "--goto", "/path/file.go:10"}, cmd)
 	})
@@ -35,7 +38,8 @@ func TestBuildCommand(t *testing.T) {
 		viper.Set("editor", "vim")
 		viper.Set("editor_args", "+{line}")
 
-		cmd := BuildCommand("/path/file.go", 42, 1)
+		cmd, err := BuildCommand(Target{Path: "/path/file.go", Line: 42, Column: 1})
+		require.NoError(t, err)
 
 		assert.Equal(t, []string{"vim", "+42", "/path/file.go"}, cmd)
 	})
@@ -45,7 +49,8 @@ func TestBuildCommand(t *testing.T) {
 		viper.Set("editor", "nvim")
 		viper.Set("editor_args", "-c normal {line}G")
 
-		cmd := BuildCommand("/path/file.go", 25, 1)
+		cmd, err := BuildCommand(Target{Path: "/path/file.go", Line: 25, Column: 1})
+		require.NoError(t, err)
 
 		assert.Equal(t, []string{"nvim", "-c", "normal", "25G", "/path/file.go"}, cmd)
 	})
@@ -55,7 +60,8 @@ func TestBuildCommand(t *testing.T) {
 		viper.Set("editor", "emacs")
 		viper.Set("editor_args", "+{line}:{column}")
 
-		cmd := BuildCommand("/path/file.go", 15, 8)
+		cmd, err := BuildCommand(Target{Path: "/path/file.go", Line: 15, Column: 8})
+		require.NoError(t, err)
 
 		assert.Equal(t, []string{"emacs", "+15:8", "/path/file.go"}, cmd)
 	})
@@ -65,7 +71,8 @@ func TestBuildCommand(t *testing.T) {
 		viper.Set("editor", "myeditor")
 		viper.Set("editor_args", "{file} --line {line} --col {column}")
 
-		cmd := BuildCommand("/src/main.go", 100, 50)
+		cmd, err := BuildCommand(Target{Path: "/src/main.go", Line: 100, Column: 50})
+		require.NoError(t, err)
 
 		assert.Equal(t, []string{"myeditor", "/src/main.go", "--line", "100", "--col", "50"}, cmd)
 	})
@@ -76,12 +83,139 @@ func TestBuildCommand(t *testing.T) {
 		viper.Set("editor_args", "")
 		os.Unsetenv("EDITOR")
 
-		cmd := BuildCommand("/path/file.go", 1, 1)
+		cmd, err := BuildCommand(Target{Path: "/path/file.go", Line: 1, Column: 1})
+		require.NoError(t, err)
 
 		// Should use OS-specific default
 		expectedEditor := GetSystemDefaultEditor()
 		assert.Equal(t, []string{expectedEditor, "/path/file.go"}, cmd)
 	})
+
+	t.Run("quoted args stay whole", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "nvim")
+		viper.Set("editor_args", "-c 'normal {line}G'")
+
+		cmd, err := BuildCommand(Target{Path: "/path/file.go", Line: 25})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"nvim", "-c", "normal 25G", "/path/file.go"}, cmd)
+	})
+
+	t.Run("file paths with spaces stay one argument", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "code")
+		viper.Set("editor_args", "--goto {file}:{line}:{column}")
+
+		cmd, err := BuildCommand(Target{Path: "/my problems/two_sum.go", Line: 3, Column: 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"code", "--goto", "/my problems/two_sum.go:3:2"}, cmd)
+	})
+
+	t.Run("editor with its own flags", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "code --wait")
+		viper.Set("editor_args", "")
+
+		cmd, err := BuildCommand(Target{Path: "/path/file.go"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"code", "--wait", "/path/file.go"}, cmd)
+	})
+
+	t.Run("missing position opens the first line", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "vim")
+		viper.Set("editor_args", "+{line}")
+
+		cmd, err := BuildCommand(Target{Path: "/path/file.go"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"vim", "+1", "/path/file.go"}, cmd)
+	})
+
+	t.Run("extra files follow the target", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "code")
+		viper.Set("editor_args", "--goto {file}:{line}")
+
+		cmd, err := BuildCommand(Target{Path: "solutions/two_sum.go", Line: 8}, "problems/two_sum_test.go")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"code", "--goto", "solutions/two_sum.go:8", "problems/two_sum_test.go"}, cmd)
+	})
+
+	t.Run("fallback opener gets the target alone", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "")
+		viper.Set("editor_args", "")
+		os.Unsetenv("EDITOR")
+
+		cmd, err := BuildCommand(Target{Path: "solutions/two_sum.go", Line: 8}, "problems/two_sum_test.go")
+		require.NoError(t, err)
+
+		expectedEditor := GetSystemDefaultEditor()
+		if systemOpeners[expectedEditor] {
+			assert.Equal(t, []string{expectedEditor, "solutions/two_sum.go"}, cmd)
+		} else {
+			assert.Equal(t, []string{expectedEditor, "solutions/two_sum.go", "problems/two_sum_test.go"}, cmd)
+		}
+	})
+
+	t.Run("system openers take a single file", func(t *testing.T) {
+		for _, opener := range []string{"open", "xdg-open", "/usr/bin/xdg-open", "start"} {
+			viper.Reset()
+			viper.Set("editor", opener)
+			viper.Set("editor_args", "")
+
+			cmd, err := BuildCommand(Target{Path: "solutions/two_sum.go"}, "problems/two_sum_test.go")
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{opener, "solutions/two_sum.go"}, cmd, opener)
+		}
+	})
+
+	t.Run("unterminated quote is an error", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "nvim")
+		viper.Set("editor_args", "-c 'normal {line}G")
+
+		_, err := BuildCommand(Target{Path: "/path/file.go"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "unterminated ' quote")
+	})
+}
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"empty", "", nil},
+		{"whitespace", "  -a \tb  ", []string{"-a", "b"}},
+		{"single quotes are literal", `'a "b" \c'`, []string{`a "b" \c`}},
+		{"double quotes keep spaces", `"a b" c`, []string{"a b", "c"}},
+		{"escapes in double quotes", `"say \"hi\" \n"`, []string{`say "hi" \n`}},
+		{"escaped space", `a\ b`, []string{"a b"}},
+		{"adjacent quotes join", `--goto="{file}":'{line}'`, []string{"--goto={file}:{line}"}},
+		{"empty quotes are a word", `a "" b`, []string{"a", "", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SplitArgs(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("trailing backslash is an error", func(t *testing.T) {
+		_, err := SplitArgs(`a\`)
+		assert.Error(t, err)
+	})
 }
 
 func TestGetFallbackEditor(t *testing.T) {
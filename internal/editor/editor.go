@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -31,18 +33,78 @@ func Detect() string {
 	}
 }
 
-// Launch opens the file in the detected editor
-// Uses cmd.Start() to avoid blocking the CLI
-func Launch(editorCmd, filePath string) error {
-	cmd := exec.Command(editorCmd, filePath)
-	cmd.Stdin = os.Stdin
+// Mode tells whether launching the editor waits for it to close
+type Mode string
+
+const (
+	ModeAuto       Mode = "auto"       // Wait for terminal editors only
+	ModeWait       Mode = "wait"       // Wait until the editor closes
+	ModeBackground Mode = "background" // Return once the editor started
+)
+
+// Modes lists the valid editor_mode settings
+var Modes = []Mode{ModeAuto, ModeWait, ModeBackground}
+
+// terminalEditors take over the terminal, so the CLI has to wait for them
+// rather than return to a shell that would read from the same terminal
+var terminalEditors = map[string]bool{
+	"vi": true, "vim": true, "nvim": true, "nano": true, "pico": true,
+	"emacs": true, "micro": true, "hx": true, "helix": true, "kak": true,
+	"joe": true, "ne": true, "mg": true, "ed": true,
+}
+
+// Launch opens the target, and any extra files, in the configured editor
+// and returns the editor program. Whether it waits for the editor to close
+// follows the editor_mode setting; by default it waits for terminal editors
+// such as vim and returns at once for GUI editors.
+func Launch(target Target, extra ...string) (string, error) {
+	return launch(Mode(viper.GetString("editor_mode")), target, extra)
+}
+
+// LaunchAt opens the file at a line and column and waits for the editor to
+// close, so it can be used from an interactive prompt
+func LaunchAt(filePath string, line, column int) error {
+	_, err := launch(ModeWait, Target{Path: filePath, Line: line, Column: column}, nil)
+	return err
+}
+
+// launch runs the editor command for the target in the given mode
+func launch(mode Mode, target Target, extra []string) (string, error) {
+	args, err := BuildCommand(target, extra...)
+	if err != nil {
+		return "", err
+	}
+	program := args[0]
+
+	cmd := exec.Command(program, args[1:]...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	if err := cmd.Start(); err != nil {
-		return fmt.Errorf("failed to launch %s: %w", editorCmd, err)
+	if !waits(mode, program) {
+		// A background editor must not read from the terminal
+		if err := cmd.Start(); err != nil {
+			return "", fmt.Errorf("failed to launch %s: %w", program, err)
+		}
+		cmd.Process.Release()
+		return program, nil
 	}
 
-	// Don't wait for editor to close (allow background editing)
-	return nil
+	cmd.Stdin = os.Stdin
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to launch %s: %w", program, err)
+	}
+	return program, nil
+}
+
+// waits reports whether launching the program in mode waits for it to close
+func waits(mode Mode, program string) bool {
+	switch mode {
+	case ModeWait:
+		return true
+	case ModeBackground:
+		return false
+	default:
+		name := strings.TrimSuffix(filepath.Base(program), ".exe")
+		return terminalEditors[name]
+	}
 }
@@ -64,12 +64,63 @@ func TestLaunch(t *testing.T) {
 		tmpFile := t.TempDir() + "/test.txt"
 		os.WriteFile(tmpFile, []byte("test"), 0644)
 
-		err := Launch("non-existent-editor-12345", tmpFile)
+		viper.Reset()
+		viper.Set("editor", "non-existent-editor-12345")
+		defer viper.Reset()
+
+		_, err := Launch(Target{Path: tmpFile})
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to launch")
 	})
 
+	t.Run("returns the editor program", func(t *testing.T) {
+		tmpFile := t.TempDir() + "/solution.go"
+		os.WriteFile(tmpFile, []byte("package main\n"), 0644)
+
+		viper.Reset()
+		viper.Set("editor", "true --wait")
+		viper.Set("editor_mode", "background")
+		defer viper.Reset()
+
+		program, err := Launch(Target{Path: tmpFile, Line: 3})
+		assert.NoError(t, err)
+		assert.Equal(t, "true", program)
+	})
+
 	// Note: Testing successful editor launch is difficult without mocking exec.Command
 	// These tests verify the error cases and basic functionality
 	// Integration tests will verify the full workflow
 }
+
+func TestWaits(t *testing.T) {
+	assert.True(t, waits(ModeAuto, "vim"))
+	assert.True(t, waits(ModeAuto, "/usr/bin/nvim"))
+	assert.False(t, waits(ModeAuto, "code"))
+	assert.False(t, waits(ModeAuto, "xdg-open"))
+	assert.True(t, waits(ModeWait, "code"))
+	assert.False(t, waits(ModeBackground, "vim"))
+}
+
+func TestLaunchAt(t *testing.T) {
+	tmpFile := t.TempDir() + "/solution.go"
+	os.WriteFile(tmpFile, []byte("package main\n"), 0644)
+
+	t.Run("waits for the editor to exit", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "true")
+		viper.Set("editor_args", "{file}:{line}:{column}")
+		defer viper.Reset()
+
+		assert.NoError(t, LaunchAt(tmpFile, 7, 9))
+	})
+
+	t.Run("returns error when the editor fails", func(t *testing.T) {
+		viper.Reset()
+		viper.Set("editor", "false")
+		defer viper.Reset()
+
+		err := LaunchAt(tmpFile, 1, 1)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to launch false")
+	})
+}
@@ -0,0 +1,242 @@
+package gitstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNotRepository is returned when a directory is not the root of a git repository
+var ErrNotRepository = errors.New("not a git repository")
+
+// Fallback identity used for commits when git has no user configured
+const (
+	fallbackName  = "dsa"
+	fallbackEmail = "dsa@localhost"
+)
+
+// Repo runs git commands against a repository rooted at a directory
+type Repo struct {
+	dir string
+}
+
+// Commit is one entry of a repository's log
+type Commit struct {
+	Hash     string
+	Date     time.Time
+	Subject  string
+	Trailers map[string][]string // "Key: value" lines of the message body, in order
+}
+
+// Trailer returns the first value of a trailer, or "" if the commit has none
+func (c *Commit) Trailer(key string) string {
+	if values := c.Trailers[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// NewRepo creates a repository handle for dir. The repository does not need
+// to exist yet; see Init.
+func NewRepo(dir string) *Repo {
+	return &Repo{dir: dir}
+}
+
+// Dir returns the repository's root directory
+func (r *Repo) Dir() string {
+	return r.dir
+}
+
+// Exists reports whether dir is itself the root of a git repository.
+// A directory inside another repository does not count.
+func (r *Repo) Exists() bool {
+	_, err := os.Stat(filepath.Join(r.dir, ".git"))
+	return err == nil
+}
+
+// Init creates the repository if it does not exist yet, together with a
+// .gitignore for the given patterns
+func (r *Repo) Init(ignore ...string) error {
+	if r.Exists() {
+		return nil
+	}
+
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", r.dir, err)
+	}
+	if _, err := r.run("init", "--quiet"); err != nil {
+		return err
+	}
+
+	if len(ignore) > 0 {
+		content := strings.Join(ignore, "\n") + "\n"
+		if err := os.WriteFile(filepath.Join(r.dir, ".gitignore"), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write .gitignore: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Commit stages the given paths, relative to the repository root, and commits
+// them with message. A commit is created even when the files are unchanged so
+// every call is recorded. Returns the hash of the new commit.
+func (r *Repo) Commit(message string, paths ...string) (string, error) {
+	if !r.Exists() {
+		return "", ErrNotRepository
+	}
+
+	addArgs := append([]string{"add", "--"}, paths...)
+	if _, err := r.run(addArgs...); err != nil {
+		return "", err
+	}
+
+	commitArgs := append(r.identityArgs(), "commit", "--quiet", "--allow-empty", "--no-verify", "--file", "-", "--")
+	commitArgs = append(commitArgs, paths...)
+	if _, err := r.runInput(message, commitArgs...); err != nil {
+		return "", err
+	}
+
+	hash, err := r.run("rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(hash), nil
+}
+
+// identityArgs supplies a fallback author when git has no identity configured,
+// so committing never fails on a fresh machine
+func (r *Repo) identityArgs() []string {
+	var args []string
+	if name, _ := r.run("config", "user.name"); strings.TrimSpace(name) == "" {
+		args = append(args, "-c", "user.name="+fallbackName)
+	}
+	if email, _ := r.run("config", "user.email"); strings.TrimSpace(email) == "" {
+		args = append(args, "-c", "user.email="+fallbackEmail)
+	}
+	return args
+}
+
+// Log returns the commits whose message has a line matching the extended
+// regular expression grep, most recent first. An empty grep returns every commit.
+func (r *Repo) Log(grep string) ([]Commit, error) {
+	if !r.Exists() {
+		return nil, ErrNotRepository
+	}
+
+	// Fields are separated by \x1f and commits by \x1e
+	args := []string{"log", "--format=%H%x1f%aI%x1f%B%x1e"}
+	if grep != "" {
+		args = append(args, "--extended-regexp", "--grep="+grep)
+	}
+
+	out, err := r.run(args...)
+	if err != nil {
+		// A repository without commits has no log
+		if !r.hasCommits() {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, entry := range strings.Split(out, "\x1e") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.SplitN(entry, "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date of commit %s: %w", fields[0], err)
+		}
+		subject, trailers := parseMessage(fields[2])
+		commits = append(commits, Commit{
+			Hash:     fields[0],
+			Date:     date,
+			Subject:  subject,
+			Trailers: trailers,
+		})
+	}
+
+	return commits, nil
+}
+
+// hasCommits reports whether HEAD points at a commit
+func (r *Repo) hasCommits() bool {
+	_, err := r.run("rev-parse", "--verify", "--quiet", "HEAD")
+	return err == nil
+}
+
+// parseMessage splits a commit message into its subject and the "Key: value"
+// lines of its body
+func parseMessage(message string) (string, map[string][]string) {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	trailers := make(map[string][]string)
+	for _, line := range lines[1:] {
+		key, value, found := strings.Cut(line, ": ")
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			continue
+		}
+		trailers[key] = append(trailers[key], strings.TrimSpace(value))
+	}
+	return lines[0], trailers
+}
+
+// Show returns the content of a file, relative to the repository root, at a commit
+func (r *Repo) Show(hash, path string) (string, error) {
+	return r.run("show", hash+":"+filepath.ToSlash(path))
+}
+
+// Checkout restores a file, relative to the repository root, to its content at a commit
+func (r *Repo) Checkout(hash, path string) error {
+	_, err := r.run("checkout", hash, "--", path)
+	return err
+}
+
+// run executes git in the repository and returns its standard output
+func (r *Repo) run(args ...string) (string, error) {
+	return r.runInput("", args...)
+}
+
+// runInput executes git in the repository with input on standard input
+func (r *Repo) runInput(input string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", r.dir}, args...)...)
+	cmd.Stdin = strings.NewReader(input)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		detail := strings.TrimSpace(stderr.String())
+		if detail == "" {
+			detail = err.Error()
+		}
+		return "", fmt.Errorf("git %s failed: %s", commandName(args), detail)
+	}
+
+	return stdout.String(), nil
+}
+
+// commandName returns the git subcommand of an argument list, skipping
+// leading "-c key=value" options
+func commandName(args []string) string {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" {
+			i++
+			continue
+		}
+		return args[i]
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+package gitstore
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRepo creates an initialized repository in a temporary directory
+func newTestRepo(t *testing.T) *Repo {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	repo := NewRepo(filepath.Join(t.TempDir(), "solutions"))
+	require.NoError(t, repo.Init("history/"))
+	return repo
+}
+
+func TestInit(t *testing.T) {
+	t.Run("creates repository and .gitignore", func(t *testing.T) {
+		repo := newTestRepo(t)
+
+		assert.True(t, repo.Exists())
+		content, err := os.ReadFile(filepath.Join(repo.Dir(), ".gitignore"))
+		require.NoError(t, err)
+		assert.Equal(t, "history/\n", string(content))
+	})
+
+	t.Run("is a no-op for an existing repository", func(t *testing.T) {
+		repo := newTestRepo(t)
+		require.NoError(t, os.WriteFile(filepath.Join(repo.Dir(), ".gitignore"), []byte("custom\n"), 0644))
+
+		require.NoError(t, repo.Init("history/"))
+
+		content, err := os.ReadFile(filepath.Join(repo.Dir(), ".gitignore"))
+		require.NoError(t, err)
+		assert.Equal(t, "custom\n", string(content))
+	})
+
+	t.Run("a directory without .git does not exist as a repository", func(t *testing.T) {
+		repo := NewRepo(t.TempDir())
+		assert.False(t, repo.Exists())
+
+		_, err := repo.Commit("message", "file.go")
+		assert.ErrorIs(t, err, ErrNotRepository)
+	})
+}
+
+func TestCommitAndLog(t *testing.T) {
+	t.Run("empty repository has no log", func(t *testing.T) {
+		repo := newTestRepo(t)
+
+		commits, err := repo.Log("")
+		assert.NoError(t, err)
+		assert.Empty(t, commits)
+	})
+
+	t.Run("records commits with trailers, most recent first", func(t *testing.T) {
+		repo := newTestRepo(t)
+		path := filepath.Join(repo.Dir(), "two-sum.go")
+
+		require.NoError(t, os.WriteFile(path, []byte("package solutions\n"), 0644))
+		first, err := repo.Commit("two-sum: failed\n\nProblem: two-sum\nVerdict: failed\n", "two-sum.go")
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(path, []byte("package solutions\n\n// v2\n"), 0644))
+		second, err := repo.Commit("two-sum: passed\n\nProblem: two-sum\nVerdict: passed\nBench: A 1 ns/op\nBench: B 2 ns/op\n", "two-sum.go")
+		require.NoError(t, err)
+
+		commits, err := repo.Log("")
+		require.NoError(t, err)
+		require.Len(t, commits, 2)
+
+		assert.Equal(t, second, commits[0].Hash)
+		assert.Equal(t, "two-sum: passed", commits[0].Subject)
+		assert.Equal(t, "passed", commits[0].Trailer("Verdict"))
+		assert.Equal(t, []string{"A 1 ns/op", "B 2 ns/op"}, commits[0].Trailers["Bench"])
+		assert.False(t, commits[0].Date.IsZero())
+
+		assert.Equal(t, first, commits[1].Hash)
+		assert.Equal(t, "failed", commits[1].Trailer("Verdict"))
+		assert.Equal(t, "", commits[1].Trailer("Missing"))
+	})
+
+	t.Run("commits unchanged files", func(t *testing.T) {
+		repo := newTestRepo(t)
+		require.NoError(t, os.WriteFile(filepath.Join(repo.Dir(), "a.go"), []byte("package a\n"), 0644))
+
+		first, err := repo.Commit("first", "a.go")
+		require.NoError(t, err)
+		second, err := repo.Commit("second", "a.go")
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("filters by message", func(t *testing.T) {
+		repo := newTestRepo(t)
+		require.NoError(t, os.WriteFile(filepath.Join(repo.Dir(), "a.go"), []byte("package a\n"), 0644))
+
+		_, err := repo.Commit("a\n\nProblem: two-sum\n", "a.go")
+		require.NoError(t, err)
+		_, err = repo.Commit("b\n\nProblem: two-sum-ii\n", "a.go")
+		require.NoError(t, err)
+
+		commits, err := repo.Log("^Problem: two-sum$")
+		require.NoError(t, err)
+		require.Len(t, commits, 1)
+		assert.Equal(t, "a", commits[0].Subject)
+	})
+
+	t.Run("commits only the given paths", func(t *testing.T) {
+		repo := newTestRepo(t)
+		require.NoError(t, os.WriteFile(filepath.Join(repo.Dir(), "a.go"), []byte("package a\n"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(repo.Dir(), "b.go"), []byte("package b\n"), 0644))
+
+		hash, err := repo.Commit("a only", "a.go")
+		require.NoError(t, err)
+
+		_, err = repo.Show(hash, "a.go")
+		assert.NoError(t, err)
+		_, err = repo.Show(hash, "b.go")
+		assert.Error(t, err)
+	})
+}
+
+func TestShowAndCheckout(t *testing.T) {
+	repo := newTestRepo(t)
+	path := filepath.Join(repo.Dir(), "variants", "hashmap", "two-sum.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+
+	require.NoError(t, os.WriteFile(path, []byte("version 1\n"), 0644))
+	first, err := repo.Commit("v1", filepath.Join("variants", "hashmap", "two-sum.go"))
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("version 2\n"), 0644))
+	_, err = repo.Commit("v2", filepath.Join("variants", "hashmap", "two-sum.go"))
+	require.NoError(t, err)
+
+	code, err := repo.Show(first, filepath.Join("variants", "hashmap", "two-sum.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "version 1\n", code)
+
+	require.NoError(t, repo.Checkout(first, filepath.Join("variants", "hashmap", "two-sum.go")))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "version 1\n", string(content))
+}
+
+func TestParseMessage(t *testing.T) {
+	subject, trailers := parseMessage("subject line\n\nsome prose: not a trailer\nKey: value\nOther:  spaced \n")
+
+	assert.Equal(t, "subject line", subject)
+	assert.Equal(t, []string{"value"}, trailers["Key"])
+	assert.Equal(t, []string{"spaced"}, trailers["Other"])
+	assert.NotContains(t, trailers, "some prose")
+}
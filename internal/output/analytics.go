@@ -3,6 +3,7 @@ package output
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/ak95asb/dsa-dojo/internal/analytics"
@@ -77,6 +78,12 @@ func (f *AnalyticsFormatter) Render() string {
 		output.WriteString("\n")
 	}
 
+	// Approaches practiced per problem, shown once variants are in use
+	if f.stats.MultiApproachProblems > 0 {
+		output.WriteString(f.formatApproaches())
+		output.WriteString("\n")
+	}
+
 	return output.String()
 }
 
@@ -172,6 +179,28 @@ func (f *AnalyticsFormatter) formatInsights() string {
 	return output.String()
 }
 
+// formatApproaches lists problems solved with more than one approach
+func (f *AnalyticsFormatter) formatApproaches() string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("Approaches (%d problems solved multiple ways):\n", f.stats.MultiApproachProblems))
+
+	slugs := make([]string, 0, len(f.stats.ApproachesByProblem))
+	for slug, variants := range f.stats.ApproachesByProblem {
+		if len(variants) > 1 {
+			slugs = append(slugs, slug)
+		}
+	}
+	sort.Strings(slugs)
+
+	for _, slug := range slugs {
+		output.WriteString(fmt.Sprintf("  %-24s %s\n", slug+":",
+			color.CyanString(strings.Join(f.stats.ApproachesByProblem[slug], ", "))))
+	}
+
+	return output.String()
+}
+
 // formatRateBar creates a colored progress bar for success rates
 func (f *AnalyticsFormatter) formatRateBar(rate float64, width int) string {
 	if rate < 0 {
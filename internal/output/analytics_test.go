@@ -222,9 +222,9 @@ func TestAnalyticsFormatter_FormatRateBar(t *testing.T) {
 	formatter := NewAnalyticsFormatter(&analytics.AnalyticsStats{}, analytics.AnalyticsFilter{})
 
 	tests := []struct {
-		name     string
-		rate     float64
-		width    int
+		name      string
+		rate      float64
+		width     int
 		minFilled int
 		maxFilled int
 	}{
@@ -270,3 +270,34 @@ func TestAnalyticsFormatter_EmptyStats(t *testing.T) {
 		formatter.Render()
 	})
 }
+
+func TestAnalyticsFormatter_RenderApproaches(t *testing.T) {
+	os.Setenv("NO_COLOR", "1")
+	defer os.Unsetenv("NO_COLOR")
+
+	stats := &analytics.AnalyticsStats{
+		ApproachesByProblem: map[string][]string{
+			"two-sum":            {"default", "hashmap"},
+			"valid-parentheses":  {"stack"},
+			"contains-duplicate": {"brute", "set", "sorting"},
+		},
+		MultiApproachProblems: 2,
+	}
+
+	output := NewAnalyticsFormatter(stats, analytics.AnalyticsFilter{}).Render()
+
+	assert.Contains(t, output, "Approaches (2 problems solved multiple ways):")
+	assert.Contains(t, output, "two-sum:")
+	assert.Contains(t, output, "default, hashmap")
+	assert.Contains(t, output, "brute, set, sorting")
+	assert.NotContains(t, output, "valid-parentheses")
+	assert.Less(t, strings.Index(output, "contains-duplicate"), strings.Index(output, "two-sum"))
+
+	t.Run("omitted without multiple approaches", func(t *testing.T) {
+		stats.MultiApproachProblems = 0
+
+		output := NewAnalyticsFormatter(stats, analytics.AnalyticsFilter{}).Render()
+
+		assert.NotContains(t, output, "Approaches")
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"github.com/fatih/color"
 )
 
@@ -77,9 +79,26 @@ func PrintProblemDetails(details *problem.ProblemDetails) {
 	}
 
 	if details.HasSolution {
-		fmt.Printf("  Solution File: solutions/%s.go\n", details.Slug)
+		fmt.Printf("  Solution File: %s\n", workspace.ProblemLayout(details.Slug).Solution())
 	}
 
 	fmt.Println()
 	fmt.Println(strings.Repeat("=", 80))
 }
+
+// PrintSnippetSuggestions lists snippets relevant to a problem with the
+// command that inserts them into its solution. Prints nothing when empty.
+func PrintSnippetSuggestions(slug string, snippets []*snippet.Snippet) {
+	if len(snippets) == 0 {
+		return
+	}
+
+	boldColor := color.New(color.Bold).SprintFunc()
+	cyanColor := color.New(color.FgCyan).SprintFunc()
+
+	fmt.Printf("%s:\n", boldColor("Suggested Snippets"))
+	for _, s := range snippets {
+		fmt.Printf("  %s %s\n", cyanColor(fmt.Sprintf("%-14s", s.Name)), s.Description)
+	}
+	fmt.Printf("\nInsert one with: dsa snippet insert %s <name>\n", slug)
+}
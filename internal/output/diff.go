@@ -0,0 +1,54 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/fatih/color"
+)
+
+// FormatUnifiedDiff renders diff hunks as a colored unified diff between two
+// labelled versions: removals in red, additions in green, hunk headers in cyan
+func FormatUnifiedDiff(labelA, labelB string, hunks []solution.Hunk) string {
+	// Check NO_COLOR environment variable
+	if os.Getenv("NO_COLOR") != "" {
+		color.NoColor = true
+	}
+
+	boldColor := color.New(color.Bold).SprintFunc()
+	cyanColor := color.New(color.FgCyan).SprintFunc()
+	redColor := color.New(color.FgRed).SprintFunc()
+	greenColor := color.New(color.FgGreen).SprintFunc()
+
+	var output strings.Builder
+
+	output.WriteString(boldColor("--- "+labelA) + "\n")
+	output.WriteString(boldColor("+++ "+labelB) + "\n")
+
+	for _, hunk := range hunks {
+		output.WriteString(cyanColor(fmt.Sprintf("@@ -%s +%s @@", hunkRange(hunk.OldStart, hunk.OldLines), hunkRange(hunk.NewStart, hunk.NewLines))) + "\n")
+		for _, line := range hunk.Lines {
+			switch line.Op {
+			case solution.DiffDelete:
+				output.WriteString(redColor("-"+line.Text) + "\n")
+			case solution.DiffInsert:
+				output.WriteString(greenColor("+"+line.Text) + "\n")
+			default:
+				output.WriteString(" " + line.Text + "\n")
+			}
+		}
+	}
+
+	return output.String()
+}
+
+// hunkRange formats one side of a hunk header, omitting a count of 1 as
+// unified diffs do
+func hunkRange(start, lines int) string {
+	if lines == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, lines)
+}
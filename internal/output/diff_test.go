@@ -0,0 +1,34 @@
+package output
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	os.Setenv("NO_COLOR", "1")
+	defer os.Unsetenv("NO_COLOR")
+
+	hunks := solution.Diff("a\nb\nc\n", "a\nB\nc\nd\n", 3)
+
+	output := FormatUnifiedDiff("#2", "current", hunks)
+
+	assert.Equal(t, `--- #2
++++ current
+@@ -1,3 +1,4 @@
+ a
+-b
++B
+ c
++d
+`, output)
+}
+
+func TestHunkRange(t *testing.T) {
+	assert.Equal(t, "5", hunkRange(5, 1))
+	assert.Equal(t, "5,3", hunkRange(5, 3))
+	assert.Equal(t, "0,0", hunkRange(0, 0))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"gorm.io/gorm"
 )
 
@@ -151,8 +152,8 @@ func (s *Service) GetProblemBySlug(slug string) (*ProblemDetails, error) {
 		Status:          "not_started",
 		Attempts:        0,
 		HasSolution:     solutionCount > 0,
-		BoilerplatePath: fmt.Sprintf("problems/templates/%s.go", slug),
-		TestPath:        fmt.Sprintf("problems/templates/%s_test.go", slug),
+		BoilerplatePath: workspace.ProblemLayout(slug).ReferenceBoilerplate(),
+		TestPath:        workspace.ProblemLayout(slug).ReferenceTests(),
 	}
 
 	if progressErr == nil {
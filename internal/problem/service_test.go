@@ -272,8 +272,8 @@ func TestGetProblemBySlug(t *testing.T) {
 		assert.Equal(t, "arrays", details.Topic)
 		assert.Equal(t, "completed", details.Status) // Problem ID 1 is marked as completed
 		assert.Equal(t, 3, details.Attempts)
-		assert.Equal(t, "problems/templates/two-sum.go", details.BoilerplatePath)
-		assert.Equal(t, "problems/templates/two-sum_test.go", details.TestPath)
+		assert.Equal(t, "problems/templates/two_sum.go", details.BoilerplatePath)
+		assert.Equal(t, "problems/templates/two_sum_test.go", details.TestPath)
 	})
 
 	t.Run("returns problem details for unsolved problem", func(t *testing.T) {
@@ -0,0 +1,297 @@
+// Package profiling summarizes CPU and memory profiles written by go test
+// so they can be read without opening go tool pprof.
+package profiling
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/pprof/profile"
+)
+
+// Kind identifies what a profile measures
+type Kind string
+
+const (
+	KindCPU    Kind = "cpu"
+	KindMemory Kind = "memory"
+)
+
+// DefaultTop is the number of functions and allocation sites shown by default
+const DefaultTop = 10
+
+// FunctionStat is the cost attributed to one function
+type FunctionStat struct {
+	Name string
+	Flat int64 // Cost spent in the function itself
+	Cum  int64 // Cost spent in the function and everything it calls
+}
+
+// AllocSite is a source line that allocates memory
+type AllocSite struct {
+	Function string
+	File     string
+	Line     int64
+	Bytes    int64
+	Objects  int64
+}
+
+// Report is the summary of one profile
+type Report struct {
+	Kind       Kind
+	Unit       string // Unit of Flat/Cum/Total values: "nanoseconds" or "bytes"
+	Total      int64
+	Functions  []FunctionStat // Top functions by flat cost
+	AllocSites []AllocSite    // Top allocation sites (memory profiles only)
+
+	prof       *profile.Profile
+	valueIndex int
+}
+
+// Analyzer parses profiles and summarizes them
+type Analyzer struct{}
+
+// NewAnalyzer creates a new profile analyzer
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{}
+}
+
+// Analyze parses a profile file and returns its top functions and, for
+// memory profiles, its top allocation sites
+func (a *Analyzer) Analyze(path string, top int) (*Report, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open profile: %w", err)
+	}
+	defer file.Close()
+
+	prof, err := profile.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse profile %s: %w", path, err)
+	}
+
+	if top <= 0 {
+		top = DefaultTop
+	}
+
+	report := &Report{prof: prof}
+	report.Kind, report.valueIndex, report.Unit = selectSampleType(prof)
+
+	flat := make(map[string]int64)
+	cum := make(map[string]int64)
+	for _, sample := range prof.Sample {
+		value := sample.Value[report.valueIndex]
+		report.Total += value
+
+		frames := stackFrames(sample)
+		if len(frames) == 0 {
+			continue
+		}
+		flat[frames[0].Function] += value
+
+		// Count each function once per sample so recursion isn't double counted
+		seen := make(map[string]bool)
+		for _, frame := range frames {
+			if !seen[frame.Function] {
+				seen[frame.Function] = true
+				cum[frame.Function] += value
+			}
+		}
+	}
+
+	for name, c := range cum {
+		report.Functions = append(report.Functions, FunctionStat{Name: name, Flat: flat[name], Cum: c})
+	}
+	sort.Slice(report.Functions, func(i, j int) bool {
+		fi, fj := report.Functions[i], report.Functions[j]
+		if fi.Flat != fj.Flat {
+			return fi.Flat > fj.Flat
+		}
+		if fi.Cum != fj.Cum {
+			return fi.Cum > fj.Cum
+		}
+		return fi.Name < fj.Name
+	})
+	if len(report.Functions) > top {
+		report.Functions = report.Functions[:top]
+	}
+
+	if report.Kind == KindMemory {
+		report.AllocSites = allocSites(prof, report.valueIndex, top)
+	}
+
+	return report, nil
+}
+
+// selectSampleType picks the value to report: CPU time for CPU profiles and
+// allocated bytes for memory profiles
+func selectSampleType(prof *profile.Profile) (Kind, int, string) {
+	for i, st := range prof.SampleType {
+		if st.Type == "cpu" {
+			return KindCPU, i, st.Unit
+		}
+	}
+	for i, st := range prof.SampleType {
+		if st.Type == "alloc_space" {
+			return KindMemory, i, st.Unit
+		}
+	}
+
+	last := len(prof.SampleType) - 1
+	kind := KindCPU
+	if prof.SampleType[last].Unit == "bytes" {
+		kind = KindMemory
+	}
+	return kind, last, prof.SampleType[last].Unit
+}
+
+// frame is one entry of a call stack
+type frame struct {
+	Function string
+	File     string
+	Line     int64
+}
+
+// stackFrames flattens a sample's locations, including inlined calls,
+// into frames ordered from leaf to root
+func stackFrames(sample *profile.Sample) []frame {
+	var frames []frame
+	for _, loc := range sample.Location {
+		for _, line := range loc.Line {
+			if line.Function == nil {
+				continue
+			}
+			frames = append(frames, frame{
+				Function: line.Function.Name,
+				File:     line.Function.Filename,
+				Line:     line.Line,
+			})
+		}
+	}
+	return frames
+}
+
+// allocSites groups allocated bytes and objects by the allocating line
+func allocSites(prof *profile.Profile, bytesIndex, top int) []AllocSite {
+	objectsIndex := -1
+	for i, st := range prof.SampleType {
+		if st.Type == "alloc_objects" {
+			objectsIndex = i
+		}
+	}
+
+	type siteKey struct {
+		file string
+		line int64
+	}
+	sites := make(map[siteKey]*AllocSite)
+	for _, sample := range prof.Sample {
+		frames := stackFrames(sample)
+		if len(frames) == 0 {
+			continue
+		}
+
+		// Skip runtime frames so the site is the user code that allocated
+		leaf := frames[0]
+		for _, f := range frames {
+			if !strings.HasPrefix(f.Function, "runtime.") {
+				leaf = f
+				break
+			}
+		}
+
+		key := siteKey{leaf.File, leaf.Line}
+		site, ok := sites[key]
+		if !ok {
+			site = &AllocSite{Function: leaf.Function, File: leaf.File, Line: leaf.Line}
+			sites[key] = site
+		}
+		site.Bytes += sample.Value[bytesIndex]
+		if objectsIndex >= 0 {
+			site.Objects += sample.Value[objectsIndex]
+		}
+	}
+
+	result := make([]AllocSite, 0, len(sites))
+	for _, site := range sites {
+		if site.Bytes > 0 {
+			result = append(result, *site)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Bytes != result[j].Bytes {
+			return result[i].Bytes > result[j].Bytes
+		}
+		return result[i].Line < result[j].Line
+	})
+	if len(result) > top {
+		result = result[:top]
+	}
+	return result
+}
+
+// AnnotatedLine is one line of a source file with the cost attributed to it
+type AnnotatedLine struct {
+	Number int
+	Source string
+	Flat   int64
+	Cum    int64
+}
+
+// Annotate attributes profile costs to the lines of a source file.
+// Lines are matched by absolute path or, for profiles recorded elsewhere,
+// by path suffix. Aliases are further path suffixes under which the same
+// source was compiled, e.g. when it was copied to a staging directory.
+func (r *Report) Annotate(sourcePath string, aliases ...string) ([]AnnotatedLine, error) {
+	data, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source file: %w", err)
+	}
+
+	absPath, _ := filepath.Abs(sourcePath)
+	suffixes := []string{"/" + filepath.ToSlash(filepath.Clean(sourcePath))}
+	for _, alias := range aliases {
+		suffixes = append(suffixes, "/"+filepath.ToSlash(filepath.Clean(alias)))
+	}
+	matches := func(file string) bool {
+		if file == absPath {
+			return true
+		}
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(filepath.ToSlash(file), suffix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	flat := make(map[int64]int64)
+	cum := make(map[int64]int64)
+	for _, sample := range r.prof.Sample {
+		value := sample.Value[r.valueIndex]
+		seen := make(map[int64]bool)
+		for i, f := range stackFrames(sample) {
+			if !matches(f.File) {
+				continue
+			}
+			if i == 0 {
+				flat[f.Line] += value
+			}
+			if !seen[f.Line] {
+				seen[f.Line] = true
+				cum[f.Line] += value
+			}
+		}
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	annotated := make([]AnnotatedLine, len(lines))
+	for i, source := range lines {
+		n := int64(i + 1)
+		annotated[i] = AnnotatedLine{Number: i + 1, Source: source, Flat: flat[n], Cum: cum[n]}
+	}
+	return annotated, nil
+}
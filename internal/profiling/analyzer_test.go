@@ -0,0 +1,205 @@
+package profiling
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSolution is the source file that synthetic profiles point into
+const testSolution = `package solutions
+
+func TwoSum(nums []int, target int) []int {
+	seen := make(map[int]int)
+	for i, n := range nums {
+		if j, ok := seen[target-n]; ok {
+			return []int{j, i}
+		}
+		seen[n] = i
+	}
+	return nil
+}
+`
+
+// writeTestProfile builds a profile with the given sample types where each
+// sample is a stack of "function:line" frames (leaf first) in solutionPath
+func writeTestProfile(t *testing.T, sampleTypes []*profile.ValueType, solutionPath string, samples map[string][]int64) string {
+	prof := &profile.Profile{SampleType: sampleTypes, PeriodType: sampleTypes[len(sampleTypes)-1], Period: 1}
+
+	functions := make(map[string]*profile.Function)
+	var nextID uint64 = 1
+	for stack, values := range samples {
+		sample := &profile.Sample{Value: values}
+		for _, frameSpec := range strings.Split(stack, ";") {
+			name, lineSpec, _ := strings.Cut(frameSpec, ":")
+			fn, ok := functions[name]
+			if !ok {
+				file := "/usr/local/go/src/testing/benchmark.go"
+				if strings.HasPrefix(name, "solutions.") {
+					file = solutionPath
+				}
+				fn = &profile.Function{ID: nextID, Name: name, SystemName: name, Filename: file}
+				functions[name] = fn
+				prof.Function = append(prof.Function, fn)
+				nextID++
+			}
+			var line int64
+			for _, c := range lineSpec {
+				line = line*10 + int64(c-'0')
+			}
+			loc := &profile.Location{ID: nextID, Line: []profile.Line{{Function: fn, Line: line}}}
+			nextID++
+			prof.Location = append(prof.Location, loc)
+			sample.Location = append(sample.Location, loc)
+		}
+		prof.Sample = append(prof.Sample, sample)
+	}
+	require.NoError(t, prof.CheckValid())
+
+	path := filepath.Join(t.TempDir(), "test.prof")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	require.NoError(t, prof.Write(file))
+	return path
+}
+
+// cpuSampleTypes matches the sample types of Go CPU profiles
+var cpuSampleTypes = []*profile.ValueType{{Type: "samples", Unit: "count"}, {Type: "cpu", Unit: "nanoseconds"}}
+
+// memSampleTypes matches the sample types of Go heap profiles
+var memSampleTypes = []*profile.ValueType{
+	{Type: "alloc_objects", Unit: "count"},
+	{Type: "alloc_space", Unit: "bytes"},
+	{Type: "inuse_objects", Unit: "count"},
+	{Type: "inuse_space", Unit: "bytes"},
+}
+
+// setupSolution writes testSolution to a temp dir and returns its path
+func setupSolution(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "solutions", "two_sum.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(testSolution), 0644))
+	return path
+}
+
+func TestAnalyzer_Analyze_CPU(t *testing.T) {
+	solutionPath := setupSolution(t)
+	profilePath := writeTestProfile(t, cpuSampleTypes, solutionPath, map[string][]int64{
+		"runtime.mapaccess2_fast64;solutions.TwoSum:6;testing.(*B).run1:1": {6, 60_000_000},
+		"runtime.mapassign_fast64;solutions.TwoSum:9;testing.(*B).run1:1":  {3, 30_000_000},
+		"solutions.TwoSum:5;testing.(*B).run1:1":                           {1, 10_000_000},
+	})
+
+	report, err := NewAnalyzer().Analyze(profilePath, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, KindCPU, report.Kind)
+	assert.Equal(t, "nanoseconds", report.Unit)
+	assert.Equal(t, int64(100_000_000), report.Total)
+	require.NotEmpty(t, report.Functions)
+	assert.Equal(t, "runtime.mapaccess2_fast64", report.Functions[0].Name)
+	assert.Equal(t, int64(60_000_000), report.Functions[0].Flat)
+
+	var twoSum FunctionStat
+	for _, fn := range report.Functions {
+		if fn.Name == "solutions.TwoSum" {
+			twoSum = fn
+		}
+	}
+	assert.Equal(t, int64(10_000_000), twoSum.Flat)
+	assert.Equal(t, int64(100_000_000), twoSum.Cum)
+	assert.Empty(t, report.AllocSites)
+
+	t.Run("limits top functions", func(t *testing.T) {
+		report, err := NewAnalyzer().Analyze(profilePath, 2)
+
+		require.NoError(t, err)
+		assert.Len(t, report.Functions, 2)
+	})
+
+	t.Run("annotates solution lines", func(t *testing.T) {
+		lines, err := report.Annotate(solutionPath)
+
+		require.NoError(t, err)
+		require.Len(t, lines, 12)
+		assert.Equal(t, int64(60_000_000), lines[5].Cum) // Line 6: map lookup
+		assert.Equal(t, int64(0), lines[5].Flat)         // Time is spent in the runtime
+		assert.Equal(t, int64(30_000_000), lines[8].Cum) // Line 9: map insert
+		assert.Equal(t, int64(10_000_000), lines[4].Flat)
+		assert.Equal(t, int64(0), lines[0].Cum)
+	})
+
+	t.Run("annotates source compiled under another path", func(t *testing.T) {
+		copyPath := filepath.Join(t.TempDir(), "copy.go")
+		require.NoError(t, os.WriteFile(copyPath, []byte(testSolution), 0644))
+
+		lines, err := report.Annotate(copyPath, "solutions/two_sum.go")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(60_000_000), lines[5].Cum)
+
+		lines, err = report.Annotate(copyPath)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), lines[5].Cum)
+	})
+
+	t.Run("renders flame graph", func(t *testing.T) {
+		var svg bytes.Buffer
+
+		err := report.WriteFlameGraph(&svg, "Two <Sum>")
+
+		require.NoError(t, err)
+		output := svg.String()
+		assert.True(t, strings.HasPrefix(output, "<svg"))
+		assert.Contains(t, output, "Two &lt;Sum&gt;")
+		assert.Contains(t, output, "solutions.TwoSum")
+		assert.Contains(t, output, "<title>all (100.00ms, 100.00%)</title>")
+		assert.NotContains(t, output, "<script")
+	})
+}
+
+func TestAnalyzer_Analyze_Memory(t *testing.T) {
+	solutionPath := setupSolution(t)
+	profilePath := writeTestProfile(t, memSampleTypes, solutionPath, map[string][]int64{
+		"runtime.makemap_small;solutions.TwoSum:4;testing.(*B).run1:1":    {100, 4800, 0, 0},
+		"runtime.mapassign_fast64;solutions.TwoSum:9;testing.(*B).run1:1": {50, 16384, 0, 0},
+		"runtime.growslice;solutions.TwoSum:7;testing.(*B).run1:1":        {10, 160, 0, 0},
+	})
+
+	report, err := NewAnalyzer().Analyze(profilePath, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, KindMemory, report.Kind)
+	assert.Equal(t, "bytes", report.Unit)
+	require.Len(t, report.AllocSites, 3)
+	assert.Equal(t, int64(9), report.AllocSites[0].Line)
+	assert.Equal(t, int64(16384), report.AllocSites[0].Bytes)
+	assert.Equal(t, int64(50), report.AllocSites[0].Objects)
+	assert.Equal(t, "solutions.TwoSum", report.AllocSites[0].Function)
+}
+
+func TestAnalyzer_Analyze_Errors(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewAnalyzer().Analyze(filepath.Join(t.TempDir(), "missing.prof"), 10)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid profile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bad.prof")
+		require.NoError(t, os.WriteFile(path, []byte("not a profile"), 0644))
+
+		_, err := NewAnalyzer().Analyze(path, 10)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to parse profile")
+	})
+}
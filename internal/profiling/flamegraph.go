@@ -0,0 +1,164 @@
+package profiling
+
+import (
+	"fmt"
+	"hash/fnv"
+	"html"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Flame graph layout in SVG user units
+const (
+	flameWidth      = 1200.0
+	flameRowHeight  = 18.0
+	flamePadding    = 10.0
+	flameTitleSpace = 30.0
+	flameCharWidth  = 7.0 // Approximate width of one character at font-size 12
+	flameMinWidth   = 0.3 // Frames narrower than this are not drawn
+)
+
+// flameNode is a function in the merged call tree
+type flameNode struct {
+	name     string
+	value    int64
+	children map[string]*flameNode
+}
+
+// child returns the named child, creating it if needed
+func (n *flameNode) child(name string) *flameNode {
+	if n.children == nil {
+		n.children = make(map[string]*flameNode)
+	}
+	c, ok := n.children[name]
+	if !ok {
+		c = &flameNode{name: name}
+		n.children[name] = c
+	}
+	return c
+}
+
+// sortedChildren returns children in name order so output is deterministic
+func (n *flameNode) sortedChildren() []*flameNode {
+	children := make([]*flameNode, 0, len(n.children))
+	for _, c := range n.children {
+		children = append(children, c)
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].name < children[j].name })
+	return children
+}
+
+// depth returns the height of the tree below and including n
+func (n *flameNode) depth() int {
+	max := 0
+	for _, c := range n.children {
+		if d := c.depth(); d > max {
+			max = d
+		}
+	}
+	return max + 1
+}
+
+// buildFlameTree merges all sample stacks into a call tree rooted at "all"
+func (r *Report) buildFlameTree() *flameNode {
+	root := &flameNode{name: "all"}
+	for _, sample := range r.prof.Sample {
+		value := sample.Value[r.valueIndex]
+		if value == 0 {
+			continue
+		}
+		root.value += value
+
+		frames := stackFrames(sample)
+		node := root
+		for i := len(frames) - 1; i >= 0; i-- {
+			node = node.child(frames[i].Function)
+			node.value += value
+		}
+	}
+	return root
+}
+
+// WriteFlameGraph renders the profile as a self-contained SVG flame graph.
+// The SVG has no scripts or external references; hovering a frame shows
+// its full name and cost.
+func (r *Report) WriteFlameGraph(w io.Writer, title string) error {
+	root := r.buildFlameTree()
+	depth := root.depth()
+	height := flameTitleSpace + float64(depth)*flameRowHeight + flamePadding*2
+
+	var svg strings.Builder
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="Verdana, sans-serif" font-size="12">`+"\n",
+		flameWidth, height, flameWidth, height))
+	svg.WriteString(`<rect width="100%" height="100%" fill="#fdfdf6"/>` + "\n")
+	svg.WriteString(fmt.Sprintf(`<text x="%.0f" y="20" text-anchor="middle" font-size="16">%s</text>`+"\n",
+		flameWidth/2, html.EscapeString(title)))
+
+	if root.value > 0 {
+		scale := (flameWidth - flamePadding*2) / float64(root.value)
+		// Root sits on the bottom row; callees stack upwards
+		bottom := height - flamePadding - flameRowHeight
+		r.writeFlameNode(&svg, root, flamePadding, bottom, scale, root.value)
+	}
+
+	svg.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, svg.String())
+	return err
+}
+
+// writeFlameNode draws a frame and, above it, its callees
+func (r *Report) writeFlameNode(svg *strings.Builder, node *flameNode, x, y, scale float64, total int64) {
+	width := float64(node.value) * scale
+	if width < flameMinWidth {
+		return
+	}
+
+	tooltip := fmt.Sprintf("%s (%s, %.2f%%)", node.name, FormatValue(node.value, r.Unit), float64(node.value)/float64(total)*100)
+	svg.WriteString("<g>")
+	svg.WriteString(fmt.Sprintf("<title>%s</title>", html.EscapeString(tooltip)))
+	svg.WriteString(fmt.Sprintf(`<rect x="%.2f" y="%.2f" width="%.2f" height="%.0f" fill="%s" rx="2"/>`,
+		x, y, width, flameRowHeight-1, flameColor(node.name)))
+
+	if maxChars := int((width - 6) / flameCharWidth); maxChars >= 3 {
+		label := node.name
+		if len(label) > maxChars {
+			label = label[:maxChars-2] + ".."
+		}
+		svg.WriteString(fmt.Sprintf(`<text x="%.2f" y="%.2f">%s</text>`, x+3, y+flameRowHeight-5, html.EscapeString(label)))
+	}
+	svg.WriteString("</g>\n")
+
+	childX := x
+	for _, child := range node.sortedChildren() {
+		r.writeFlameNode(svg, child, childX, y-flameRowHeight, scale, total)
+		childX += float64(child.value) * scale
+	}
+}
+
+// flameColor picks a stable warm color for a function name
+func flameColor(name string) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	sum := h.Sum32()
+	red := 205 + sum%50
+	green := 80 + (sum>>8)%150
+	blue := 40 + (sum>>16)%50
+	return fmt.Sprintf("rgb(%d,%d,%d)", red, green, blue)
+}
+
+// SaveFlameGraph writes the flame graph SVG to a file
+func (r *Report) SaveFlameGraph(path, title string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create flame graph file: %w", err)
+	}
+	defer file.Close()
+
+	if err := r.WriteFlameGraph(file, title); err != nil {
+		return fmt.Errorf("failed to write flame graph: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package profiling
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Formatter handles formatting profile reports for display
+type Formatter struct{}
+
+// NewFormatter creates a new report formatter
+func NewFormatter() *Formatter {
+	return &Formatter{}
+}
+
+// FormatReport formats the top functions and, for memory profiles, the top
+// allocation sites
+func (f *Formatter) FormatReport(report *Report) string {
+	var output strings.Builder
+
+	title := "CPU profile"
+	if report.Kind == KindMemory {
+		title = "Memory profile (allocated)"
+	}
+	output.WriteString(fmt.Sprintf("\n%s: %s total\n", title, FormatValue(report.Total, report.Unit)))
+
+	if len(report.Functions) == 0 {
+		output.WriteString("  No samples recorded. Try a longer benchmark (e.g. --count or -benchtime).\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("\nTop %d functions by flat %s:\n", len(report.Functions), f.costName(report)))
+	output.WriteString(fmt.Sprintf("  %12s %7s  %12s %7s  %s\n", "flat", "flat%", "cum", "cum%", "function"))
+	for _, fn := range report.Functions {
+		output.WriteString(fmt.Sprintf("  %12s %6.1f%%  %12s %6.1f%%  %s\n",
+			FormatValue(fn.Flat, report.Unit), percent(fn.Flat, report.Total),
+			FormatValue(fn.Cum, report.Unit), percent(fn.Cum, report.Total),
+			fn.Name))
+	}
+
+	if len(report.AllocSites) > 0 {
+		output.WriteString(fmt.Sprintf("\nTop %d allocation sites:\n", len(report.AllocSites)))
+		output.WriteString(fmt.Sprintf("  %12s %7s  %10s  %s\n", "bytes", "%", "objects", "location"))
+		for _, site := range report.AllocSites {
+			output.WriteString(fmt.Sprintf("  %12s %6.1f%%  %10d  %s:%d (%s)\n",
+				FormatValue(site.Bytes, report.Unit), percent(site.Bytes, report.Total),
+				site.Objects, filepath.Base(site.File), site.Line, site.Function))
+		}
+	}
+
+	return output.String()
+}
+
+// FormatAnnotation formats a source file with the flat and cumulative cost of
+// each line. Lines without cost are shown for context only.
+func (f *Formatter) FormatAnnotation(report *Report, path string, lines []AnnotatedLine) string {
+	var output strings.Builder
+
+	var hasCost bool
+	for _, line := range lines {
+		if line.Cum > 0 {
+			hasCost = true
+			break
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("\nLine-by-line %s for %s:\n", f.costName(report), path))
+	if !hasCost {
+		output.WriteString("  No samples attributed to this file.\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("  %12s  %12s  %5s\n", "flat", "cum", "line"))
+	for _, line := range lines {
+		flat, cum := ".", "."
+		if line.Flat > 0 {
+			flat = FormatValue(line.Flat, report.Unit)
+		}
+		if line.Cum > 0 {
+			cum = FormatValue(line.Cum, report.Unit)
+		}
+		output.WriteString(fmt.Sprintf("  %12s  %12s  %5d  %s\n", flat, cum, line.Number, line.Source))
+	}
+
+	return output.String()
+}
+
+// costName describes what a report's values measure
+func (f *Formatter) costName(report *Report) string {
+	if report.Kind == KindMemory {
+		return "allocations"
+	}
+	return "CPU time"
+}
+
+// FormatValue formats a profile value in a human-readable unit
+func FormatValue(value int64, unit string) string {
+	v := float64(value)
+	switch unit {
+	case "nanoseconds":
+		switch {
+		case v < 1e3:
+			return fmt.Sprintf("%.0fns", v)
+		case v < 1e6:
+			return fmt.Sprintf("%.2fµs", v/1e3)
+		case v < 1e9:
+			return fmt.Sprintf("%.2fms", v/1e6)
+		default:
+			return fmt.Sprintf("%.2fs", v/1e9)
+		}
+	case "bytes":
+		switch {
+		case v < 1024:
+			return fmt.Sprintf("%.0fB", v)
+		case v < 1024*1024:
+			return fmt.Sprintf("%.2fKB", v/1024)
+		case v < 1024*1024*1024:
+			return fmt.Sprintf("%.2fMB", v/(1024*1024))
+		default:
+			return fmt.Sprintf("%.2fGB", v/(1024*1024*1024))
+		}
+	default:
+		return fmt.Sprintf("%d", value)
+	}
+}
+
+// percent returns part as a percentage of total
+func percent(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
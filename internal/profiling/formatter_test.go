@@ -0,0 +1,103 @@
+package profiling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatter_FormatReport(t *testing.T) {
+	formatter := NewFormatter()
+
+	t.Run("formats CPU report", func(t *testing.T) {
+		report := &Report{
+			Kind:  KindCPU,
+			Unit:  "nanoseconds",
+			Total: 100_000_000,
+			Functions: []FunctionStat{
+				{Name: "runtime.mapaccess2_fast64", Flat: 60_000_000, Cum: 60_000_000},
+				{Name: "solutions.TwoSum", Flat: 10_000_000, Cum: 100_000_000},
+			},
+		}
+
+		output := formatter.FormatReport(report)
+
+		assert.Contains(t, output, "CPU profile: 100.00ms total")
+		assert.Contains(t, output, "Top 2 functions by flat CPU time")
+		assert.Contains(t, output, "60.0%")
+		assert.Contains(t, output, "solutions.TwoSum")
+		assert.NotContains(t, output, "allocation sites")
+	})
+
+	t.Run("formats memory report with allocation sites", func(t *testing.T) {
+		report := &Report{
+			Kind:       KindMemory,
+			Unit:       "bytes",
+			Total:      2048,
+			Functions:  []FunctionStat{{Name: "solutions.TwoSum", Flat: 2048, Cum: 2048}},
+			AllocSites: []AllocSite{{Function: "solutions.TwoSum", File: "/work/solutions/two_sum.go", Line: 9, Bytes: 2048, Objects: 3}},
+		}
+
+		output := formatter.FormatReport(report)
+
+		assert.Contains(t, output, "Memory profile (allocated): 2.00KB total")
+		assert.Contains(t, output, "Top 1 allocation sites")
+		assert.Contains(t, output, "two_sum.go:9 (solutions.TwoSum)")
+	})
+
+	t.Run("explains empty profile", func(t *testing.T) {
+		output := formatter.FormatReport(&Report{Kind: KindCPU, Unit: "nanoseconds"})
+
+		assert.Contains(t, output, "No samples recorded")
+	})
+}
+
+func TestFormatter_FormatAnnotation(t *testing.T) {
+	formatter := NewFormatter()
+	report := &Report{Kind: KindCPU, Unit: "nanoseconds"}
+
+	t.Run("shows costs next to source lines", func(t *testing.T) {
+		lines := []AnnotatedLine{
+			{Number: 1, Source: "package solutions"},
+			{Number: 2, Source: "	seen[n] = i", Flat: 1500, Cum: 30_000_000},
+		}
+
+		output := formatter.FormatAnnotation(report, "solutions/two_sum.go", lines)
+
+		assert.Contains(t, output, "Line-by-line CPU time for solutions/two_sum.go")
+		assert.Contains(t, output, "1.50µs")
+		assert.Contains(t, output, "30.00ms")
+		assert.Contains(t, output, "seen[n] = i")
+	})
+
+	t.Run("reports file without samples", func(t *testing.T) {
+		lines := []AnnotatedLine{{Number: 1, Source: "package solutions"}}
+
+		output := formatter.FormatAnnotation(report, "solutions/two_sum.go", lines)
+
+		assert.Contains(t, output, "No samples attributed")
+	})
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		value    int64
+		unit     string
+		expected string
+	}{
+		{500, "nanoseconds", "500ns"},
+		{1500, "nanoseconds", "1.50µs"},
+		{2_500_000, "nanoseconds", "2.50ms"},
+		{3_000_000_000, "nanoseconds", "3.00s"},
+		{512, "bytes", "512B"},
+		{2048, "bytes", "2.00KB"},
+		{3 * 1024 * 1024, "bytes", "3.00MB"},
+		{42, "count", "42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FormatValue(tt.value, tt.unit))
+		})
+	}
+}
@@ -22,6 +22,13 @@ func NewTracker(db *gorm.DB) *Tracker {
 // It creates/updates Progress and Solution records atomically in a transaction.
 // Returns true if this is the first time the problem was solved, false otherwise.
 func (t *Tracker) TrackTestCompletion(problemID uint, filePath string, passed bool, testsPassed, testsTotal int) (bool, error) {
+	return t.TrackVariantTestCompletion(problemID, "", filePath, passed, testsPassed, testsTotal)
+}
+
+// TrackVariantTestCompletion records test results for a named variant of a
+// problem's solution. The problem counts as solved once any variant passes;
+// the Solution record keeps the variant so solved approaches can be listed.
+func (t *Tracker) TrackVariantTestCompletion(problemID uint, variant, filePath string, passed bool, testsPassed, testsTotal int) (bool, error) {
 	var isFirstTimeSolve bool
 
 	err := t.db.Transaction(func(tx *gorm.DB) error {
@@ -75,6 +82,7 @@ func (t *Tracker) TrackTestCompletion(problemID uint, filePath string, passed bo
 			Status:      status,
 			TestsPassed: testsPassed,
 			TestsTotal:  testsTotal,
+			Variant:     variant,
 		}
 
 		err = tx.Create(solution).Error
@@ -91,3 +99,19 @@ func (t *Tracker) TrackTestCompletion(problemID uint, filePath string, passed bo
 
 	return isFirstTimeSolve, nil
 }
+
+// RecordCoverage stores the coverage of the solution on the latest Solution
+// record of a problem's variant, the one created for the test run that
+// measured it
+func (t *Tracker) RecordCoverage(problemID uint, variant string, coverage float64) error {
+	var solution database.Solution
+	err := t.db.Where("problem_id = ? AND variant = ?", problemID, variant).
+		Order("id DESC").First(&solution).Error
+	if err != nil {
+		return fmt.Errorf("failed to find solution: %w", err)
+	}
+	if err := t.db.Model(&solution).Update("coverage", coverage).Error; err != nil {
+		return fmt.Errorf("failed to store coverage: %w", err)
+	}
+	return nil
+}
@@ -205,5 +205,59 @@ func TestTrackTestCompletion_TransactionRollback(t *testing.T) {
 	assert.Equal(t, int64(0), solutionCount)
 }
 
+func TestTrackVariantTestCompletion_RecordsVariant(t *testing.T) {
+	db := setupTestDB(t)
+	tracker := NewTracker(db)
+
+	problem := &database.Problem{Slug: "two-sum", Title: "Two Sum", Difficulty: "easy", Topic: "arrays"}
+	require.NoError(t, db.Create(problem).Error)
+
+	isFirstSolve, err := tracker.TrackTestCompletion(problem.ID, "solutions/two-sum.go", true, 5, 5)
+	require.NoError(t, err)
+	assert.True(t, isFirstSolve)
+
+	// Solving another approach is tracked but is not a first solve
+	isFirstSolve, err = tracker.TrackVariantTestCompletion(problem.ID, "hashmap", "solutions/variants/hashmap/two-sum.go", true, 5, 5)
+	require.NoError(t, err)
+	assert.False(t, isFirstSolve)
+
+	var solutions []database.Solution
+	require.NoError(t, db.Order("id").Find(&solutions, "problem_id = ?", problem.ID).Error)
+	require.Len(t, solutions, 2)
+	assert.Equal(t, "", solutions[0].Variant)
+	assert.Equal(t, "hashmap", solutions[1].Variant)
+
+	var progress database.Progress
+	require.NoError(t, db.First(&progress, "problem_id = ?", problem.ID).Error)
+	assert.Equal(t, 2, progress.TotalAttempts)
+}
+
 // Note: Concurrent test removed - SQLite has limited concurrency support due to database-level locking.
 // In the CLI context, test executions are sequential (one at a time), so concurrent access isn't a real-world scenario.
+
+func TestRecordCoverage(t *testing.T) {
+	db := setupTestDB(t)
+	tracker := NewTracker(db)
+
+	problem := &database.Problem{Slug: "two-sum", Title: "Two Sum", Difficulty: "easy", Topic: "arrays"}
+	require.NoError(t, db.Create(problem).Error)
+
+	_, err := tracker.TrackTestCompletion(problem.ID, "solutions/two_sum.go", true, 5, 5)
+	require.NoError(t, err)
+	_, err = tracker.TrackVariantTestCompletion(problem.ID, "hashmap", "solutions/variants/hashmap/two_sum.go", true, 5, 5)
+	require.NoError(t, err)
+	_, err = tracker.TrackTestCompletion(problem.ID, "solutions/two_sum.go", true, 5, 5)
+	require.NoError(t, err)
+
+	require.NoError(t, tracker.RecordCoverage(problem.ID, "", 87.5))
+
+	var solutions []database.Solution
+	require.NoError(t, db.Order("id").Find(&solutions, "problem_id = ?", problem.ID).Error)
+	require.Len(t, solutions, 3)
+	assert.Nil(t, solutions[0].Coverage, "earlier runs keep their coverage")
+	assert.Nil(t, solutions[1].Coverage, "other variants keep their coverage")
+	require.NotNil(t, solutions[2].Coverage)
+	assert.Equal(t, 87.5, *solutions[2].Coverage)
+
+	assert.Error(t, tracker.RecordCoverage(problem.ID, "missing", 50))
+}
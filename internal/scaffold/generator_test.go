@@ -10,26 +10,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestSlugToFunctionName(t *testing.T) {
-	tests := []struct {
-		name string
-		slug string
-		want string
-	}{
-		{"simple slug", "two-sum", "TwoSum"},
-		{"multiple words", "binary-search-tree", "BinarySearchTree"},
-		{"with numbers", "3sum", "3sum"},
-		{"single word", "arrays", "Arrays"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := slugToFunctionName(tt.slug)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestGenerateBoilerplate(t *testing.T) {
 	// Create temporary directory for test
 	tmpDir := t.TempDir()
@@ -0,0 +1,320 @@
+package snippet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InsertResult describes what inserting a snippet changed
+type InsertResult struct {
+	Imports []string // Import paths added to the file
+	Added   []string // Declarations added, e.g. "UnionFind" or "(UnionFind).Find"
+	Skipped []string // Declarations the file already had
+}
+
+// Changed reports whether the insertion modified the file
+func (r *InsertResult) Changed() bool {
+	return len(r.Imports) > 0 || len(r.Added) > 0
+}
+
+// InsertIntoFile inserts a snippet into the Go file at path; see Insert. The
+// file is left untouched when another file of its package already declares
+// one of the snippet's names, since the package would no longer compile.
+func InsertIntoFile(path string, snippet *Snippet) (*InsertResult, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	updated, result, err := Insert(string(source), snippet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert snippet %s into %s: %w", snippet.Name, path, err)
+	}
+	if !result.Changed() {
+		return result, nil
+	}
+	if err := checkPackageConflicts(path, updated, result.Added); err != nil {
+		return nil, fmt.Errorf("cannot insert snippet %s into %s: %w", snippet.Name, path, err)
+	}
+
+	if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return result, nil
+}
+
+// Insert adds a snippet's declarations to the end of a Go file and merges its
+// imports into the file's imports. Declarations whose name the file already
+// declares are skipped, so inserting twice changes nothing. Returns the
+// formatted source.
+func Insert(source string, snippet *Snippet) (string, *InsertResult, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "solution.go", source, parser.ParseComments)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse file: %w", err)
+	}
+	snippetFset := token.NewFileSet()
+	snippetFile, err := parser.ParseFile(snippetFset, snippet.Name+".go", snippet.Code, parser.ParseComments)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse snippet: %w", err)
+	}
+
+	result := &InsertResult{}
+
+	// Declarations missing from the file, in snippet order
+	declared := declaredNames(file)
+	var added strings.Builder
+	lastLine := -1                // Snippet line the previous added declaration ends on
+	used := make(map[string]bool) // Package names the added declarations refer to
+	for _, decl := range snippetFile.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			continue
+		}
+		names := declNames(decl)
+		if len(names) > 0 && allDeclared(names, declared) {
+			result.Skipped = append(result.Skipped, names...)
+			continue
+		}
+		result.Added = append(result.Added, names...)
+
+		// Keep declarations that were adjacent in the snippet together
+		start, end := declRange(decl)
+		if lastLine < 0 || snippetFset.Position(start).Line > lastLine+1 {
+			added.WriteString("\n")
+		}
+		added.WriteString(snippet.Code[snippetFset.Position(start).Offset:snippetFset.Position(end).Offset] + "\n")
+		lastLine = snippetFset.Position(end).Line
+		ast.Inspect(decl, func(node ast.Node) bool {
+			if selector, ok := node.(*ast.SelectorExpr); ok {
+				if ident, ok := selector.X.(*ast.Ident); ok {
+					used[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+
+	// Imports the added declarations use that the file lacks
+	existing := make(map[string]bool)
+	for _, spec := range file.Imports {
+		existing[importKey(spec)] = true
+	}
+	for _, spec := range snippetFile.Imports {
+		if !existing[importKey(spec)] && used[importName(spec)] {
+			existing[importKey(spec)] = true
+			result.Imports = append(result.Imports, importLine(spec))
+		}
+	}
+	sort.Strings(result.Imports)
+
+	if !result.Changed() {
+		return source, result, nil
+	}
+
+	updated := addImports(fset, file, source, result.Imports)
+	updated = strings.TrimRight(updated, "\n") + "\n" + added.String()
+
+	formatted, err := format.Source([]byte(updated))
+	if err != nil {
+		return "", nil, fmt.Errorf("merged file does not compile: %w", err)
+	}
+
+	for i, line := range result.Imports {
+		result.Imports[i] = importPathOf(line)
+	}
+	return string(formatted), result, nil
+}
+
+// checkPackageConflicts returns an error naming the first of names that
+// another Go file in the directory of path declares in the same package
+func checkPackageConflicts(path, source string, names []string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), path, source, parser.PackageClauseOnly)
+	if err != nil {
+		return fmt.Errorf("failed to parse file: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	declaredIn := make(map[string]string) // Name -> file declaring it
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || entry.Name() == filepath.Base(path) {
+			continue
+		}
+		other := filepath.Join(dir, entry.Name())
+		parsed, err := parser.ParseFile(token.NewFileSet(), other, nil, parser.SkipObjectResolution)
+		if err != nil || parsed.Name.Name != file.Name.Name {
+			continue // Not part of the package as far as can be told
+		}
+		for name := range declaredNames(parsed) {
+			if _, ok := declaredIn[name]; !ok {
+				declaredIn[name] = other
+			}
+		}
+	}
+
+	for _, name := range names {
+		if other, ok := declaredIn[name]; ok {
+			return fmt.Errorf("%s is already declared in %s, which is in the same package", name, other)
+		}
+	}
+	return nil
+}
+
+// declaredNames returns the names of every top-level declaration of a file
+func declaredNames(file *ast.File) map[string]bool {
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		for _, name := range declNames(decl) {
+			declared[name] = true
+		}
+	}
+	return declared
+}
+
+// declNames returns the names a top-level declaration introduces. Methods are
+// named "(T).M" or "(*T).M" with the pointer dropped for matching.
+func declNames(decl ast.Decl) []string {
+	var names []string
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			names = append(names, "("+receiverType(d.Recv.List[0].Type)+")."+d.Name.Name)
+		} else {
+			names = append(names, d.Name.Name)
+		}
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, s.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					if name.Name != "_" {
+						names = append(names, name.Name)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// receiverType returns the base type name of a method receiver
+func receiverType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverType(t.X)
+	case *ast.IndexExpr:
+		return receiverType(t.X)
+	case *ast.IndexListExpr:
+		return receiverType(t.X)
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
+
+// allDeclared reports whether every name is already declared
+func allDeclared(names []string, declared map[string]bool) bool {
+	for _, name := range names {
+		if !declared[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// declRange returns the extent of a declaration including its doc comment
+func declRange(decl ast.Decl) (token.Pos, token.Pos) {
+	start := decl.Pos()
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Doc != nil {
+			start = d.Doc.Pos()
+		}
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			start = d.Doc.Pos()
+		}
+	}
+	return start, decl.End()
+}
+
+// importKey identifies an import by name and path
+func importKey(spec *ast.ImportSpec) string {
+	name := ""
+	if spec.Name != nil {
+		name = spec.Name.Name
+	}
+	return name + " " + spec.Path.Value
+}
+
+// importName returns the name an import is referred to by, assuming the
+// package name matches the last path element
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	path, _ := strconv.Unquote(spec.Path.Value)
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// importLine renders an import spec as it appears in an import block
+func importLine(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name + " " + spec.Path.Value
+	}
+	return spec.Path.Value
+}
+
+// importPathOf returns the unquoted path of an import line
+func importPathOf(line string) string {
+	fields := strings.Fields(line)
+	path, err := strconv.Unquote(fields[len(fields)-1])
+	if err != nil {
+		return fields[len(fields)-1]
+	}
+	return path
+}
+
+// addImports adds import lines to a file's source: into its parenthesized
+// import block if it has one, else as a new block after its imports or
+// package clause
+func addImports(fset *token.FileSet, file *ast.File, source string, lines []string) string {
+	if len(lines) == 0 {
+		return source
+	}
+
+	var last *ast.GenDecl
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			offset := fset.Position(gen.Rparen).Offset
+			return source[:offset] + "\t" + strings.Join(lines, "\n\t") + "\n" + source[offset:]
+		}
+		last = gen
+	}
+
+	block := "\n\nimport (\n\t" + strings.Join(lines, "\n\t") + "\n)"
+	offset := fset.Position(file.Name.End()).Offset
+	if last != nil {
+		offset = fset.Position(last.End()).Offset
+	}
+	return source[:offset] + block + source[offset:]
+}
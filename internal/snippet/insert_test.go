@@ -0,0 +1,158 @@
+package snippet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getBuiltin returns a built-in snippet
+func getBuiltin(t *testing.T, name string) *Snippet {
+	snippet, err := NewStoreWithDirs("", "").Get(name)
+	require.NoError(t, err)
+	return snippet
+}
+
+func TestInsert_WithoutImports(t *testing.T) {
+	source := "package solutions\n\nfunc TopK(nums []int, k int) []int {\n\treturn nil\n}\n"
+
+	updated, result, err := Insert(source, getBuiltin(t, "min-heap"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"container/heap"}, result.Imports)
+	assert.Contains(t, result.Added, "MinHeap")
+	assert.Contains(t, result.Added, "(MinHeap).Pop")
+	assert.Empty(t, result.Skipped)
+
+	assert.Contains(t, updated, "package solutions\n\nimport (\n\t\"container/heap\"\n)\n\nfunc TopK")
+	assert.Contains(t, updated, "func (h MinHeap) Len() int           { return len(h) }\nfunc (h MinHeap) Less")
+	assert.Contains(t, updated, "// NewMinHeap builds a heap from values\nfunc NewMinHeap")
+	assert.NotContains(t, updated, "package snippets")
+}
+
+func TestInsert_MergesImportBlock(t *testing.T) {
+	source := "package solutions\n\nimport (\n\t\"fmt\"\n)\n\nfunc Solve() { fmt.Println() }\n"
+
+	updated, result, err := Insert(source, getBuiltin(t, "min-heap"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"container/heap"}, result.Imports)
+	assert.Contains(t, updated, "import (\n\t\"container/heap\"\n\t\"fmt\"\n)\n")
+	assert.Equal(t, 1, countOf(updated, "import"))
+}
+
+func TestInsert_AfterSingleImport(t *testing.T) {
+	source := "package solutions\n\nimport \"fmt\"\n\nfunc Solve() { fmt.Println() }\n"
+
+	updated, _, err := Insert(source, getBuiltin(t, "min-heap"))
+	require.NoError(t, err)
+	assert.Contains(t, updated, "import \"fmt\"\n\nimport (\n\t\"container/heap\"\n)\n")
+}
+
+func TestInsert_Twice(t *testing.T) {
+	source := "package solutions\n\nfunc Solve() {}\n"
+	snippet := getBuiltin(t, "union-find")
+
+	once, _, err := Insert(source, snippet)
+	require.NoError(t, err)
+
+	twice, result, err := Insert(once, snippet)
+	require.NoError(t, err)
+	assert.False(t, result.Changed())
+	assert.Equal(t, once, twice)
+	assert.Contains(t, result.Skipped, "UnionFind")
+}
+
+func TestInsert_SkipsExistingDeclarations(t *testing.T) {
+	// The file already has its own heap type; only the constructor is missing
+	source := `package solutions
+
+type MinHeap []int
+
+func (h MinHeap) Len() int           { return len(h) }
+func (h MinHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h MinHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *MinHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *MinHeap) Pop() any          { return nil }
+`
+	updated, result, err := Insert(source, getBuiltin(t, "min-heap"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"NewMinHeap"}, result.Added)
+	assert.Contains(t, result.Skipped, "MinHeap")
+	assert.Contains(t, result.Skipped, "(MinHeap).Push")
+	assert.Equal(t, []string{"container/heap"}, result.Imports)
+	assert.Equal(t, 1, countOf(updated, "type MinHeap"))
+}
+
+func TestInsert_OnlyNeededImports(t *testing.T) {
+	// NewMinHeap is the only user of container/heap
+	source := "package solutions\n\nfunc NewMinHeap(values ...int) []int { return values }\n"
+
+	updated, result, err := Insert(source, getBuiltin(t, "min-heap"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Imports)
+	assert.NotContains(t, updated, `"container/heap"`)
+}
+
+func TestInsert_InvalidFile(t *testing.T) {
+	_, _, err := Insert("package solutions\n\nfunc {", getBuiltin(t, "trie"))
+	assert.ErrorContains(t, err, "failed to parse file")
+}
+
+func TestInsertIntoFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "solution.go")
+	require.NoError(t, os.WriteFile(path, []byte("package solutions\n"), 0644))
+
+	result, err := InsertIntoFile(path, getBuiltin(t, "trie"))
+	require.NoError(t, err)
+	assert.True(t, result.Changed())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "type Trie struct")
+}
+
+func TestInsertIntoFile_DeclaredElsewhereInPackage(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "kth_largest.go")
+	second := filepath.Join(dir, "top_k.go")
+	require.NoError(t, os.WriteFile(first, []byte("package solutions\n"), 0644))
+	require.NoError(t, os.WriteFile(second, []byte("package solutions\n"), 0644))
+
+	_, err := InsertIntoFile(first, getBuiltin(t, "min-heap"))
+	require.NoError(t, err)
+
+	_, err = InsertIntoFile(second, getBuiltin(t, "min-heap"))
+	assert.ErrorContains(t, err, "MinHeap is already declared in "+first)
+
+	content, err := os.ReadFile(second)
+	require.NoError(t, err)
+	assert.Equal(t, "package solutions\n", string(content), "file must be left untouched")
+}
+
+func TestInsertIntoFile_OtherPackageInDirectory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "solution.go")
+	require.NoError(t, os.WriteFile(path, []byte("package solutions\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "solution_test.go"),
+		[]byte("package solutions_test\n\ntype Trie struct{}\n"), 0644))
+
+	result, err := InsertIntoFile(path, getBuiltin(t, "trie"))
+	require.NoError(t, err)
+	assert.Contains(t, result.Added, "Trie")
+}
+
+// countOf counts the occurrences of substr in s
+func countOf(s, substr string) int {
+	count := 0
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			count++
+		}
+	}
+	return count
+}
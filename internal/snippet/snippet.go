@@ -0,0 +1,271 @@
+// Package snippet keeps a library of reusable Go code, such as union-find or
+// a heap, that can be inserted into solution files. Built-in snippets ship
+// with dsa; users add their own to ~/.dsa/snippets (global) or
+// .dsa/snippets (workspace), which override built-ins of the same name.
+package snippet
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+)
+
+//go:embed builtin/*.snippet
+var builtinFS embed.FS
+
+// fileExtension is the extension of snippet files
+const fileExtension = ".snippet"
+
+// Sources of a snippet, from lowest to highest precedence
+const (
+	SourceBuiltin   = "built-in"
+	SourceGlobal    = "global"
+	SourceWorkspace = "workspace"
+)
+
+// ErrSnippetNotFound is returned when no snippet has the requested name
+var ErrSnippetNotFound = errors.New("snippet not found")
+
+// namePattern allows lowercase words joined by hyphens, e.g. "union-find"
+var namePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Snippet is a reusable piece of Go code. Code is a complete Go file; its
+// package clause is ignored on insertion.
+type Snippet struct {
+	Name        string
+	Description string
+	Tags        []string
+	Code        string
+	Source      string // SourceBuiltin, SourceGlobal or SourceWorkspace
+	Path        string // File of a user snippet; empty for built-ins
+}
+
+// Store loads snippets from the built-ins and the user snippet directories
+type Store struct {
+	globalDir    string
+	workspaceDir string
+}
+
+// NewStore creates a store for ~/.dsa/snippets and .dsa/snippets in the
+// workspace
+func NewStore() *Store {
+	globalDir := ""
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		globalDir = filepath.Join(home, ".dsa", "snippets")
+	}
+	return NewStoreWithDirs(globalDir, workspace.Settings("snippets"))
+}
+
+// NewStoreWithDirs creates a store with explicit user directories. An empty
+// directory disables that source.
+func NewStoreWithDirs(globalDir, workspaceDir string) *Store {
+	return &Store{globalDir: globalDir, workspaceDir: workspaceDir}
+}
+
+// ValidateName checks that a snippet name can be used as a file name
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid snippet name '%s': use lowercase letters, digits and hyphens (e.g. union-find)", name)
+	}
+	return nil
+}
+
+// List returns every snippet sorted by name. User snippets replace built-ins
+// of the same name, and workspace snippets replace global ones.
+func (s *Store) List() ([]*Snippet, error) {
+	byName := make(map[string]*Snippet)
+
+	entries, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in snippets: %w", err)
+	}
+	for _, entry := range entries {
+		content, err := builtinFS.ReadFile("builtin/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read built-in snippet %s: %w", entry.Name(), err)
+		}
+		snippet := Parse(strings.TrimSuffix(entry.Name(), fileExtension), string(content))
+		snippet.Source = SourceBuiltin
+		byName[snippet.Name] = snippet
+	}
+
+	for _, dir := range []struct{ path, source string }{{s.globalDir, SourceGlobal}, {s.workspaceDir, SourceWorkspace}} {
+		if err := s.loadDir(dir.path, dir.source, byName); err != nil {
+			return nil, err
+		}
+	}
+
+	snippets := make([]*Snippet, 0, len(byName))
+	for _, snippet := range byName {
+		snippets = append(snippets, snippet)
+	}
+	sort.Slice(snippets, func(i, j int) bool { return snippets[i].Name < snippets[j].Name })
+	return snippets, nil
+}
+
+// loadDir adds the snippets of a user directory, replacing earlier ones
+func (s *Store) loadDir(dir, source string, byName map[string]*Snippet) error {
+	if dir == "" {
+		return nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+fileExtension))
+	if err != nil {
+		return fmt.Errorf("failed to list snippets in %s: %w", dir, err)
+	}
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read snippet %s: %w", path, err)
+		}
+		snippet := Parse(strings.TrimSuffix(filepath.Base(path), fileExtension), string(content))
+		snippet.Source = source
+		snippet.Path = path
+		byName[snippet.Name] = snippet
+	}
+	return nil
+}
+
+// Get returns the snippet with the given name, or ErrSnippetNotFound
+func (s *Store) Get(name string) (*Snippet, error) {
+	snippets, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, snippet := range snippets {
+		if snippet.Name == name {
+			return snippet, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: '%s'. Run 'dsa snippet list' to see available snippets", ErrSnippetNotFound, name)
+}
+
+// Add saves a user snippet in the workspace or global directory and returns
+// its file. code must be Go source; a package clause is added if it has none.
+func (s *Store) Add(name, description string, tags []string, code string, workspace bool) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+
+	dir := s.globalDir
+	if workspace {
+		dir = s.workspaceDir
+	}
+	if dir == "" {
+		return "", fmt.Errorf("no snippet directory available")
+	}
+
+	snippet := &Snippet{Name: name, Description: description, Tags: normalizeTags(tags), Code: withPackageClause(code)}
+	if _, err := parser.ParseFile(token.NewFileSet(), name+".go", snippet.Code, parser.ParseComments); err != nil {
+		return "", fmt.Errorf("snippet is not valid Go: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create snippet directory: %w", err)
+	}
+	path := filepath.Join(dir, name+fileExtension)
+	if err := os.WriteFile(path, []byte(snippet.Format()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write snippet: %w", err)
+	}
+	return path, nil
+}
+
+// Suggest returns the snippets sharing a tag with any of tags, most shared
+// tags first
+func (s *Store) Suggest(tags []string) ([]*Snippet, error) {
+	snippets, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool)
+	for _, tag := range normalizeTags(tags) {
+		wanted[tag] = true
+	}
+
+	shared := make(map[string]int)
+	var matches []*Snippet
+	for _, snippet := range snippets {
+		for _, tag := range snippet.Tags {
+			if wanted[tag] {
+				shared[snippet.Name]++
+			}
+		}
+		if shared[snippet.Name] > 0 {
+			matches = append(matches, snippet)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return shared[matches[i].Name] > shared[matches[j].Name] })
+	return matches, nil
+}
+
+// Parse reads a snippet file: "// Description:" and "// Tags:" comment lines
+// before the package clause, followed by Go source
+func Parse(name, content string) *Snippet {
+	snippet := &Snippet{Name: name}
+
+	lines := strings.SplitAfter(content, "\n")
+	start := 0
+	for ; start < len(lines); start++ {
+		text, isComment := strings.CutPrefix(strings.TrimSpace(lines[start]), "//")
+		if !isComment {
+			break
+		}
+		key, value, _ := strings.Cut(strings.TrimSpace(text), ":")
+		if key == "Description" {
+			snippet.Description = strings.TrimSpace(value)
+		} else if key == "Tags" {
+			snippet.Tags = normalizeTags(strings.Split(value, ","))
+		} else {
+			break // Other comments, such as a package doc, belong to the code
+		}
+	}
+
+	snippet.Code = strings.Join(lines[start:], "")
+	return snippet
+}
+
+// Format renders a snippet as a snippet file
+func (s *Snippet) Format() string {
+	var out strings.Builder
+	if s.Description != "" {
+		fmt.Fprintf(&out, "// Description: %s\n", s.Description)
+	}
+	if len(s.Tags) > 0 {
+		fmt.Fprintf(&out, "// Tags: %s\n", strings.Join(s.Tags, ", "))
+	}
+	out.WriteString(s.Code)
+	return out.String()
+}
+
+// normalizeTags lowercases and trims tags, dropping empty ones and duplicates
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// withPackageClause adds a package clause to code that has none
+func withPackageClause(code string) string {
+	if _, err := parser.ParseFile(token.NewFileSet(), "", code, parser.PackageClauseOnly); err == nil {
+		return code
+	}
+	return "package snippets\n\n" + code
+}
@@ -0,0 +1,99 @@
+package snippet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStore creates a store with empty global and workspace directories
+func newTestStore(t *testing.T) *Store {
+	root := t.TempDir()
+	return NewStoreWithDirs(filepath.Join(root, "global"), filepath.Join(root, "workspace"))
+}
+
+func TestList_Builtins(t *testing.T) {
+	snippets, err := newTestStore(t).List()
+	require.NoError(t, err)
+
+	var names []string
+	for _, snippet := range snippets {
+		names = append(names, snippet.Name)
+		assert.Equal(t, SourceBuiltin, snippet.Source)
+		assert.NotEmpty(t, snippet.Description, snippet.Name)
+		assert.NotEmpty(t, snippet.Tags, snippet.Name)
+		assert.Contains(t, snippet.Code, "package snippets", snippet.Name)
+	}
+	assert.Equal(t, []string{"bfs", "min-heap", "segment-tree", "trie", "union-find"}, names)
+}
+
+func TestList_Precedence(t *testing.T) {
+	store := newTestStore(t)
+
+	_, err := store.Add("trie", "Global trie", []string{"trie"}, "type Trie struct{}\n", false)
+	require.NoError(t, err)
+	snippet, err := store.Get("trie")
+	require.NoError(t, err)
+	assert.Equal(t, SourceGlobal, snippet.Source)
+	assert.Equal(t, "Global trie", snippet.Description)
+
+	path, err := store.Add("trie", "Workspace trie", []string{"trie"}, "type Trie struct{}\n", true)
+	require.NoError(t, err)
+	snippet, err = store.Get("trie")
+	require.NoError(t, err)
+	assert.Equal(t, SourceWorkspace, snippet.Source)
+	assert.Equal(t, path, snippet.Path)
+}
+
+func TestGet_NotFound(t *testing.T) {
+	_, err := newTestStore(t).Get("missing")
+	assert.ErrorIs(t, err, ErrSnippetNotFound)
+}
+
+func TestAdd(t *testing.T) {
+	t.Run("adds a package clause and normalizes tags", func(t *testing.T) {
+		store := newTestStore(t)
+
+		path, err := store.Add("two-pointers", "Two pointers", []string{" Arrays", "arrays", ""}, "func pair() {}\n", false)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "// Description: Two pointers\n// Tags: arrays\npackage snippets\n\nfunc pair() {}\n", string(content))
+	})
+
+	t.Run("rejects invalid Go", func(t *testing.T) {
+		_, err := newTestStore(t).Add("broken", "", nil, "func {", false)
+		assert.ErrorContains(t, err, "not valid Go")
+	})
+
+	t.Run("rejects invalid names", func(t *testing.T) {
+		_, err := newTestStore(t).Add("Union Find", "", nil, "type UF struct{}", false)
+		assert.ErrorContains(t, err, "invalid snippet name")
+	})
+}
+
+func TestSuggest(t *testing.T) {
+	store := newTestStore(t)
+
+	snippets, err := store.Suggest([]string{"Graphs", "topological-sort"})
+	require.NoError(t, err)
+	require.NotEmpty(t, snippets)
+	assert.Equal(t, "bfs", snippets[0].Name) // Shares both tags
+
+	snippets, err = store.Suggest([]string{"dynamic-programming"})
+	require.NoError(t, err)
+	assert.Empty(t, snippets)
+}
+
+func TestParse(t *testing.T) {
+	snippet := Parse("example", "// Description: An example\n// Tags: a, B\n// Package doc\npackage snippets\n")
+
+	assert.Equal(t, "An example", snippet.Description)
+	assert.Equal(t, []string{"a", "b"}, snippet.Tags)
+	assert.Equal(t, "// Package doc\npackage snippets\n", snippet.Code)
+	assert.Equal(t, "// Description: An example\n// Tags: a, b\n// Package doc\npackage snippets\n", snippet.Format())
+}
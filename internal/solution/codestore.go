@@ -0,0 +1,89 @@
+package solution
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCodeNotFound is returned when no stored code matches a hash
+var ErrCodeNotFound = errors.New("stored code not found")
+
+// CodeStore keeps solution code content-addressed: each distinct source is
+// gzip-compressed and stored once, keyed by its SHA-256
+type CodeStore struct {
+	db *gorm.DB
+}
+
+// NewCodeStore creates a new code store
+func NewCodeStore(db *gorm.DB) *CodeStore {
+	return &CodeStore{db: db}
+}
+
+// HashCode returns the SHA-256 of code as a hex string
+func HashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// Put stores code unless identical code is already stored and returns its hash
+func (c *CodeStore) Put(code string) (string, error) {
+	hash := HashCode(code)
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(code)); err != nil {
+		return "", fmt.Errorf("failed to compress code: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to compress code: %w", err)
+	}
+
+	blob := &database.CodeBlob{Hash: hash, Data: buf.Bytes(), Size: len(code)}
+	if err := c.db.Clauses(clause.OnConflict{DoNothing: true}).Create(blob).Error; err != nil {
+		return "", fmt.Errorf("failed to store code: %w", err)
+	}
+
+	return hash, nil
+}
+
+// Get returns the code stored under hash
+func (c *CodeStore) Get(hash string) (string, error) {
+	var blob database.CodeBlob
+	if err := c.db.Where("hash = ?", hash).First(&blob).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return "", ErrCodeNotFound
+		}
+		return "", fmt.Errorf("failed to load stored code: %w", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(blob.Data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress code %s: %w", hash, err)
+	}
+	defer reader.Close()
+
+	code, err := io.ReadAll(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress code %s: %w", hash, err)
+	}
+
+	return string(code), nil
+}
+
+// Has reports whether code with the given hash is stored
+func (c *CodeStore) Has(hash string) (bool, error) {
+	var count int64
+	if err := c.db.Model(&database.CodeBlob{}).Where("hash = ?", hash).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to query stored code: %w", err)
+	}
+	return count > 0, nil
+}
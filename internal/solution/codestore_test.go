@@ -0,0 +1,51 @@
+package solution
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeStore(t *testing.T) {
+	db := setupTestDB(t)
+	store := NewCodeStore(db)
+	code := "package solutions\n\n" + strings.Repeat("// padding that compresses well\n", 100)
+
+	t.Run("stores compressed code by hash", func(t *testing.T) {
+		hash, err := store.Put(code)
+
+		require.NoError(t, err)
+		assert.Equal(t, HashCode(code), hash)
+
+		var blob database.CodeBlob
+		require.NoError(t, db.First(&blob, "hash = ?", hash).Error)
+		assert.Equal(t, len(code), blob.Size)
+		assert.Less(t, len(blob.Data), len(code))
+
+		stored, err := store.Get(hash)
+		require.NoError(t, err)
+		assert.Equal(t, code, stored)
+	})
+
+	t.Run("stores identical code once", func(t *testing.T) {
+		_, err := store.Put(code)
+		require.NoError(t, err)
+
+		var count int64
+		db.Model(&database.CodeBlob{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+
+		has, err := store.Has(HashCode(code))
+		require.NoError(t, err)
+		assert.True(t, has)
+	})
+
+	t.Run("returns ErrCodeNotFound for unknown hash", func(t *testing.T) {
+		_, err := store.Get(HashCode("missing"))
+
+		assert.ErrorIs(t, err, ErrCodeNotFound)
+	})
+}
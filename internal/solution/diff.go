@@ -0,0 +1,152 @@
+package solution
+
+import (
+	"strings"
+)
+
+// DiffOp identifies how a line changed between two versions
+type DiffOp int
+
+const (
+	DiffEqual  DiffOp = iota // Line is present in both versions
+	DiffDelete               // Line is only in the old version
+	DiffInsert               // Line is only in the new version
+)
+
+// DiffLine is one line of a line-based diff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// Hunk is a group of changed lines with surrounding context, using the
+// 1-based line numbers of a unified diff header ("@@ -1,4 +1,5 @@")
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Lines              []DiffLine
+}
+
+// DefaultDiffContext is the number of unchanged lines shown around changes
+const DefaultDiffContext = 3
+
+// Diff compares two sources line by line and groups the changes into
+// unified diff hunks with the given number of context lines. Returns no
+// hunks when the sources are identical.
+func Diff(before, after string, context int) []Hunk {
+	return groupHunks(diffLines(splitLines(before), splitLines(after)), context)
+}
+
+// splitLines splits source into lines, ignoring a trailing newline
+func splitLines(source string) []string {
+	if source == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(source, "\n"), "\n")
+}
+
+// diffLines computes a minimal line diff from the longest common subsequence.
+// Solutions are small, so the quadratic table is not a concern.
+func diffLines(a, b []string) []DiffLine {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]DiffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, DiffLine{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, DiffLine{Op: DiffInsert, Text: b[j]})
+	}
+
+	return lines
+}
+
+// groupHunks splits a diff into hunks, merging changes separated by at most
+// 2*context unchanged lines
+func groupHunks(lines []DiffLine, context int) []Hunk {
+	// Line numbers in each version of lines[i]
+	oldNums := make([]int, len(lines))
+	newNums := make([]int, len(lines))
+	oldLine, newLine := 1, 1
+	for i, line := range lines {
+		oldNums[i], newNums[i] = oldLine, newLine
+		if line.Op != DiffInsert {
+			oldLine++
+		}
+		if line.Op != DiffDelete {
+			newLine++
+		}
+	}
+
+	var hunks []Hunk
+	for i := 0; i < len(lines); i++ {
+		if lines[i].Op == DiffEqual {
+			continue
+		}
+
+		// Extend the hunk while the next change is within reach of the context
+		start := max(i-context, 0)
+		end := i + 1
+		for end < len(lines) {
+			next := end
+			for next < len(lines) && lines[next].Op == DiffEqual {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				break
+			}
+			end = next + 1
+		}
+		end = min(end+context, len(lines))
+
+		hunk := Hunk{OldStart: oldNums[start], NewStart: newNums[start], Lines: lines[start:end]}
+		for _, line := range hunk.Lines {
+			if line.Op != DiffInsert {
+				hunk.OldLines++
+			}
+			if line.Op != DiffDelete {
+				hunk.NewLines++
+			}
+		}
+		// Unified diffs number an empty side by the line before it
+		if hunk.OldLines == 0 {
+			hunk.OldStart--
+		}
+		if hunk.NewLines == 0 {
+			hunk.NewStart--
+		}
+		hunks = append(hunks, hunk)
+
+		i = end - 1
+	}
+
+	return hunks
+}
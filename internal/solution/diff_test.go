@@ -0,0 +1,92 @@
+package solution
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// numberedLines returns n distinct lines
+func numberedLines(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = "line " + string(rune('a'+i))
+	}
+	return lines
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("identical sources have no hunks", func(t *testing.T) {
+		assert.Empty(t, Diff("a\nb\n", "a\nb\n", 3))
+	})
+
+	t.Run("single change with context", func(t *testing.T) {
+		before := "a\nb\nc\nd\ne\n"
+		after := "a\nb\nC\nd\ne\n"
+
+		hunks := Diff(before, after, 1)
+
+		require.Len(t, hunks, 1)
+		assert.Equal(t, Hunk{
+			OldStart: 2, OldLines: 3,
+			NewStart: 2, NewLines: 3,
+			Lines: []DiffLine{
+				{Op: DiffEqual, Text: "b"},
+				{Op: DiffDelete, Text: "c"},
+				{Op: DiffInsert, Text: "C"},
+				{Op: DiffEqual, Text: "d"},
+			},
+		}, hunks[0])
+	})
+
+	t.Run("distant changes get separate hunks", func(t *testing.T) {
+		lines := numberedLines(20)
+		before := strings.Join(lines, "\n")
+		changed := append([]string{}, lines...)
+		changed[1] = "first"
+		changed[17] = "second"
+
+		hunks := Diff(before, strings.Join(changed, "\n"), 3)
+
+		require.Len(t, hunks, 2)
+		assert.Equal(t, 1, hunks[0].OldStart)
+		assert.Equal(t, 5, hunks[0].OldLines)
+		assert.Equal(t, 15, hunks[1].OldStart)
+		assert.Equal(t, 6, hunks[1].OldLines)
+	})
+
+	t.Run("nearby changes share a hunk", func(t *testing.T) {
+		lines := numberedLines(20)
+		changed := append([]string{}, lines...)
+		changed[5] = "first"
+		changed[11] = "second"
+
+		hunks := Diff(strings.Join(lines, "\n"), strings.Join(changed, "\n"), 3)
+
+		require.Len(t, hunks, 1)
+		assert.Equal(t, 3, hunks[0].OldStart)
+		assert.Equal(t, 13, hunks[0].OldLines)
+		assert.Equal(t, 13, hunks[0].NewLines)
+	})
+
+	t.Run("insertions and deletions shift line counts", func(t *testing.T) {
+		hunks := Diff("a\nb\n", "a\nx\ny\nb\n", 3)
+
+		require.Len(t, hunks, 1)
+		assert.Equal(t, 1, hunks[0].OldStart)
+		assert.Equal(t, 2, hunks[0].OldLines)
+		assert.Equal(t, 4, hunks[0].NewLines)
+	})
+
+	t.Run("empty side is numbered by the preceding line", func(t *testing.T) {
+		hunks := Diff("", "a\nb\n", 3)
+
+		require.Len(t, hunks, 1)
+		assert.Equal(t, 0, hunks[0].OldStart)
+		assert.Equal(t, 0, hunks[0].OldLines)
+		assert.Equal(t, 1, hunks[0].NewStart)
+		assert.Equal(t, 2, hunks[0].NewLines)
+	})
+}
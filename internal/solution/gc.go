@@ -0,0 +1,202 @@
+package solution
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+	"gorm.io/gorm"
+)
+
+// DefaultKeepLast is the number of recent submissions kept per problem and
+// variant when no retention policy is given
+const DefaultKeepLast = 10
+
+// RetentionPolicy decides which submissions survive garbage collection.
+// A submission is kept if any rule keeps it.
+type RetentionPolicy struct {
+	KeepLast    int  // Keep the N most recent submissions per problem and variant
+	KeepPassing bool // Keep every passing submission
+}
+
+// GCResult summarizes what garbage collection removed
+type GCResult struct {
+	SubmissionsDeleted int   // Submission rows removed by the retention policy
+	SubmissionsKept    int   // Submission rows left after collection
+	CodeCompacted      int   // Rows whose inline code moved to the code store
+	BlobsDeleted       int   // Stored code no longer referenced by any submission
+	BlobBytesFreed     int64 // Compressed size of deleted stored code
+	FilesRemoved       int   // Legacy history copies already in the code store
+	FileBytesFreed     int64 // Size of removed history copies
+}
+
+// legacyHistoryFile matches the timestamped copies older versions wrote to
+// solutions/history/<slug>/[<variant>/]<timestamp>.go. Backups are not matched.
+var legacyHistoryFile = regexp.MustCompile(`^\d{8}-\d{6}\.go$`)
+
+// gcBatchSize limits the number of IDs bound in one DELETE statement
+const gcBatchSize = 500
+
+// errDryRun rolls back the garbage collection transaction for a dry run
+var errDryRun = errors.New("dry run")
+
+// GarbageCollect applies a retention policy to the submissions of one
+// problem, or of all problems when problemSlug is empty; rows of test runs
+// without code are left alone, and benchmarks of deleted submissions no
+// longer reference them. It then moves
+// remaining inline code to the code store, deletes stored code nothing
+// references and removes legacy history copies whose code is stored; copies
+// of deleted submissions are left alone. A dry run reports the same result
+// without changing anything.
+func (s *Service) GarbageCollect(problemSlug string, problemID uint, policy RetentionPolicy, dryRun bool) (*GCResult, error) {
+	result := &GCResult{}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		codes := NewCodeStore(tx)
+
+		// 1. Apply the retention policy
+		query := tx.Order("problem_id, variant, created_at DESC, id DESC")
+		if problemSlug != "" {
+			query = query.Where("problem_id = ?", problemID)
+		}
+		var solutions []database.Solution
+		if err := query.Find(&solutions).Error; err != nil {
+			return fmt.Errorf("failed to query submissions: %w", err)
+		}
+
+		type series struct {
+			problemID uint
+			variant   string
+		}
+		seen := make(map[series]int)
+		var deleteIDs []uint
+		var kept []database.Solution
+		for _, solution := range solutions {
+			// Test runs record rows without code. They are not submissions,
+			// so they neither count towards KeepLast nor get deleted.
+			if solution.Code == "" && solution.CodeHash == "" {
+				continue
+			}
+			key := series{solution.ProblemID, solution.Variant}
+			seen[key]++
+			passing := solution.Passed || solution.Status == "Passed"
+			if seen[key] <= policy.KeepLast || (policy.KeepPassing && passing) {
+				kept = append(kept, solution)
+				continue
+			}
+			deleteIDs = append(deleteIDs, solution.ID)
+		}
+		for start := 0; start < len(deleteIDs); start += gcBatchSize {
+			batch := deleteIDs[start:min(start+gcBatchSize, len(deleteIDs))]
+			// Benchmarks outlive the submissions they measured
+			if err := tx.Model(&database.BenchmarkResult{}).Where("solution_id IN ?", batch).Update("solution_id", nil).Error; err != nil {
+				return fmt.Errorf("failed to unlink benchmarks: %w", err)
+			}
+			if err := tx.Delete(&database.Solution{}, batch).Error; err != nil {
+				return fmt.Errorf("failed to delete submissions: %w", err)
+			}
+		}
+		result.SubmissionsDeleted = len(deleteIDs)
+		result.SubmissionsKept = len(kept)
+
+		// 2. Move inline code of older submissions to the code store
+		for _, solution := range kept {
+			if solution.Code == "" {
+				continue
+			}
+			hash, err := codes.Put(solution.Code)
+			if err != nil {
+				return err
+			}
+			updates := map[string]interface{}{"code": "", "code_hash": hash}
+			if err := tx.Model(&database.Solution{}).Where("id = ?", solution.ID).Updates(updates).Error; err != nil {
+				return fmt.Errorf("failed to compact submission %d: %w", solution.ID, err)
+			}
+			result.CodeCompacted++
+		}
+
+		// 3. Delete stored code no submission references
+		var orphans []database.CodeBlob
+		err := tx.Where("hash NOT IN (?)", tx.Model(&database.Solution{}).Where("code_hash <> ''").Select("code_hash")).
+			Find(&orphans).Error
+		if err != nil {
+			return fmt.Errorf("failed to query unreferenced code: %w", err)
+		}
+		for _, blob := range orphans {
+			if err := tx.Delete(&database.CodeBlob{}, "hash = ?", blob.Hash).Error; err != nil {
+				return fmt.Errorf("failed to delete stored code: %w", err)
+			}
+			result.BlobsDeleted++
+			result.BlobBytesFreed += int64(len(blob.Data))
+		}
+
+		// 4. Remove legacy history copies that the code store now holds
+		historyDir := workspace.Solutions("history")
+		if problemSlug != "" {
+			historyDir = filepath.Join(historyDir, problemSlug)
+		}
+		if err := removeStoredHistoryCopies(codes, historyDir, dryRun, result); err != nil {
+			return err
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+
+	// Reclaim the space of deleted rows
+	if !dryRun && (result.SubmissionsDeleted > 0 || result.CodeCompacted > 0 || result.BlobsDeleted > 0) {
+		if err := s.db.Exec("VACUUM").Error; err != nil {
+			return nil, fmt.Errorf("failed to vacuum database: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// removeStoredHistoryCopies deletes timestamped history copies under dir
+// whose exact content is in the code store
+func removeStoredHistoryCopies(codes *CodeStore, dir string, dryRun bool, result *GCResult) error {
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() || !legacyHistoryFile.MatchString(entry.Name()) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		stored, err := codes.Has(HashCode(string(content)))
+		if err != nil || !stored {
+			return err
+		}
+
+		if !dryRun {
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+		result.FilesRemoved++
+		result.FileBytesFreed += int64(len(content))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clean history directory: %w", err)
+	}
+	return nil
+}
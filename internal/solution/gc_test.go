@@ -0,0 +1,164 @@
+package solution
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedSubmissions stores n submissions of a problem and variant, oldest first,
+// with distinct code; passing lists the 0-based indexes that passed
+func seedSubmissions(t *testing.T, db *gorm.DB, problemID uint, variant string, n int, passing ...int) {
+	codes := NewCodeStore(db)
+	base := time.Now().Add(-time.Duration(n) * time.Hour)
+	for i := 0; i < n; i++ {
+		hash, err := codes.Put(fmt.Sprintf("package solutions // %d %s %d", problemID, variant, i))
+		require.NoError(t, err)
+		solution := database.Solution{ProblemID: problemID, Variant: variant, CodeHash: hash, CreatedAt: base.Add(time.Duration(i) * time.Hour)}
+		for _, p := range passing {
+			solution.Passed = solution.Passed || p == i
+		}
+		require.NoError(t, db.Create(&solution).Error)
+	}
+}
+
+func countRows(t *testing.T, db *gorm.DB, model interface{}) int64 {
+	var count int64
+	require.NoError(t, db.Model(model).Count(&count).Error)
+	return count
+}
+
+func TestGarbageCollect(t *testing.T) {
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	t.Run("keeps last N per problem and variant plus passing", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+		seedSubmissions(t, db, 1, "", 6, 0)
+		seedSubmissions(t, db, 1, "hashmap", 3)
+		seedSubmissions(t, db, 2, "", 4)
+
+		result, err := svc.GarbageCollect("", 0, RetentionPolicy{KeepLast: 2, KeepPassing: true}, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3+1+2, result.SubmissionsDeleted)
+		assert.Equal(t, 3+2+2, result.SubmissionsKept)
+		assert.Equal(t, 6, result.BlobsDeleted)
+		assert.Equal(t, int64(7), countRows(t, db, &database.Solution{}))
+		assert.Equal(t, int64(7), countRows(t, db, &database.CodeBlob{}))
+
+		// The oldest submission passed and survives
+		history, err := svc.GetVariantHistory(1, "")
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.True(t, history[2].Passed)
+		assert.Equal(t, "package solutions // 1  0", history[2].Code)
+	})
+
+	t.Run("test runs without code do not count towards keep-last", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+		seedSubmissions(t, db, 1, "", 3)
+		for i := 0; i < 5; i++ {
+			require.NoError(t, db.Create(&database.Solution{ProblemID: 1, Status: "Failed", CreatedAt: time.Now().Add(time.Duration(i) * time.Minute)}).Error)
+		}
+
+		result, err := svc.GarbageCollect("", 0, RetentionPolicy{KeepLast: 2}, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.SubmissionsDeleted, "only the oldest submission falls out of the window")
+		assert.Equal(t, 2, result.SubmissionsKept)
+		assert.Equal(t, int64(2+5), countRows(t, db, &database.Solution{}), "test run rows are left alone")
+	})
+
+	t.Run("unlinks benchmarks of deleted submissions", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+		seedSubmissions(t, db, 1, "", 2)
+		var oldest database.Solution
+		require.NoError(t, db.Order("created_at").First(&oldest).Error)
+		benchmark := database.BenchmarkResult{ProblemID: 1, SolutionID: &oldest.ID, Name: "BenchmarkTwoSum", NsPerOp: 10}
+		require.NoError(t, db.Create(&benchmark).Error)
+
+		_, err := svc.GarbageCollect("", 0, RetentionPolicy{KeepLast: 1}, false)
+
+		require.NoError(t, err)
+		require.NoError(t, db.First(&benchmark, benchmark.ID).Error)
+		assert.Nil(t, benchmark.SolutionID)
+	})
+
+	t.Run("limits collection to one problem", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+		seedSubmissions(t, db, 1, "", 4)
+		seedSubmissions(t, db, 2, "", 4)
+
+		result, err := svc.GarbageCollect("two-sum", 1, RetentionPolicy{KeepLast: 1}, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.SubmissionsDeleted)
+		assert.Equal(t, int64(5), countRows(t, db, &database.Solution{}))
+	})
+
+	t.Run("dry run changes nothing", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+		seedSubmissions(t, db, 1, "", 5)
+
+		result, err := svc.GarbageCollect("", 0, RetentionPolicy{KeepLast: 1}, true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.SubmissionsDeleted)
+		assert.Equal(t, 4, result.BlobsDeleted)
+		assert.Equal(t, int64(5), countRows(t, db, &database.Solution{}))
+		assert.Equal(t, int64(5), countRows(t, db, &database.CodeBlob{}))
+	})
+
+	t.Run("compacts inline code and removes stored history copies", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+		require.NoError(t, db.Create(&database.Solution{ProblemID: 1, Code: "legacy code", Passed: true}).Error)
+
+		historyDir := filepath.Join("solutions", "history", "two-sum")
+		require.NoError(t, os.MkdirAll(historyDir, 0755))
+		stored := filepath.Join(historyDir, "20250101-120000.go")
+		unknown := filepath.Join(historyDir, "20250101-130000.go")
+		backup := filepath.Join(historyDir, "backup-20250101-140000.go")
+		require.NoError(t, os.WriteFile(stored, []byte("legacy code"), 0644))
+		require.NoError(t, os.WriteFile(unknown, []byte("pruned code"), 0644))
+		require.NoError(t, os.WriteFile(backup, []byte("legacy code"), 0644))
+
+		result, err := svc.GarbageCollect("", 0, RetentionPolicy{KeepLast: DefaultKeepLast, KeepPassing: true}, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.CodeCompacted)
+		assert.Equal(t, 1, result.FilesRemoved)
+		assert.Equal(t, int64(len("legacy code")), result.FileBytesFreed)
+
+		var solution database.Solution
+		require.NoError(t, db.First(&solution).Error)
+		assert.Empty(t, solution.Code)
+		assert.Equal(t, HashCode("legacy code"), solution.CodeHash)
+
+		_, err = os.Stat(stored)
+		assert.True(t, os.IsNotExist(err))
+		_, err = os.Stat(unknown)
+		assert.NoError(t, err)
+		_, err = os.Stat(backup)
+		assert.NoError(t, err)
+
+		history, err := svc.GetHistory(1)
+		require.NoError(t, err)
+		assert.Equal(t, "legacy code", history[0].Code)
+	})
+}
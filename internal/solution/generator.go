@@ -6,34 +6,49 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"text/template"
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
-	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/templates"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 )
 
 // Generator handles solution file generation
 type Generator struct {
-	solutionsDir string
+	templates *templates.Loader
 }
 
-// NewGenerator creates a new solution file generator
+// NewGenerator creates a new solution file generator using the solution
+// template, which users can override (see 'dsa template')
 func NewGenerator() *Generator {
 	return &Generator{
-		solutionsDir: "solutions",
+		templates: templates.NewLoader(),
 	}
 }
 
 // GenerateSolution creates a solution file for the problem
 func (g *Generator) GenerateSolution(p *database.Problem, force bool) (string, error) {
-	// Ensure solutions directory exists
-	if err := os.MkdirAll(g.solutionsDir, 0755); err != nil {
-		return "", fmt.Errorf("create solutions directory: %w", err)
+	return g.generate(p, workspace.ProblemLayout(p.Slug).Solution(), "", force)
+}
+
+// GenerateVariant creates the solution file for a named variant at
+// solutions/variants/<variant>/<name>.go
+func (g *Generator) GenerateVariant(p *database.Problem, variant string, force bool) (string, error) {
+	return g.generate(p, workspace.ProblemLayout(p.Slug).Variant(variant), variant, force)
+}
+
+// generate renders the solution template to filePath, backing up and
+// confirming before overwriting an existing file
+func (g *Generator) generate(p *database.Problem, filePath, variant string, force bool) (string, error) {
+	// Parse the template first so a broken override changes nothing
+	t, err := g.templates.Parse(templates.Solution, nil)
+	if err != nil {
+		return "", err
 	}
 
-	// Generate file path
-	fileName := problem.SlugToSnakeCase(p.Slug) + ".go"
-	filePath := filepath.Join(g.solutionsDir, fileName)
+	// Ensure solution directory exists
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", fmt.Errorf("create solutions directory: %w", err)
+	}
 
 	// Check if file exists
 	if _, err := os.Stat(filePath); err == nil {
@@ -53,32 +68,11 @@ func (g *Generator) GenerateSolution(p *database.Problem, force bool) (string, e
 		fmt.Printf("✓ Backup created: %s\n", backupPath)
 	}
 
-	// Generate function name
-	funcName := slugToFunctionName(p.Slug)
-
 	// Prepare template data
-	data := struct {
-		FunctionName string
-		ProblemTitle string
-		Description  string
-		Difficulty   string
-		Topic        string
-		Slug         string
-	}{
-		FunctionName: funcName,
-		ProblemTitle: p.Title,
-		Description:  p.Description,
-		Difficulty:   p.Difficulty,
-		Topic:        p.Topic,
-		Slug:         p.Slug,
-	}
+	data := templates.NewData(p)
+	data.Variant = variant
 
 	// Execute template
-	t, err := template.New("solution").Parse(solutionTemplate)
-	if err != nil {
-		return "", fmt.Errorf("parse template: %w", err)
-	}
-
 	file, err := os.Create(filePath)
 	if err != nil {
 		return "", fmt.Errorf("create file: %w", err)
@@ -112,36 +106,18 @@ func copyFile(src, dst string) error {
 	return os.WriteFile(dst, input, 0644)
 }
 
-// slugToFunctionName converts "two-sum" -> "TwoSum"
-func slugToFunctionName(slug string) string {
-	parts := strings.Split(slug, "-")
-	for i, part := range parts {
-		if len(part) > 0 {
-			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+// TodoLine returns the line and column of the first TODO comment in a file,
+// where a freshly generated stub expects the solution, or 0, 0 if there is
+// none
+func TodoLine(filePath string) (int, int) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, 0
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		if col := strings.Index(line, "// TODO"); col >= 0 {
+			return i + 1, col + 1
 		}
 	}
-	return strings.Join(parts, "")
-}
-
-const solutionTemplate = `package solutions
-
-// {{.ProblemTitle}}
-// Difficulty: {{.Difficulty}}
-// Topic: {{.Topic}}
-//
-// Description:
-// {{.Description}}
-//
-// Run tests: dsa test {{.Slug}}
-
-// {{.FunctionName}} solves the {{.ProblemTitle}} problem
-func {{.FunctionName}}() {
-	// TODO: Implement your solution here
-	//
-	// Hints:
-	// - Read the problem description above carefully
-	// - Consider edge cases (empty inputs, single elements, etc.)
-	// - Test your solution with: dsa test {{.Slug}}
-	// - Run benchmarks with: dsa bench {{.Slug}}
+	return 0, 0
 }
-`
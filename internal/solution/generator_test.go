@@ -9,26 +9,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestSlugToFunctionName(t *testing.T) {
-	tests := []struct {
-		name string
-		slug string
-		want string
-	}{
-		{"simple slug", "two-sum", "TwoSum"},
-		{"multiple words", "binary-search-tree", "BinarySearchTree"},
-		{"with numbers", "3sum", "3sum"},
-		{"single word", "arrays", "Arrays"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := slugToFunctionName(tt.slug)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestGenerateSolution(t *testing.T) {
 	// Create temporary directory for test
 	tmpDir := t.TempDir()
@@ -149,3 +129,84 @@ func TestGenerateSolutionCreatesDirectory(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, info.IsDir())
 }
+
+func TestGenerateVariant(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalDir)
+
+	generator := NewGenerator()
+	problem := &database.Problem{
+		ID:         1,
+		Slug:       "two-sum",
+		Title:      "Two Sum",
+		Difficulty: "easy",
+		Topic:      "arrays",
+	}
+
+	filePath, err := generator.GenerateVariant(problem, "hashmap", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("solutions", "variants", "hashmap", "two_sum.go"), filePath)
+
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	contentStr := string(content)
+	assert.Contains(t, contentStr, "// Variant: hashmap")
+	assert.Contains(t, contentStr, "dsa test two-sum --variant hashmap")
+	assert.Contains(t, contentStr, "func TwoSum()")
+
+	// The default solution is untouched
+	_, err = os.Stat(filepath.Join("solutions", "two_sum.go"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestGenerateSolution_TemplateOverride(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	originalDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalDir)
+
+	// Workspace template with a complexity header
+	os.MkdirAll(filepath.Join(".dsa", "templates"), 0755)
+	override := "package solutions\n\n// Time: {{.TargetComplexity}}\n{{.Signature}} {}\n"
+	os.WriteFile(filepath.Join(".dsa", "templates", "solution.tmpl"), []byte(override), 0644)
+
+	problem := &database.Problem{Slug: "two-sum", Title: "Two Sum", TargetComplexity: "O(n)"}
+	filePath, err := NewGenerator().GenerateSolution(problem, true)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "package solutions\n\n// Time: O(n)\nfunc TwoSum() {}\n", string(content))
+}
+
+func TestTodoLine(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("finds the TODO of a generated stub", func(t *testing.T) {
+		path := filepath.Join(dir, "stub.go")
+		os.WriteFile(path, []byte("package solutions\n\nfunc TwoSum() {\n\t// TODO: Implement your solution here\n}\n"), 0644)
+
+		line, col := TodoLine(path)
+		assert.Equal(t, 4, line)
+		assert.Equal(t, 2, col)
+	})
+
+	t.Run("returns zero without a TODO", func(t *testing.T) {
+		path := filepath.Join(dir, "solved.go")
+		os.WriteFile(path, []byte("package solutions\n\nfunc TwoSum() {}\n"), 0644)
+
+		line, col := TodoLine(path)
+		assert.Zero(t, line)
+		assert.Zero(t, col)
+	})
+
+	t.Run("returns zero for a missing file", func(t *testing.T) {
+		line, col := TodoLine(filepath.Join(dir, "missing.go"))
+		assert.Zero(t, line)
+		assert.Zero(t, col)
+	})
+}
@@ -0,0 +1,233 @@
+package solution
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/gitstore"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+)
+
+// gitIgnored keeps local backups out of the solutions repository
+var gitIgnored = []string{"history/"}
+
+// Verdicts recorded in submission commits
+const (
+	verdictPassed = "passed"
+	verdictFailed = "failed"
+)
+
+// SolutionsRepo returns the git repository of the workspace's solutions
+// directory. It exists only once git mode has committed a submission.
+func SolutionsRepo() *gitstore.Repo {
+	return gitstore.NewRepo(workspace.Solutions())
+}
+
+// GitHistory reads the submissions of one problem from the commits of the
+// solutions repository. Every submission is a commit whose message carries
+// the problem, variant, verdict, test counts and saved benchmarks as
+// "Key: value" lines; see SubmissionMessage.
+type GitHistory struct {
+	repo *gitstore.Repo
+	slug string
+}
+
+// NewGitHistory creates the git-backed history of a problem
+func NewGitHistory(repo *gitstore.Repo, problemSlug string) *GitHistory {
+	return &GitHistory{repo: repo, slug: problemSlug}
+}
+
+// CommitSubmission records a submission as a commit of its solution file,
+// creating the repository on first use. benchmarks are the saved results of
+// the submitted code and may be empty. Returns the commit hash.
+func CommitSubmission(repo *gitstore.Repo, problemSlug string, record *SubmissionRecord, benchmarks []database.BenchmarkResult) (string, error) {
+	paths := make([]string, 0, 2)
+	if !repo.Exists() {
+		if err := repo.Init(gitIgnored...); err != nil {
+			return "", fmt.Errorf("failed to initialize solutions repository: %w", err)
+		}
+		paths = append(paths, ".gitignore")
+	}
+
+	path, err := repoPath(repo, problemSlug, record.Variant)
+	if err != nil {
+		return "", err
+	}
+	paths = append(paths, path)
+
+	hash, err := repo.Commit(SubmissionMessage(problemSlug, record, benchmarks), paths...)
+	if err != nil {
+		return "", fmt.Errorf("failed to commit submission: %w", err)
+	}
+	return hash, nil
+}
+
+// SubmissionMessage builds the commit message of a submission, e.g.
+//
+//	two-sum (hashmap): passed 5/5 tests
+//
+//	Problem: two-sum
+//	Variant: hashmap
+//	Verdict: passed
+//	Tests: 5/5
+//	Submission: 42
+//	Bench: BenchmarkTwoSum 1520 ns/op 64 B/op 2 allocs/op
+func SubmissionMessage(problemSlug string, record *SubmissionRecord, benchmarks []database.BenchmarkResult) string {
+	verdict := verdictFailed
+	if record.Passed {
+		verdict = verdictPassed
+	}
+
+	var message strings.Builder
+	subject := problemSlug
+	if record.Variant != "" {
+		subject += " (" + record.Variant + ")"
+	}
+	fmt.Fprintf(&message, "%s: %s %d/%d tests\n\n", subject, verdict, record.TestsPassed, record.TestsTotal)
+
+	fmt.Fprintf(&message, "Problem: %s\n", problemSlug)
+	fmt.Fprintf(&message, "Variant: %s\n", VariantLabel(record.Variant))
+	fmt.Fprintf(&message, "Verdict: %s\n", verdict)
+	fmt.Fprintf(&message, "Tests: %d/%d\n", record.TestsPassed, record.TestsTotal)
+	if record.ID != 0 {
+		fmt.Fprintf(&message, "Submission: %d\n", record.ID)
+	}
+	for _, benchmark := range benchmarks {
+		fmt.Fprintf(&message, "Bench: %s %s ns/op %s B/op %s allocs/op\n", benchmark.Name,
+			strconv.FormatFloat(benchmark.NsPerOp, 'f', -1, 64),
+			strconv.FormatFloat(benchmark.BytesPerOp, 'f', -1, 64),
+			strconv.FormatFloat(benchmark.AllocsPerOp, 'f', -1, 64))
+	}
+
+	return message.String()
+}
+
+// List returns the problem's submission commits, most recent first
+func (h *GitHistory) List(variant *string) ([]SubmissionRecord, error) {
+	commits, err := h.repo.Log("^Problem: " + regexp.QuoteMeta(h.slug) + "$")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+
+	records := make([]SubmissionRecord, 0, len(commits))
+	for _, commit := range commits {
+		record := commitRecord(&commit)
+		if variant != nil && record.Variant != *variant {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Get returns the submission commit at a 1-based index with its code
+func (h *GitHistory) Get(variant *string, index int) (*SubmissionRecord, error) {
+	if index < 1 {
+		return nil, fmt.Errorf("index must be >= 1")
+	}
+
+	records, err := h.List(variant)
+	if err != nil {
+		return nil, err
+	}
+	if index > len(records) {
+		return nil, fmt.Errorf("submission #%d not found", index)
+	}
+
+	record := records[index-1]
+	if err := h.loadCode(&record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// FindByCode returns the most recent submission commit with exactly this code
+func (h *GitHistory) FindByCode(code string) (*SubmissionRecord, error) {
+	records, err := h.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		if err := h.loadCode(&record); err != nil {
+			continue
+		}
+		if record.Code == code {
+			return &record, nil
+		}
+	}
+	return nil, ErrNoSubmission
+}
+
+// Restore checks the submitted file out of its commit
+func (h *GitHistory) Restore(record *SubmissionRecord) error {
+	if record.Commit == "" {
+		return fmt.Errorf("submission has no git commit")
+	}
+
+	path, err := repoPath(h.repo, h.slug, record.Variant)
+	if err != nil {
+		return err
+	}
+	if err := h.repo.Checkout(record.Commit, path); err != nil {
+		return fmt.Errorf("failed to restore solution: %w", err)
+	}
+	return nil
+}
+
+// loadCode reads the submitted file from a record's commit
+func (h *GitHistory) loadCode(record *SubmissionRecord) error {
+	path, err := repoPath(h.repo, h.slug, record.Variant)
+	if err != nil {
+		return err
+	}
+
+	code, err := h.repo.Show(record.Commit, path)
+	if err != nil {
+		return fmt.Errorf("failed to read submission %s: %w", ShortCommit(record.Commit), err)
+	}
+	record.Code = code
+	return nil
+}
+
+// commitRecord converts a submission commit to a SubmissionRecord without code
+func commitRecord(commit *gitstore.Commit) SubmissionRecord {
+	record := SubmissionRecord{
+		Language:  "go",
+		Passed:    commit.Trailer("Verdict") == verdictPassed,
+		CreatedAt: commit.Date,
+		Variant:   NormalizeVariant(commit.Trailer("Variant")),
+		Commit:    commit.Hash,
+	}
+
+	if passed, total, found := strings.Cut(commit.Trailer("Tests"), "/"); found {
+		record.TestsPassed, _ = strconv.Atoi(passed)
+		record.TestsTotal, _ = strconv.Atoi(total)
+	}
+	if id, err := strconv.ParseUint(commit.Trailer("Submission"), 10, 64); err == nil {
+		record.ID = uint(id)
+	}
+
+	return record
+}
+
+// repoPath returns the solution file of a variant relative to the repository root
+func repoPath(repo *gitstore.Repo, problemSlug, variant string) (string, error) {
+	path, err := filepath.Rel(repo.Dir(), variantSolutionPath(problemSlug, variant))
+	if err != nil {
+		return "", fmt.Errorf("solution file is outside %s: %w", repo.Dir(), err)
+	}
+	return path, nil
+}
+
+// ShortCommit abbreviates a commit hash for display
+func ShortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,223 @@
+package solution
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chdirTempWorkspace switches to an empty temporary directory with a
+// solutions/ directory for the duration of a test
+func chdirTempWorkspace(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	tempDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "solutions"), 0755))
+
+	oldWd, _ := os.Getwd()
+	require.NoError(t, os.Chdir(tempDir))
+	t.Cleanup(func() { os.Chdir(oldWd) })
+}
+
+// submitToGit writes a solution file and commits it as a submission
+func submitToGit(t *testing.T, slug, variant, code string, passed bool) string {
+	path := variantSolutionPath(slug, variant)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(code), 0644))
+
+	testsPassed := 3
+	if !passed {
+		testsPassed = 1
+	}
+	record := &SubmissionRecord{Passed: passed, TestsPassed: testsPassed, TestsTotal: 3, Variant: variant, Code: code}
+	hash, err := CommitSubmission(SolutionsRepo(), slug, record, nil)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestSubmissionMessage(t *testing.T) {
+	t.Run("default solution without benchmarks", func(t *testing.T) {
+		record := &SubmissionRecord{ID: 7, Passed: false, TestsPassed: 2, TestsTotal: 5}
+
+		message := SubmissionMessage("two-sum", record, nil)
+
+		assert.Equal(t, "two-sum: failed 2/5 tests\n\n"+
+			"Problem: two-sum\n"+
+			"Variant: default\n"+
+			"Verdict: failed\n"+
+			"Tests: 2/5\n"+
+			"Submission: 7\n", message)
+	})
+
+	t.Run("variant with benchmarks", func(t *testing.T) {
+		record := &SubmissionRecord{Passed: true, TestsPassed: 5, TestsTotal: 5, Variant: "hashmap"}
+		benchmarks := []database.BenchmarkResult{
+			{Name: "BenchmarkTwoSum", NsPerOp: 1520.5, BytesPerOp: 64, AllocsPerOp: 2},
+		}
+
+		message := SubmissionMessage("two-sum", record, benchmarks)
+
+		assert.Contains(t, message, "two-sum (hashmap): passed 5/5 tests\n\n")
+		assert.Contains(t, message, "Variant: hashmap\n")
+		assert.Contains(t, message, "Bench: BenchmarkTwoSum 1520.5 ns/op 64 B/op 2 allocs/op\n")
+		assert.NotContains(t, message, "Submission:")
+	})
+}
+
+func TestCommitSubmission(t *testing.T) {
+	chdirTempWorkspace(t)
+
+	hash := submitToGit(t, "two-sum", "", "package solutions\n", true)
+	assert.NotEmpty(t, hash)
+
+	// The repository is created with its .gitignore committed
+	assert.True(t, SolutionsRepo().Exists())
+	code, err := SolutionsRepo().Show(hash, ".gitignore")
+	require.NoError(t, err)
+	assert.Equal(t, "history/\n", code)
+}
+
+func TestGitHistory(t *testing.T) {
+	t.Run("lists submissions of one problem, most recent first", func(t *testing.T) {
+		chdirTempWorkspace(t)
+		first := submitToGit(t, "two-sum", "", "// v1\n", false)
+		submitToGit(t, "two-sum-ii", "", "// other problem\n", true)
+		second := submitToGit(t, "two-sum", "hashmap", "// hashmap\n", true)
+
+		history := NewGitHistory(SolutionsRepo(), "two-sum")
+		records, err := history.List(nil)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+
+		assert.Equal(t, second, records[0].Commit)
+		assert.Equal(t, "hashmap", records[0].Variant)
+		assert.True(t, records[0].Passed)
+		assert.Equal(t, 3, records[0].TestsPassed)
+		assert.Equal(t, 3, records[0].TestsTotal)
+
+		assert.Equal(t, first, records[1].Commit)
+		assert.Equal(t, "", records[1].Variant)
+		assert.False(t, records[1].Passed)
+		assert.Equal(t, 1, records[1].TestsPassed)
+	})
+
+	t.Run("filters by variant and loads code", func(t *testing.T) {
+		chdirTempWorkspace(t)
+		submitToGit(t, "two-sum", "", "// default v1\n", false)
+		submitToGit(t, "two-sum", "hashmap", "// hashmap\n", true)
+		submitToGit(t, "two-sum", "", "// default v2\n", true)
+
+		history := NewGitHistory(SolutionsRepo(), "two-sum")
+		defaultVariant := ""
+		record, err := history.Get(&defaultVariant, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "// default v1\n", record.Code)
+
+		_, err = history.Get(&defaultVariant, 3)
+		assert.EqualError(t, err, "submission #3 not found")
+	})
+
+	t.Run("finds a submission by code", func(t *testing.T) {
+		chdirTempWorkspace(t)
+		first := submitToGit(t, "two-sum", "", "// v1\n", false)
+		submitToGit(t, "two-sum", "", "// v2\n", true)
+
+		history := NewGitHistory(SolutionsRepo(), "two-sum")
+		record, err := history.FindByCode("// v1\n")
+		require.NoError(t, err)
+		assert.Equal(t, first, record.Commit)
+
+		_, err = history.FindByCode("// never submitted\n")
+		assert.ErrorIs(t, err, ErrNoSubmission)
+	})
+
+	t.Run("restores by checking out the commit", func(t *testing.T) {
+		chdirTempWorkspace(t)
+		submitToGit(t, "two-sum", "hashmap", "// v1\n", false)
+		submitToGit(t, "two-sum", "hashmap", "// v2\n", true)
+		require.NoError(t, os.WriteFile(VariantPath("two-sum", "hashmap"), []byte("// work in progress\n"), 0644))
+
+		history := NewGitHistory(SolutionsRepo(), "two-sum")
+		record, err := history.Get(nil, 2)
+		require.NoError(t, err)
+		require.NoError(t, history.Restore(record))
+
+		content, err := os.ReadFile(VariantPath("two-sum", "hashmap"))
+		require.NoError(t, err)
+		assert.Equal(t, "// v1\n", string(content))
+	})
+
+	t.Run("reads the submission ID from the commit", func(t *testing.T) {
+		chdirTempWorkspace(t)
+		require.NoError(t, os.WriteFile(variantSolutionPath("two-sum", ""), []byte("// v1\n"), 0644))
+		_, err := CommitSubmission(SolutionsRepo(), "two-sum", &SubmissionRecord{ID: 42, Passed: true}, nil)
+		require.NoError(t, err)
+
+		records, err := NewGitHistory(SolutionsRepo(), "two-sum").List(nil)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, uint(42), records[0].ID)
+	})
+}
+
+func TestMergeHistory(t *testing.T) {
+	chdirTempWorkspace(t)
+	db := setupTestDB(t)
+	svc := NewService(db)
+	path := variantSolutionPath("two-sum", "")
+
+	// Submissions made before git mode was turned on
+	submit := func(code string, age time.Duration) *SubmissionRecord {
+		require.NoError(t, os.WriteFile(path, []byte(code), 0644))
+		record, err := svc.RecordSubmission("two-sum", 1, path, true, 3, 3)
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&database.Solution{}).Where("id = ?", record.ID).Update("created_at", time.Now().Add(-age)).Error)
+		return record
+	}
+	submit("// db v1\n", 2*time.Hour)
+	second := submit("// db v2\n", time.Hour)
+
+	// A submission in git mode is in both and listed once
+	require.NoError(t, os.WriteFile(path, []byte("// git v3\n"), 0644))
+	third, err := svc.RecordSubmission("two-sum", 1, path, true, 3, 3)
+	require.NoError(t, err)
+	commit, err := CommitSubmission(SolutionsRepo(), "two-sum", third, nil)
+	require.NoError(t, err)
+
+	// Test runs without code are not submissions
+	require.NoError(t, db.Create(&database.Solution{ProblemID: 1, Status: "Failed"}).Error)
+
+	history := MergeHistory(NewGitHistory(SolutionsRepo(), "two-sum"), svc.ProblemHistory("two-sum", 1))
+	records, err := history.List(nil)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, commit, records[0].Commit)
+	assert.Equal(t, "// db v2\n", records[1].Code)
+	assert.Equal(t, "// db v1\n", records[2].Code)
+
+	record, err := history.Get(nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "// git v3\n", record.Code)
+	record, err = history.Get(nil, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "// db v1\n", record.Code)
+	_, err = history.Get(nil, 4)
+	assert.EqualError(t, err, "submission #4 not found")
+
+	record, err = history.FindByCode("// db v2\n")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, record.ID)
+
+	require.NoError(t, history.Restore(record))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "// db v2\n", string(content))
+}
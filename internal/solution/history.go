@@ -0,0 +1,137 @@
+package solution
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// History reads and restores the submissions of one problem. A nil variant
+// includes every variant; otherwise only that variant is read and indexes
+// count within it.
+type History interface {
+	// List returns submissions, most recent first
+	List(variant *string) ([]SubmissionRecord, error)
+	// Get returns the submission at a 1-based index (1 = most recent)
+	Get(variant *string, index int) (*SubmissionRecord, error)
+	// FindByCode returns the most recent submission with exactly this code,
+	// or ErrNoSubmission
+	FindByCode(code string) (*SubmissionRecord, error)
+	// Restore writes a submission back to the file of its variant
+	Restore(record *SubmissionRecord) error
+}
+
+// ProblemHistory returns the submission history of a problem kept in the database
+func (s *Service) ProblemHistory(problemSlug string, problemID uint) History {
+	return &dbHistory{svc: s, slug: problemSlug, problemID: problemID}
+}
+
+// dbHistory adapts the Service's database queries to History
+type dbHistory struct {
+	svc       *Service
+	slug      string
+	problemID uint
+}
+
+func (h *dbHistory) List(variant *string) ([]SubmissionRecord, error) {
+	if variant != nil {
+		return h.svc.GetVariantHistory(h.problemID, *variant)
+	}
+	return h.svc.GetHistory(h.problemID)
+}
+
+func (h *dbHistory) Get(variant *string, index int) (*SubmissionRecord, error) {
+	if variant != nil {
+		return h.svc.GetVariantSubmissionByIndex(h.problemID, *variant, index)
+	}
+	return h.svc.GetSubmissionByIndex(h.problemID, index)
+}
+
+func (h *dbHistory) FindByCode(code string) (*SubmissionRecord, error) {
+	return h.svc.FindSubmissionByCode(h.problemID, code)
+}
+
+func (h *dbHistory) Restore(record *SubmissionRecord) error {
+	return h.svc.RestoreSolution(h.slug, record)
+}
+
+// MergeHistory combines the git history of a problem with its database
+// history. Submissions with code in the database that no commit records,
+// e.g. those made before git mode was turned on, are listed among the
+// commits by date, so indexes cover both.
+func MergeHistory(git *GitHistory, db History) History {
+	return &mergedHistory{git: git, db: db}
+}
+
+// mergedHistory reads commits from git and uncommitted submissions from the
+// database
+type mergedHistory struct {
+	git *GitHistory
+	db  History
+}
+
+func (h *mergedHistory) List(variant *string) ([]SubmissionRecord, error) {
+	records, err := h.git.List(variant)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := h.db.List(variant)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := make(map[uint]bool, len(records))
+	for _, record := range records {
+		if record.ID != 0 {
+			committed[record.ID] = true
+		}
+	}
+	for _, record := range stored {
+		// Test runs record rows without code; they are not submissions
+		if record.Code != "" && !committed[record.ID] {
+			records = append(records, record)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+	return records, nil
+}
+
+func (h *mergedHistory) Get(variant *string, index int) (*SubmissionRecord, error) {
+	if index < 1 {
+		return nil, fmt.Errorf("index must be >= 1")
+	}
+
+	records, err := h.List(variant)
+	if err != nil {
+		return nil, err
+	}
+	if index > len(records) {
+		return nil, fmt.Errorf("submission #%d not found", index)
+	}
+
+	record := records[index-1]
+	if record.Commit != "" {
+		if err := h.git.loadCode(&record); err != nil {
+			return nil, err
+		}
+	}
+	return &record, nil
+}
+
+func (h *mergedHistory) FindByCode(code string) (*SubmissionRecord, error) {
+	record, err := h.git.FindByCode(code)
+	if errors.Is(err, ErrNoSubmission) {
+		return h.db.FindByCode(code)
+	}
+	return record, err
+}
+
+func (h *mergedHistory) Restore(record *SubmissionRecord) error {
+	if record.Commit != "" {
+		return h.git.Restore(record)
+	}
+	return h.db.Restore(record)
+}
@@ -1,12 +1,14 @@
 package solution
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"gorm.io/gorm"
 )
 
@@ -14,6 +16,7 @@ import (
 type Service struct {
 	db        *gorm.DB
 	generator *Generator
+	codes     *CodeStore
 }
 
 // NewService creates a new solution service
@@ -21,6 +24,7 @@ func NewService(db *gorm.DB) *Service {
 	return &Service{
 		db:        db,
 		generator: NewGenerator(),
+		codes:     NewCodeStore(db),
 	}
 }
 
@@ -29,6 +33,11 @@ func (s *Service) GenerateSolution(p *database.Problem, force bool) (string, err
 	return s.generator.GenerateSolution(p, force)
 }
 
+// GenerateVariant creates the solution file for a named variant of the problem
+func (s *Service) GenerateVariant(p *database.Problem, variant string, force bool) (string, error) {
+	return s.generator.GenerateVariant(p, variant, force)
+}
+
 // SubmissionRecord represents a solution submission with metadata
 type SubmissionRecord struct {
 	ID          uint
@@ -39,64 +48,72 @@ type SubmissionRecord struct {
 	CreatedAt   time.Time
 	TestsPassed int
 	TestsTotal  int
+	Variant     string // Empty for the default solution
+	Commit      string // Git commit of the submission in a git-backed solutions workspace
+
+	ComplexityClass      string
+	ComplexityConfidence float64
 }
 
-// RecordSubmission saves a solution to history directory and database
-// Creates directory structure: solutions/history/<problem-slug>/<timestamp>.go
+// RecordSubmission saves a solution to the database. The code is stored
+// once per distinct content and referenced by hash, so resubmitting
+// unchanged code does not store it again.
 func (s *Service) RecordSubmission(problemSlug string, problemID uint, solutionPath string, passed bool, testsPassed, testsTotal int) (*SubmissionRecord, error) {
+	return s.RecordVariantSubmission(problemSlug, "", problemID, solutionPath, passed, testsPassed, testsTotal)
+}
+
+// RecordVariantSubmission saves a submission of a named variant; an empty
+// variant records the default solution
+func (s *Service) RecordVariantSubmission(problemSlug, variant string, problemID uint, solutionPath string, passed bool, testsPassed, testsTotal int) (*SubmissionRecord, error) {
 	// Read solution code
 	code, err := os.ReadFile(solutionPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read solution file: %w", err)
 	}
 
-	// Create history directory
-	historyDir := filepath.Join("solutions", "history", problemSlug)
-	if err := os.MkdirAll(historyDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create history directory: %w", err)
-	}
-
-	// Generate timestamp-based filename
-	timestamp := time.Now().Format("20060102-150405")
-	historyPath := filepath.Join(historyDir, fmt.Sprintf("%s.go", timestamp))
-
-	// Copy solution to history
-	if err := os.WriteFile(historyPath, code, 0644); err != nil {
-		return nil, fmt.Errorf("failed to write history file: %w", err)
+	// Store code by content hash
+	hash, err := s.codes.Put(string(code))
+	if err != nil {
+		return nil, err
 	}
 
 	// Create database record
 	solution := &database.Solution{
-		ProblemID: problemID,
-		Code:      string(code),
-		Language:  "go",
-		Passed:    passed,
+		ProblemID:   problemID,
+		CodeHash:    hash,
+		Language:    "go",
+		Passed:      passed,
+		TestsPassed: testsPassed,
+		TestsTotal:  testsTotal,
+		Variant:     variant,
 	}
 
 	if err := s.db.Create(solution).Error; err != nil {
 		return nil, fmt.Errorf("failed to create database record: %w", err)
 	}
 
-	return &SubmissionRecord{
-		ID:          solution.ID,
-		ProblemID:   solution.ProblemID,
-		Code:        solution.Code,
-		Language:    solution.Language,
-		Passed:      solution.Passed,
-		CreatedAt:   solution.CreatedAt,
-		TestsPassed: testsPassed,
-		TestsTotal:  testsTotal,
-	}, nil
+	record := toRecord(solution)
+	record.Code = string(code)
+	return &record, nil
 }
 
 // GetHistory retrieves all solution submissions for a problem
 // Returns submissions sorted by most recent first
 func (s *Service) GetHistory(problemID uint) ([]SubmissionRecord, error) {
+	return s.getHistory(s.db.Where("problem_id = ?", problemID))
+}
+
+// GetVariantHistory retrieves the submissions of one variant of a problem,
+// most recent first. An empty variant selects the default solution.
+func (s *Service) GetVariantHistory(problemID uint, variant string) ([]SubmissionRecord, error) {
+	return s.getHistory(s.db.Where("problem_id = ? AND variant = ?", problemID, variant))
+}
+
+// getHistory runs a submission query sorted by most recent first
+func (s *Service) getHistory(query *gorm.DB) ([]SubmissionRecord, error) {
 	var solutions []database.Solution
 
-	err := s.db.Where("problem_id = ?", problemID).
-		Order("created_at DESC").
-		Find(&solutions).Error
+	err := query.Order("created_at DESC").Find(&solutions).Error
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to query solution history: %w", err)
@@ -104,15 +121,12 @@ func (s *Service) GetHistory(problemID uint) ([]SubmissionRecord, error) {
 
 	// Convert to SubmissionRecord format
 	records := make([]SubmissionRecord, len(solutions))
-	for i, sol := range solutions {
-		records[i] = SubmissionRecord{
-			ID:        sol.ID,
-			ProblemID: sol.ProblemID,
-			Code:      sol.Code,
-			Language:  sol.Language,
-			Passed:    sol.Passed,
-			CreatedAt: sol.CreatedAt,
+	for i := range solutions {
+		record, err := s.loadRecord(&solutions[i])
+		if err != nil {
+			return nil, err
 		}
+		records[i] = record
 	}
 
 	return records, nil
@@ -120,6 +134,17 @@ func (s *Service) GetHistory(problemID uint) ([]SubmissionRecord, error) {
 
 // GetSubmissionByIndex retrieves a submission by 1-based index (1 = most recent)
 func (s *Service) GetSubmissionByIndex(problemID uint, index int) (*SubmissionRecord, error) {
+	return s.getSubmissionByIndex(s.db.Where("problem_id = ?", problemID), index)
+}
+
+// GetVariantSubmissionByIndex retrieves a submission of one variant by
+// 1-based index within that variant (1 = most recent)
+func (s *Service) GetVariantSubmissionByIndex(problemID uint, variant string, index int) (*SubmissionRecord, error) {
+	return s.getSubmissionByIndex(s.db.Where("problem_id = ? AND variant = ?", problemID, variant), index)
+}
+
+// getSubmissionByIndex returns the index-th most recent submission of a query
+func (s *Service) getSubmissionByIndex(query *gorm.DB, index int) (*SubmissionRecord, error) {
 	if index < 1 {
 		return nil, fmt.Errorf("index must be >= 1")
 	}
@@ -127,7 +152,7 @@ func (s *Service) GetSubmissionByIndex(problemID uint, index int) (*SubmissionRe
 	var solution database.Solution
 
 	// Query with LIMIT 1 OFFSET (index-1)
-	err := s.db.Where("problem_id = ?", problemID).
+	err := query.
 		Order("created_at DESC").
 		Offset(index - 1).
 		Limit(1).
@@ -140,19 +165,57 @@ func (s *Service) GetSubmissionByIndex(problemID uint, index int) (*SubmissionRe
 		return nil, fmt.Errorf("failed to query submission: %w", err)
 	}
 
-	return &SubmissionRecord{
-		ID:        solution.ID,
-		ProblemID: solution.ProblemID,
-		Code:      solution.Code,
-		Language:  solution.Language,
-		Passed:    solution.Passed,
-		CreatedAt: solution.CreatedAt,
-	}, nil
+	record, err := s.loadRecord(&solution)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// ErrNoSubmission is returned when a problem has no recorded submissions
+var ErrNoSubmission = errors.New("no submission found")
+
+// RecordComplexity stores a measured complexity class on the most recent
+// submission for a problem. Returns ErrNoSubmission if nothing was submitted.
+func (s *Service) RecordComplexity(problemID uint, class string, confidence float64) (*SubmissionRecord, error) {
+	var solution database.Solution
+
+	err := s.db.Where("problem_id = ? AND (code <> '' OR code_hash <> '')", problemID).
+		Order("created_at DESC").
+		First(&solution).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNoSubmission
+		}
+		return nil, fmt.Errorf("failed to query latest submission: %w", err)
+	}
+
+	updates := map[string]interface{}{
+		"complexity_class":      class,
+		"complexity_confidence": confidence,
+	}
+	if err := s.db.Model(&solution).Updates(updates).Error; err != nil {
+		return nil, fmt.Errorf("failed to record complexity: %w", err)
+	}
+
+	solution.ComplexityClass = class
+	solution.ComplexityConfidence = confidence
+	record, err := s.loadRecord(&solution)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
 }
 
 // BackupCurrentSolution creates a backup of the current solution
 func (s *Service) BackupCurrentSolution(problemSlug string, problemID uint) (string, error) {
-	solutionPath := filepath.Join("solutions", fmt.Sprintf("%s.go", problemSlug))
+	return s.BackupCurrentVariant(problemSlug, "", problemID)
+}
+
+// BackupCurrentVariant creates a backup of the current file of a variant.
+// An empty variant backs up the default solution.
+func (s *Service) BackupCurrentVariant(problemSlug, variant string, problemID uint) (string, error) {
+	solutionPath := variantSolutionPath(problemSlug, variant)
 
 	// Check if solution file exists
 	if _, err := os.Stat(solutionPath); os.IsNotExist(err) {
@@ -161,7 +224,7 @@ func (s *Service) BackupCurrentSolution(problemSlug string, problemID uint) (str
 
 	// Create backup with timestamp
 	timestamp := time.Now().Format("20060102-150405")
-	historyDir := filepath.Join("solutions", "history", problemSlug)
+	historyDir := workspace.Solutions("history", problemSlug, variant)
 	if err := os.MkdirAll(historyDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create history directory: %w", err)
 	}
@@ -181,12 +244,13 @@ func (s *Service) BackupCurrentSolution(problemSlug string, problemID uint) (str
 	return backupPath, nil
 }
 
-// RestoreSolution restores a submission as the current solution
+// RestoreSolution restores a submission as the current solution of the
+// variant it was submitted as
 func (s *Service) RestoreSolution(problemSlug string, record *SubmissionRecord) error {
-	solutionPath := filepath.Join("solutions", fmt.Sprintf("%s.go", problemSlug))
+	solutionPath := variantSolutionPath(problemSlug, record.Variant)
 
-	// Create solutions directory if doesn't exist
-	if err := os.MkdirAll("solutions", 0755); err != nil {
+	// Create solution directory if doesn't exist
+	if err := os.MkdirAll(filepath.Dir(solutionPath), 0755); err != nil {
 		return fmt.Errorf("failed to create solutions directory: %w", err)
 	}
 
@@ -197,3 +261,63 @@ func (s *Service) RestoreSolution(problemSlug string, record *SubmissionRecord)
 
 	return nil
 }
+
+// variantSolutionPath returns the current solution file of a variant, the
+// default solution for the empty variant
+func variantSolutionPath(problemSlug, variant string) string {
+	return workspace.ProblemLayout(problemSlug).Variant(variant)
+}
+
+// FindSubmissionByCode retrieves the most recent submission whose code matches
+// exactly. Returns ErrNoSubmission if no submission has this code.
+func (s *Service) FindSubmissionByCode(problemID uint, code string) (*SubmissionRecord, error) {
+	var solution database.Solution
+
+	err := s.db.Where("problem_id = ? AND (code_hash = ? OR code = ?)", problemID, HashCode(code), code).
+		Order("created_at DESC").
+		First(&solution).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNoSubmission
+		}
+		return nil, fmt.Errorf("failed to query submission: %w", err)
+	}
+
+	record, err := s.loadRecord(&solution)
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// loadRecord converts a database solution to a SubmissionRecord, loading its
+// code from the code store when it is not stored inline
+func (s *Service) loadRecord(solution *database.Solution) (SubmissionRecord, error) {
+	record := toRecord(solution)
+	if record.Code == "" && solution.CodeHash != "" {
+		code, err := s.codes.Get(solution.CodeHash)
+		if err != nil {
+			return SubmissionRecord{}, fmt.Errorf("failed to load code of submission %d: %w", solution.ID, err)
+		}
+		record.Code = code
+	}
+	return record, nil
+}
+
+// toRecord converts a database solution to a SubmissionRecord
+func toRecord(solution *database.Solution) SubmissionRecord {
+	return SubmissionRecord{
+		ID:          solution.ID,
+		ProblemID:   solution.ProblemID,
+		Code:        solution.Code,
+		Language:    solution.Language,
+		Passed:      solution.Passed,
+		CreatedAt:   solution.CreatedAt,
+		TestsPassed: solution.TestsPassed,
+		TestsTotal:  solution.TestsTotal,
+		Variant:     solution.Variant,
+
+		ComplexityClass:      solution.ComplexityClass,
+		ComplexityConfidence: solution.ComplexityConfidence,
+	}
+}
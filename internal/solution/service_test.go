@@ -19,7 +19,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 
 	// Auto-migrate models
-	err = db.AutoMigrate(&database.Problem{}, &database.Solution{}, &database.Progress{})
+	err = db.AutoMigrate(&database.Problem{}, &database.Solution{}, &database.Progress{}, &database.CodeBlob{}, &database.BenchmarkResult{})
 	require.NoError(t, err)
 
 	return db
@@ -57,11 +57,44 @@ func TestRecordSubmission(t *testing.T) {
 		assert.Equal(t, 5, record.TestsPassed)
 		assert.Equal(t, 5, record.TestsTotal)
 
-		// Verify history file was created
-		historyDir := filepath.Join("solutions", "history", "two-sum")
-		entries, err := os.ReadDir(historyDir)
-		assert.NoError(t, err)
-		assert.Len(t, entries, 1)
+		// Verify code is stored by content hash instead of inline
+		var saved database.Solution
+		require.NoError(t, db.First(&saved, record.ID).Error)
+		assert.Empty(t, saved.Code)
+		assert.Equal(t, HashCode("package solutions\n\nfunc TwoSum() {}"), saved.CodeHash)
+		assert.Equal(t, "package solutions\n\nfunc TwoSum() {}", record.Code)
+
+		// No copies are written to the history directory
+		_, err = os.Stat(filepath.Join("solutions", "history"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("stores unchanged code once", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+
+		tempDir := t.TempDir()
+		solutionPath := createTestSolutionFile(t, tempDir, "two-sum.go", "package solutions\n\nfunc TwoSum() {}")
+
+		oldWd, _ := os.Getwd()
+		os.Chdir(tempDir)
+		defer os.Chdir(oldWd)
+
+		for i := 0; i < 3; i++ {
+			_, err := svc.RecordSubmission("two-sum", 1, solutionPath, false, 0, 5)
+			require.NoError(t, err)
+		}
+
+		var blobs int64
+		db.Model(&database.CodeBlob{}).Count(&blobs)
+		assert.Equal(t, int64(1), blobs)
+
+		records, err := svc.GetHistory(1)
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		for _, record := range records {
+			assert.Equal(t, "package solutions\n\nfunc TwoSum() {}", record.Code)
+		}
 	})
 
 	t.Run("records failing solution successfully", func(t *testing.T) {
@@ -227,7 +260,7 @@ func TestBackupCurrentSolution(t *testing.T) {
 		// Create current solution
 		solutionDir := filepath.Join(tempDir, "solutions")
 		os.MkdirAll(solutionDir, 0755)
-		solutionPath := filepath.Join(solutionDir, "two-sum.go")
+		solutionPath := filepath.Join(solutionDir, "two_sum.go")
 		solutionContent := "package solutions\n\nfunc TwoSum() { return 42 }"
 		os.WriteFile(solutionPath, []byte(solutionContent), 0644)
 
@@ -283,7 +316,7 @@ func TestRestoreSolution(t *testing.T) {
 		assert.NoError(t, err)
 
 		// Verify solution file was created
-		solutionPath := filepath.Join("solutions", "two-sum.go")
+		solutionPath := filepath.Join("solutions", "two_sum.go")
 		content, err := os.ReadFile(solutionPath)
 		assert.NoError(t, err)
 		assert.Equal(t, record.Code, string(content))
@@ -312,3 +345,145 @@ func TestRestoreSolution(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestRecordComplexity(t *testing.T) {
+	t.Run("stores estimate on latest submission", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+
+		older := database.Solution{ProblemID: 1, Code: "v1", CreatedAt: time.Now().Add(-time.Hour)}
+		newer := database.Solution{ProblemID: 1, Code: "v2", CreatedAt: time.Now()}
+		require.NoError(t, db.Create(&older).Error)
+		require.NoError(t, db.Create(&newer).Error)
+
+		record, err := svc.RecordComplexity(1, "O(n)", 0.93)
+
+		assert.NoError(t, err)
+		assert.Equal(t, newer.ID, record.ID)
+
+		var saved database.Solution
+		db.First(&saved, newer.ID)
+		assert.Equal(t, "O(n)", saved.ComplexityClass)
+		assert.Equal(t, 0.93, saved.ComplexityConfidence)
+
+		var untouched database.Solution
+		db.First(&untouched, older.ID)
+		assert.Empty(t, untouched.ComplexityClass)
+	})
+
+	t.Run("returns ErrNoSubmission without submissions", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+
+		_, err := svc.RecordComplexity(1, "O(n)", 0.5)
+
+		assert.ErrorIs(t, err, ErrNoSubmission)
+	})
+}
+
+func TestFindSubmissionByCode(t *testing.T) {
+	t.Run("returns most recent submission with matching code", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+
+		first := database.Solution{ProblemID: 1, Code: "same", CreatedAt: time.Now().Add(-2 * time.Hour)}
+		second := database.Solution{ProblemID: 1, Code: "same", CreatedAt: time.Now().Add(-time.Hour)}
+		other := database.Solution{ProblemID: 1, Code: "different", CreatedAt: time.Now()}
+		require.NoError(t, db.Create(&first).Error)
+		require.NoError(t, db.Create(&second).Error)
+		require.NoError(t, db.Create(&other).Error)
+
+		record, err := svc.FindSubmissionByCode(1, "same")
+
+		assert.NoError(t, err)
+		assert.Equal(t, second.ID, record.ID)
+	})
+
+	t.Run("returns ErrNoSubmission when code does not match", func(t *testing.T) {
+		db := setupTestDB(t)
+		svc := NewService(db)
+		require.NoError(t, db.Create(&database.Solution{ProblemID: 1, Code: "v1"}).Error)
+
+		_, err := svc.FindSubmissionByCode(1, "v2")
+
+		assert.ErrorIs(t, err, ErrNoSubmission)
+	})
+}
+
+func TestVariantSubmissions(t *testing.T) {
+	db := setupTestDB(t)
+	svc := NewService(db)
+
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	defaultPath := createTestSolutionFile(t, tempDir, "default.go", "package solutions\n\nfunc TwoSum() { /* default */ }")
+	hashmapPath := createTestSolutionFile(t, tempDir, "hashmap.go", "package solutions\n\nfunc TwoSum() { /* hashmap */ }")
+
+	_, err := svc.RecordSubmission("two-sum", 1, defaultPath, true, 5, 5)
+	require.NoError(t, err)
+	record, err := svc.RecordVariantSubmission("two-sum", "hashmap", 1, hashmapPath, true, 5, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "hashmap", record.Variant)
+
+	t.Run("filters history by variant", func(t *testing.T) {
+		all, err := svc.GetHistory(1)
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+
+		hashmap, err := svc.GetVariantHistory(1, "hashmap")
+		require.NoError(t, err)
+		require.Len(t, hashmap, 1)
+		assert.Contains(t, hashmap[0].Code, "hashmap")
+
+		defaults, err := svc.GetVariantHistory(1, "")
+		require.NoError(t, err)
+		require.Len(t, defaults, 1)
+		assert.Contains(t, defaults[0].Code, "default")
+	})
+
+	t.Run("indexes within a variant", func(t *testing.T) {
+		record, err := svc.GetVariantSubmissionByIndex(1, "hashmap", 1)
+		require.NoError(t, err)
+		assert.Equal(t, "hashmap", record.Variant)
+
+		_, err = svc.GetVariantSubmissionByIndex(1, "hashmap", 2)
+		assert.Error(t, err)
+	})
+
+	t.Run("restores and backs up the variant file", func(t *testing.T) {
+		require.NoError(t, svc.RestoreSolution("two-sum", record))
+
+		content, err := os.ReadFile(VariantPath("two-sum", "hashmap"))
+		require.NoError(t, err)
+		assert.Equal(t, record.Code, string(content))
+		_, err = os.Stat(filepath.Join("solutions", "two_sum.go"))
+		assert.True(t, os.IsNotExist(err))
+
+		backupPath, err := svc.BackupCurrentVariant("two-sum", "hashmap", 1)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("solutions", "history", "two-sum", "hashmap"), filepath.Dir(backupPath))
+	})
+}
+
+func TestRecordSubmission_PersistsTestCounts(t *testing.T) {
+	db := setupTestDB(t)
+	svc := NewService(db)
+
+	tempDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	os.Chdir(tempDir)
+	defer os.Chdir(oldWd)
+
+	solutionPath := createTestSolutionFile(t, tempDir, "two-sum.go", "package solutions\n\nfunc TwoSum() {}")
+	_, err := svc.RecordSubmission("two-sum", 1, solutionPath, false, 3, 5)
+	require.NoError(t, err)
+
+	record, err := svc.GetSubmissionByIndex(1, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, record.TestsPassed)
+	assert.Equal(t, 5, record.TestsTotal)
+}
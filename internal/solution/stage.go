@@ -0,0 +1,71 @@
+package solution
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// StageSolution copies test files and a solution file into a new hidden
+// directory next to the first test file, rewriting the solution's package
+// clause to match the tests. The testdata directory next to the tests, with
+// fixtures and fuzz corpora, is linked into the stage. The caller removes the
+// returned directory.
+func StageSolution(testFile, solutionPath string, moreTestFiles ...string) (string, error) {
+	testSrc, err := os.ReadFile(testFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read test file: %w", err)
+	}
+	solutionSrc, err := os.ReadFile(solutionPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read solution file: %w", err)
+	}
+
+	pkg := packagePattern.FindSubmatch(testSrc)
+	if pkg == nil {
+		return "", fmt.Errorf("no package clause in %s", testFile)
+	}
+	loc := packagePattern.FindSubmatchIndex(solutionSrc)
+	if loc == nil {
+		return "", fmt.Errorf("no package clause in %s", solutionPath)
+	}
+	staged := append([]byte{}, solutionSrc[:loc[2]]...)
+	staged = append(staged, pkg[1]...)
+	solutionSrc = append(staged, solutionSrc[loc[3]:]...)
+
+	// Directories starting with "." are ignored by ./... patterns
+	stageDir, err := os.MkdirTemp(filepath.Dir(testFile), ".dsa-stage-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create staging directory: %w", err)
+	}
+
+	for _, path := range append([]string{testFile}, moreTestFiles...) {
+		src := testSrc
+		if path != testFile {
+			if src, err = os.ReadFile(path); err != nil {
+				os.RemoveAll(stageDir)
+				return "", fmt.Errorf("failed to read test file: %w", err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(stageDir, filepath.Base(path)), src, 0644); err != nil {
+			os.RemoveAll(stageDir)
+			return "", fmt.Errorf("failed to stage test file: %w", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(stageDir, "solution.go"), solutionSrc, 0644); err != nil {
+		os.RemoveAll(stageDir)
+		return "", fmt.Errorf("failed to stage solution file: %w", err)
+	}
+
+	// Tests read fixtures relative to their package directory, the stage.
+	// Without symlink support they run without fixtures.
+	if _, err := os.Stat(filepath.Join(filepath.Dir(testFile), "testdata")); err == nil {
+		os.Symlink(filepath.Join("..", "testdata"), filepath.Join(stageDir, "testdata"))
+	}
+
+	return stageDir, nil
+}
+
+// packagePattern matches the first package clause of a Go source file
+var packagePattern = regexp.MustCompile(`(?m)^package\s+(\w+)`)
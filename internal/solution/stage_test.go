@@ -0,0 +1,67 @@
+package solution
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStageSolution(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := createTestSolutionFile(t, tmpDir, "two_sum_test.go", "package problems\n\nimport \"testing\"\n\nfunc TestTwoSum(t *testing.T) {}\n")
+
+	t.Run("stages test and solution in one package", func(t *testing.T) {
+		solutionPath := createTestSolutionFile(t, t.TempDir(), "two-sum.go", "// Two Sum\npackage solutions\n\n// package notes stay intact\nfunc TwoSum() {}\n")
+
+		stageDir, err := StageSolution(testFile, solutionPath)
+		require.NoError(t, err)
+		defer os.RemoveAll(stageDir)
+
+		assert.Equal(t, tmpDir, filepath.Dir(stageDir))
+		assert.True(t, filepath.Base(stageDir)[0] == '.')
+
+		staged, err := os.ReadFile(filepath.Join(stageDir, "solution.go"))
+		require.NoError(t, err)
+		assert.Equal(t, "// Two Sum\npackage problems\n\n// package notes stay intact\nfunc TwoSum() {}\n", string(staged))
+
+		_, err = os.Stat(filepath.Join(stageDir, "two_sum_test.go"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("stages extra test files and links testdata", func(t *testing.T) {
+		dir := t.TempDir()
+		testFile := createTestSolutionFile(t, dir, "two_sum_test.go", "package problems\n")
+		benchFile := createTestSolutionFile(t, dir, "two_sum_bench_test.go", "package problems\n")
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "testdata"), 0755))
+		createTestSolutionFile(t, filepath.Join(dir, "testdata"), "input.txt", "2 7 11 15")
+		solutionPath := createTestSolutionFile(t, t.TempDir(), "two_sum.go", "package solutions\n")
+
+		stageDir, err := StageSolution(testFile, solutionPath, benchFile)
+		require.NoError(t, err)
+		defer os.RemoveAll(stageDir)
+
+		assert.FileExists(t, filepath.Join(stageDir, "two_sum_bench_test.go"))
+		fixture, err := os.ReadFile(filepath.Join(stageDir, "testdata", "input.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "2 7 11 15", string(fixture))
+	})
+
+	t.Run("returns error for missing solution", func(t *testing.T) {
+		_, err := StageSolution(testFile, filepath.Join(tmpDir, "missing.go"))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read solution file")
+	})
+
+	t.Run("returns error without package clause", func(t *testing.T) {
+		solutionPath := createTestSolutionFile(t, t.TempDir(), "broken.go", "func TwoSum() {}\n")
+
+		_, err := StageSolution(testFile, solutionPath)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no package clause")
+	})
+}
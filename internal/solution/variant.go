@@ -0,0 +1,79 @@
+package solution
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+)
+
+// DefaultVariant is the name used on the command line and in output for the
+// unnamed solution at solutions/<name>.go. It is stored as the empty string.
+const DefaultVariant = "default"
+
+// variantNamePattern allows lowercase words joined by hyphens, e.g. "two-pointers"
+var variantNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ValidateVariant checks that a variant name can be used as a directory name.
+// The empty string and DefaultVariant both refer to the default solution.
+func ValidateVariant(name string) error {
+	if name == "" || name == DefaultVariant {
+		return nil
+	}
+	if !variantNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid variant name '%s': use lowercase letters, digits and hyphens (e.g. two-pointers)", name)
+	}
+	return nil
+}
+
+// NormalizeVariant returns the stored form of a variant name, mapping
+// DefaultVariant to the empty string
+func NormalizeVariant(name string) string {
+	if name == DefaultVariant {
+		return ""
+	}
+	return name
+}
+
+// VariantLabel returns the display name of a stored variant
+func VariantLabel(variant string) string {
+	if variant == "" {
+		return DefaultVariant
+	}
+	return variant
+}
+
+// VariantPath returns the solution file for a named variant:
+// solutions/variants/<variant>/<name>.go, see workspace.Layout. Each variant
+// has its own directory so variants of the same problem can declare the same
+// function without clashing.
+func VariantPath(slug, variant string) string {
+	return workspace.ProblemLayout(slug).Variant(variant)
+}
+
+// ListVariants returns the names of all variants with a solution file for a
+// problem, sorted by name. The default solution is not included.
+func ListVariants(slug string) ([]string, error) {
+	entries, err := os.ReadDir(workspace.VariantsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read variants directory: %w", err)
+	}
+
+	var variants []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(VariantPath(slug, entry.Name())); err == nil {
+			variants = append(variants, entry.Name())
+		}
+	}
+	sort.Strings(variants)
+
+	return variants, nil
+}
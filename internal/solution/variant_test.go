@@ -0,0 +1,63 @@
+package solution
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateVariant(t *testing.T) {
+	for _, name := range []string{"", "default", "brute", "two-pointers", "dp2"} {
+		assert.NoError(t, ValidateVariant(name), name)
+	}
+	for _, name := range []string{"Brute", "two_pointers", "-brute", "brute-", "two--pointers", "../x", "a b"} {
+		assert.Error(t, ValidateVariant(name), name)
+	}
+}
+
+func TestNormalizeVariant(t *testing.T) {
+	assert.Equal(t, "", NormalizeVariant("default"))
+	assert.Equal(t, "", NormalizeVariant(""))
+	assert.Equal(t, "hashmap", NormalizeVariant("hashmap"))
+
+	assert.Equal(t, "default", VariantLabel(""))
+	assert.Equal(t, "hashmap", VariantLabel("hashmap"))
+}
+
+func TestVariantPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("solutions", "two_sum.go"), VariantPath("two-sum", ""))
+	assert.Equal(t, filepath.Join("solutions", "variants", "hashmap", "two_sum.go"), VariantPath("two-sum", "hashmap"))
+}
+
+func TestListVariants(t *testing.T) {
+	tmpDir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	os.Chdir(tmpDir)
+	defer os.Chdir(originalDir)
+
+	t.Run("returns nothing without a variants directory", func(t *testing.T) {
+		variants, err := ListVariants("two-sum")
+
+		assert.NoError(t, err)
+		assert.Empty(t, variants)
+	})
+
+	t.Run("lists variants with a solution for the problem", func(t *testing.T) {
+		for _, path := range []string{
+			VariantPath("two-sum", "two-pointers"),
+			VariantPath("two-sum", "brute"),
+			VariantPath("valid-anagram", "sorting"),
+		} {
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, []byte("package solutions\n"), 0644))
+		}
+
+		variants, err := ListVariants("two-sum")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"brute", "two-pointers"}, variants)
+	})
+}
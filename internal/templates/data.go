@@ -0,0 +1,291 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+)
+
+// Data is what every template is executed with. Fields are documented for
+// users in Fields; keep both in sync.
+type Data struct {
+	Slug             string
+	ProblemTitle     string
+	FunctionName     string
+	Signature        string
+	Description      string
+	Difficulty       string
+	Topic            string
+	Tags             []string
+	TargetComplexity string
+	Hints            []string
+	Examples         []Example
+	Variant          string // Set for the solution template only
+}
+
+// Example is one test case of a problem's reference tests, as Go source
+type Example struct {
+	Name     string
+	Input    string // e.g. "nums: []int{2, 7, 11, 15}, target: 9"
+	Expected string // e.g. "[]int{0, 1}"
+}
+
+// Field documents one value available to templates
+type Field struct {
+	Name      string
+	Type      string
+	Doc       string
+	Templates []string // Templates the field is set for; empty for all
+}
+
+// Fields documents the template data
+var Fields = []Field{
+	{Name: ".Slug", Type: "string", Doc: "Problem slug, e.g. two-sum"},
+	{Name: ".ProblemTitle", Type: "string", Doc: "Problem title, e.g. Two Sum"},
+	{Name: ".FunctionName", Type: "string", Doc: "Function to implement, e.g. TwoSum"},
+	{Name: ".Signature", Type: "string", Doc: "Signature from the problem's boilerplate, e.g. func TwoSum(nums []int, target int) []int; func TwoSum() if unknown"},
+	{Name: ".Description", Type: "string", Doc: "Problem description"},
+	{Name: ".Difficulty", Type: "string", Doc: "easy, medium or hard"},
+	{Name: ".Topic", Type: "string", Doc: "Topic, e.g. arrays"},
+	{Name: ".Tags", Type: "[]string", Doc: "Problem tags, e.g. hash-table"},
+	{Name: ".TargetComplexity", Type: "string", Doc: "Expected time complexity, e.g. O(n); may be empty"},
+	{Name: ".Hints", Type: "[]string", Doc: "\"// Hint:\" comments of the problem's boilerplate"},
+	{Name: ".Examples", Type: "[]Example", Doc: "Test cases of the problem's tests, each with .Name, .Input and .Expected as Go source"},
+	{Name: ".Variant", Type: "string", Doc: "Solution variant; empty for the default solution", Templates: []string{Solution}},
+	{Name: ".TestCases", Type: "[]TestCase", Doc: "Test cases being generated, each with .Name, .Inputs and .Expected, and when .Typed, .Args and .Want as typed Go literals and .Compare, their comparison", Templates: []string{TestGen}},
+	{Name: ".Typed", Type: "bool", Doc: "The function's signature was found; the fields below are set", Templates: []string{TestGen}},
+	{Name: ".Params", Type: "[]Column", Doc: "Parameters, each with .Name and .Type, e.g. nums []int64", Templates: []string{TestGen}},
+	{Name: ".Results", Type: "[]Column", Doc: "Expected values, named want (or want1, want2, ...); .Format is set for lists, trees and graphs", Templates: []string{TestGen}},
+	{Name: ".Call", Type: "string", Doc: "Call with the table's inputs, e.g. TwoSum(tt.nums, tt.target)", Templates: []string{TestGen}},
+	{Name: ".Got", Type: "[]string", Doc: "Values compared against .Results: the call's results, or the first input when .InPlace", Templates: []string{TestGen}},
+	{Name: ".InPlace", Type: "bool", Doc: "The function returns nothing; the first input is checked after the call", Templates: []string{TestGen}},
+	{Name: ".Checks", Type: "[]Check", Doc: "Comparisons used by .TestCases, each with .Mode and the .Assertions run after the call", Templates: []string{TestGen}},
+	{Name: ".Compare", Type: "bool", Doc: "Test cases use more than one comparison; the table has a compare column", Templates: []string{TestGen}},
+	{Name: ".StdImports", Type: "[]string", Doc: "Standard library packages used by the parameter and result types", Templates: []string{TestGen}},
+	{Name: ".Imports", Type: "[]string", Doc: "Other packages used by the tests, e.g. the workspace's structures package", Templates: []string{TestGen}},
+}
+
+// Functions documents the helper functions available to templates
+var Functions = []Field{
+	{Name: "join", Type: "[]string, sep", Doc: "Join strings with a separator: {{join .Tags \", \"}}"},
+	{Name: "lower", Type: "string", Doc: "Lowercase a string"},
+	{Name: "upper", Type: "string", Doc: "Uppercase a string"},
+	{Name: "comment", Type: "string", Doc: "Prefix every line with \"// \": {{comment .Description}}"},
+	{Name: "formatValue", Type: "value", Doc: "Format a test case value as Go source", Templates: []string{TestGen}},
+}
+
+// Funcs returns the helper functions every template can use
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"join":  strings.Join,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"comment": func(text string) string {
+			lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimRight("// "+line, " ")
+			}
+			return strings.Join(lines, "\n")
+		},
+	}
+}
+
+// FieldsFor returns the fields or functions available to one template
+func FieldsFor(name string, all []Field) []Field {
+	var available []Field
+	for _, field := range all {
+		if len(field.Templates) == 0 || contains(field.Templates, name) {
+			available = append(available, field)
+		}
+	}
+	return available
+}
+
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// DataComment returns a template comment documenting the data and functions
+// available to a template. It renders as nothing.
+func DataComment(name string) string {
+	var comment strings.Builder
+	comment.WriteString("{{- /*\n")
+	fmt.Fprintf(&comment, "  %s template. Data:\n", name)
+	for _, field := range FieldsFor(name, Fields) {
+		fmt.Fprintf(&comment, "    %-18s %-10s %s\n", field.Name, field.Type, field.Doc)
+	}
+	comment.WriteString("  Functions:\n")
+	for _, function := range FieldsFor(name, Functions) {
+		fmt.Fprintf(&comment, "    %-18s %s\n", function.Name, function.Doc)
+	}
+	comment.WriteString("  Delete this file to fall back to the built-in template\n")
+	comment.WriteString("*/ -}}\n")
+	return comment.String()
+}
+
+// NewData builds the template data of a problem. The signature and hints come
+// from the problem's boilerplate and the examples from its tests, looked up in
+// problems/templates and then problems; they are left empty when not found.
+func NewData(p *database.Problem) Data {
+	data := Data{
+		Slug:             p.Slug,
+		ProblemTitle:     p.Title,
+		FunctionName:     workspace.FunctionName(p.Slug),
+		Description:      p.Description,
+		Difficulty:       p.Difficulty,
+		Topic:            p.Topic,
+		TargetComplexity: p.TargetComplexity,
+	}
+	data.Signature = "func " + data.FunctionName + "()"
+
+	for _, tag := range strings.Split(p.Tags, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			data.Tags = append(data.Tags, tag)
+		}
+	}
+
+	layout := workspace.ProblemLayout(p.Slug)
+	if file, fset := parseFirst(layout.ReferenceBoilerplate(), layout.Boilerplate()); file != nil {
+		if signature := findSignature(fset, file, data.FunctionName); signature != "" {
+			data.Signature = signature
+		}
+		data.Hints = findHints(file)
+	}
+	if file, fset := parseFirst(layout.ReferenceTests(), layout.Tests()); file != nil {
+		data.Examples = findExamples(fset, file, "Test"+data.FunctionName)
+	}
+
+	return data
+}
+
+// parseFirst parses the first of paths that exists and is valid Go
+func parseFirst(paths ...string) (*ast.File, *token.FileSet) {
+	for _, path := range paths {
+		source, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		fset := token.NewFileSet()
+		if file, err := parser.ParseFile(fset, path, source, parser.ParseComments); err == nil {
+			return file, fset
+		}
+	}
+	return nil, nil
+}
+
+// findSignature returns the signature of the named function, or ""
+func findSignature(fset *token.FileSet, file *ast.File, name string) string {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Name.Name != name {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := printer.Fprint(&buf, fset, &ast.FuncDecl{Name: fn.Name, Type: fn.Type}); err != nil {
+			return ""
+		}
+		return buf.String()
+	}
+	return ""
+}
+
+// findHints returns the text of "// Hint:" comments
+func findHints(file *ast.File) []string {
+	var hints []string
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			if hint, found := strings.CutPrefix(text, "Hint:"); found {
+				hints = append(hints, strings.TrimSpace(hint))
+			}
+		}
+	}
+	return hints
+}
+
+// findExamples returns the keyed cases of the table in the named test
+// function. Fields named want or expected are the expected value; name is
+// the case name and everything else is input.
+func findExamples(fset *token.FileSet, file *ast.File, testName string) []Example {
+	var examples []Example
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != testName || fn.Body == nil {
+			continue
+		}
+
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			if examples != nil {
+				return false
+			}
+			table, ok := node.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+			if _, isSlice := table.Type.(*ast.ArrayType); !isSlice {
+				return true
+			}
+			for _, elt := range table.Elts {
+				if row, ok := elt.(*ast.CompositeLit); ok {
+					if example, ok := exampleFromRow(fset, row); ok {
+						examples = append(examples, example)
+					}
+				}
+			}
+			return false
+		})
+	}
+	return examples
+}
+
+// exampleFromRow converts one keyed table row to an Example
+func exampleFromRow(fset *token.FileSet, row *ast.CompositeLit) (Example, bool) {
+	var example Example
+	var inputs []string
+	for _, elt := range row.Elts {
+		pair, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return Example{}, false
+		}
+		key, ok := pair.Key.(*ast.Ident)
+		if !ok {
+			return Example{}, false
+		}
+
+		var value bytes.Buffer
+		if err := printer.Fprint(&value, fset, pair.Value); err != nil {
+			return Example{}, false
+		}
+
+		switch key.Name {
+		case "name":
+			if name, err := strconv.Unquote(value.String()); err == nil {
+				example.Name = name
+			} else {
+				example.Name = value.String()
+			}
+		case "want", "expected":
+			example.Expected = value.String()
+		default:
+			inputs = append(inputs, key.Name+": "+value.String())
+		}
+	}
+	example.Input = strings.Join(inputs, ", ")
+	return example, true
+}
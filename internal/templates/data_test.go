@@ -0,0 +1,102 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBoilerplate = `package problems
+
+// TwoSum returns indices of two numbers that add up to target
+func TwoSum(nums []int, target int) []int {
+	// Hint: Use a hash map
+	return nil
+}
+`
+
+const testTests = `package problems
+
+import "testing"
+
+func TestTwoSum(t *testing.T) {
+	tests := []struct {
+		name   string
+		nums   []int
+		target int
+		want   []int
+	}{
+		{name: "example 1", nums: []int{2, 7, 11, 15}, target: 9, want: []int{0, 1}},
+		{name: "example 2", nums: []int{3, 3}, target: 6, want: []int{0, 1}},
+	}
+	_ = tests
+}
+`
+
+// chdirTemp switches to an empty temporary directory for the duration of a test
+func chdirTemp(t *testing.T) string {
+	dir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	return dir
+}
+
+func TestNewData(t *testing.T) {
+	problem := &database.Problem{
+		Slug:             "two-sum",
+		Title:            "Two Sum",
+		Difficulty:       "easy",
+		Topic:            "arrays",
+		Description:      "Find two numbers",
+		Tags:             "hash-table, two-pointers",
+		TargetComplexity: "O(n)",
+	}
+
+	t.Run("without reference files", func(t *testing.T) {
+		chdirTemp(t)
+
+		data := NewData(problem)
+		assert.Equal(t, "TwoSum", data.FunctionName)
+		assert.Equal(t, "func TwoSum()", data.Signature)
+		assert.Equal(t, []string{"hash-table", "two-pointers"}, data.Tags)
+		assert.Equal(t, "O(n)", data.TargetComplexity)
+		assert.Empty(t, data.Hints)
+		assert.Empty(t, data.Examples)
+	})
+
+	t.Run("reads signature, hints and examples", func(t *testing.T) {
+		dir := chdirTemp(t)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "problems", "templates"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "problems", "templates", "two_sum.go"), []byte(testBoilerplate), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "problems", "two_sum_test.go"), []byte(testTests), 0644))
+
+		data := NewData(problem)
+		assert.Equal(t, "func TwoSum(nums []int, target int) []int", data.Signature)
+		assert.Equal(t, []string{"Use a hash map"}, data.Hints)
+		assert.Equal(t, []Example{
+			{Name: "example 1", Input: "nums: []int{2, 7, 11, 15}, target: 9", Expected: "[]int{0, 1}"},
+			{Name: "example 2", Input: "nums: []int{3, 3}, target: 6", Expected: "[]int{0, 1}"},
+		}, data.Examples)
+	})
+}
+
+func TestDataComment(t *testing.T) {
+	comment := DataComment(TestGen)
+
+	assert.True(t, strings.HasPrefix(comment, "{{- /*"))
+	assert.True(t, strings.HasSuffix(comment, "*/ -}}\n"))
+	assert.Contains(t, comment, ".TestCases")
+	assert.Contains(t, comment, "formatValue")
+	assert.NotContains(t, comment, ".Variant")
+}
+
+func TestCommentFunc(t *testing.T) {
+	comment := Funcs()["comment"].(func(string) string)
+	assert.Equal(t, "// line one\n//\n// line two", comment("line one\n\nline two\n"))
+}
@@ -0,0 +1,272 @@
+// Package templates loads the code templates used to generate solution,
+// boilerplate and test files. Users can override any built-in template with a
+// file in ~/.dsa/templates (global) or .dsa/templates (workspace); the
+// workspace copy wins over the global one, which wins over the built-in.
+package templates
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+)
+
+//go:embed builtin/*.tmpl
+var builtinFS embed.FS
+
+// Template names
+const (
+	Solution    = "solution"    // solutions/<name>.go created by 'dsa solve'
+	Boilerplate = "boilerplate" // problems/<name>.go created by 'dsa add'
+	Test        = "test"        // problems/<name>_test.go created by 'dsa add'
+	TestGen     = "testgen"     // problems/<name>_test.go created by 'dsa test-gen'
+)
+
+// Scope is where a template is loaded from
+type Scope string
+
+// Template scopes, from lowest to highest precedence
+const (
+	ScopeBuiltin   Scope = "built-in"
+	ScopeGlobal    Scope = "global"
+	ScopeWorkspace Scope = "workspace"
+)
+
+// fileExtension is the extension of template files in override directories
+const fileExtension = ".tmpl"
+
+// ErrUnknownTemplate is returned for a template name that does not exist
+var ErrUnknownTemplate = errors.New("unknown template")
+
+// descriptions lists every template in display order
+var descriptions = []struct {
+	name        string
+	description string
+}{
+	{Solution, "Solution file created by 'dsa solve'"},
+	{Boilerplate, "Problem boilerplate created by 'dsa add'"},
+	{Test, "Problem test file created by 'dsa add'"},
+	{TestGen, "Table-driven test file created by 'dsa test-gen'"},
+}
+
+// Template is the effective source of one template
+type Template struct {
+	Name        string
+	Description string
+	Scope       Scope
+	Path        string // Override file; empty for a built-in template
+	Source      string
+}
+
+// Loader resolves templates from the override directories and the built-ins
+type Loader struct {
+	globalDir    string
+	workspaceDir string
+}
+
+// NewLoader creates a loader for ~/.dsa/templates and .dsa/templates in the
+// current directory
+func NewLoader() *Loader {
+	globalDir := ""
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		globalDir = filepath.Join(home, ".dsa", "templates")
+	}
+	return NewLoaderWithDirs(globalDir, workspace.Settings("templates"))
+}
+
+// NewLoaderWithDirs creates a loader with explicit override directories.
+// An empty directory disables that scope.
+func NewLoaderWithDirs(globalDir, workspaceDir string) *Loader {
+	return &Loader{globalDir: globalDir, workspaceDir: workspaceDir}
+}
+
+// Names returns every template name in display order
+func Names() []string {
+	names := make([]string, len(descriptions))
+	for i, entry := range descriptions {
+		names[i] = entry.name
+	}
+	return names
+}
+
+// describe returns the description of a template, or ErrUnknownTemplate
+func describe(name string) (string, error) {
+	for _, entry := range descriptions {
+		if entry.name == name {
+			return entry.description, nil
+		}
+	}
+	return "", fmt.Errorf("%w '%s' (available: %s)", ErrUnknownTemplate, name, strings.Join(Names(), ", "))
+}
+
+// Dir returns the override directory of a scope, or "" if it is disabled
+func (l *Loader) Dir(scope Scope) string {
+	switch scope {
+	case ScopeGlobal:
+		return l.globalDir
+	case ScopeWorkspace:
+		return l.workspaceDir
+	default:
+		return ""
+	}
+}
+
+// Builtin returns the built-in source of a template
+func (l *Loader) Builtin(name string) (*Template, error) {
+	description, err := describe(name)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := builtinFS.ReadFile("builtin/" + name + fileExtension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in template %s: %w", name, err)
+	}
+
+	return &Template{Name: name, Description: description, Scope: ScopeBuiltin, Source: string(source)}, nil
+}
+
+// Resolve returns the effective source of a template: the workspace override,
+// else the global override, else the built-in
+func (l *Loader) Resolve(name string) (*Template, error) {
+	return l.resolveBelow(name, "")
+}
+
+// resolveBelow resolves a template ignoring scopes at or above ceiling. An
+// empty ceiling considers every scope.
+func (l *Loader) resolveBelow(name string, ceiling Scope) (*Template, error) {
+	builtin, err := l.Builtin(name)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := []Scope{ScopeWorkspace, ScopeGlobal}
+	switch ceiling {
+	case ScopeWorkspace:
+		scopes = []Scope{ScopeGlobal}
+	case ScopeGlobal:
+		scopes = nil
+	}
+
+	for _, scope := range scopes {
+		path := l.path(scope, name)
+		if path == "" {
+			continue
+		}
+		source, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+		}
+		return &Template{Name: name, Description: builtin.Description, Scope: scope, Path: path, Source: string(source)}, nil
+	}
+
+	return builtin, nil
+}
+
+// path returns the override file of a template in a scope, or "" if the
+// scope is disabled
+func (l *Loader) path(scope Scope, name string) string {
+	dir := l.Dir(scope)
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, name+fileExtension)
+}
+
+// List returns the effective source of every template in display order
+func (l *Loader) List() ([]*Template, error) {
+	var list []*Template
+	for _, name := range Names() {
+		tmpl, err := l.Resolve(name)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, tmpl)
+	}
+	return list, nil
+}
+
+// Parse resolves and parses a template. Every template can use the helper
+// functions documented in Funcs; extra adds functions for one template.
+func (l *Loader) Parse(name string, extra template.FuncMap) (*template.Template, error) {
+	tmpl, err := l.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := template.New(name).Funcs(Funcs()).Funcs(extra).Parse(tmpl.Source)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s template %s: %w", tmpl.Scope, tmpl.origin(), err)
+	}
+	return parsed, nil
+}
+
+// origin describes where a template came from for error messages
+func (t *Template) origin() string {
+	if t.Path != "" {
+		return t.Path
+	}
+	return t.Name
+}
+
+// Override creates the override file of a template in a scope, starting from
+// the template it overrides and a comment documenting the template data.
+// An existing override is left alone. Returns the file and whether it was created.
+func (l *Loader) Override(name string, scope Scope) (string, bool, error) {
+	path := l.path(scope, name)
+	if path == "" {
+		return "", false, fmt.Errorf("no %s template directory", scope)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return path, false, nil
+	}
+
+	base, err := l.resolveBelow(name, scope)
+	if err != nil {
+		return "", false, err
+	}
+
+	source := base.Source
+	if base.Scope == ScopeBuiltin {
+		source = DataComment(name) + source
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", false, fmt.Errorf("failed to create template directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		return "", false, fmt.Errorf("failed to write template: %w", err)
+	}
+
+	return path, true, nil
+}
+
+// Reset removes the override of a template in a scope. Returns the removed
+// file, or "" if the scope had no override.
+func (l *Loader) Reset(name string, scope Scope) (string, error) {
+	if _, err := describe(name); err != nil {
+		return "", err
+	}
+
+	path := l.path(scope, name)
+	if path == "" {
+		return "", nil
+	}
+
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to remove template: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,191 @@
+package templates
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLoader creates a loader with empty global and workspace directories
+func newTestLoader(t *testing.T) *Loader {
+	root := t.TempDir()
+	return NewLoaderWithDirs(filepath.Join(root, "global"), filepath.Join(root, "workspace"))
+}
+
+// writeOverride writes an override template in a scope
+func writeOverride(t *testing.T, loader *Loader, scope Scope, name, source string) {
+	require.NoError(t, os.MkdirAll(loader.Dir(scope), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(loader.Dir(scope), name+".tmpl"), []byte(source), 0644))
+}
+
+func TestBuiltin(t *testing.T) {
+	loader := newTestLoader(t)
+
+	for _, name := range Names() {
+		tmpl, err := loader.Builtin(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, ScopeBuiltin, tmpl.Scope)
+		assert.NotEmpty(t, tmpl.Source)
+		assert.NotEmpty(t, tmpl.Description)
+	}
+
+	_, err := loader.Builtin("missing")
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("falls back to the built-in", func(t *testing.T) {
+		loader := newTestLoader(t)
+
+		tmpl, err := loader.Resolve(Solution)
+		require.NoError(t, err)
+		assert.Equal(t, ScopeBuiltin, tmpl.Scope)
+		assert.Empty(t, tmpl.Path)
+	})
+
+	t.Run("global overrides built-in", func(t *testing.T) {
+		loader := newTestLoader(t)
+		writeOverride(t, loader, ScopeGlobal, Solution, "global")
+
+		tmpl, err := loader.Resolve(Solution)
+		require.NoError(t, err)
+		assert.Equal(t, ScopeGlobal, tmpl.Scope)
+		assert.Equal(t, "global", tmpl.Source)
+	})
+
+	t.Run("workspace overrides global", func(t *testing.T) {
+		loader := newTestLoader(t)
+		writeOverride(t, loader, ScopeGlobal, Solution, "global")
+		writeOverride(t, loader, ScopeWorkspace, Solution, "workspace")
+
+		tmpl, err := loader.Resolve(Solution)
+		require.NoError(t, err)
+		assert.Equal(t, ScopeWorkspace, tmpl.Scope)
+		assert.Equal(t, "workspace", tmpl.Source)
+		assert.Equal(t, filepath.Join(loader.Dir(ScopeWorkspace), "solution.tmpl"), tmpl.Path)
+	})
+
+	t.Run("disabled scopes are skipped", func(t *testing.T) {
+		loader := NewLoaderWithDirs("", "")
+
+		tmpl, err := loader.Resolve(TestGen)
+		require.NoError(t, err)
+		assert.Equal(t, ScopeBuiltin, tmpl.Scope)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("provides helper and extra functions", func(t *testing.T) {
+		loader := newTestLoader(t)
+		writeOverride(t, loader, ScopeGlobal, Solution, `{{join .Tags ", "}}|{{upper .Slug}}|{{shout}}`)
+
+		parsed, err := loader.Parse(Solution, map[string]any{"shout": func() string { return "!" }})
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, parsed.Execute(&buf, Data{Slug: "two-sum", Tags: []string{"a", "b"}}))
+		assert.Equal(t, "a, b|TWO-SUM|!", buf.String())
+	})
+
+	t.Run("reports the file of a broken override", func(t *testing.T) {
+		loader := newTestLoader(t)
+		writeOverride(t, loader, ScopeWorkspace, Boilerplate, "{{.Slug")
+
+		_, err := loader.Parse(Boilerplate, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), filepath.Join(loader.Dir(ScopeWorkspace), "boilerplate.tmpl"))
+	})
+
+	t.Run("built-in templates render", func(t *testing.T) {
+		loader := newTestLoader(t)
+		data := Data{Slug: "two-sum", FunctionName: "TwoSum", ProblemTitle: "Two Sum", Hints: []string{"Use a map"}}
+
+		parsed, err := loader.Parse(Solution, nil)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, parsed.Execute(&buf, data))
+		assert.Contains(t, buf.String(), "func TwoSum()")
+		assert.Contains(t, buf.String(), "// - Use a map")
+	})
+}
+
+func TestOverride(t *testing.T) {
+	t.Run("starts from the built-in with a data comment", func(t *testing.T) {
+		loader := newTestLoader(t)
+
+		path, created, err := loader.Override(Solution, ScopeGlobal)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		builtin, _ := loader.Builtin(Solution)
+		assert.Equal(t, DataComment(Solution)+builtin.Source, string(content))
+
+		// The comment renders as nothing
+		parsed, err := loader.Parse(Solution, nil)
+		require.NoError(t, err)
+		var rendered bytes.Buffer
+		require.NoError(t, parsed.Execute(&rendered, Data{FunctionName: "TwoSum"}))
+		assert.True(t, bytes.HasPrefix(rendered.Bytes(), []byte("package solutions")))
+	})
+
+	t.Run("workspace override starts from the global one", func(t *testing.T) {
+		loader := newTestLoader(t)
+		writeOverride(t, loader, ScopeGlobal, Solution, "house style")
+
+		path, created, err := loader.Override(Solution, ScopeWorkspace)
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "house style", string(content))
+	})
+
+	t.Run("keeps an existing override", func(t *testing.T) {
+		loader := newTestLoader(t)
+		writeOverride(t, loader, ScopeGlobal, Solution, "mine")
+
+		path, created, err := loader.Override(Solution, ScopeGlobal)
+		require.NoError(t, err)
+		assert.False(t, created)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "mine", string(content))
+	})
+
+	t.Run("rejects unknown templates and disabled scopes", func(t *testing.T) {
+		_, _, err := newTestLoader(t).Override("missing", ScopeGlobal)
+		assert.ErrorIs(t, err, ErrUnknownTemplate)
+
+		_, _, err = NewLoaderWithDirs("", "").Override(Solution, ScopeGlobal)
+		assert.Error(t, err)
+	})
+}
+
+func TestReset(t *testing.T) {
+	loader := newTestLoader(t)
+	writeOverride(t, loader, ScopeGlobal, Solution, "global")
+	writeOverride(t, loader, ScopeWorkspace, Solution, "workspace")
+
+	removed, err := loader.Reset(Solution, ScopeWorkspace)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(loader.Dir(ScopeWorkspace), "solution.tmpl"), removed)
+
+	tmpl, err := loader.Resolve(Solution)
+	require.NoError(t, err)
+	assert.Equal(t, ScopeGlobal, tmpl.Scope)
+
+	removed, err = loader.Reset(Solution, ScopeWorkspace)
+	require.NoError(t, err)
+	assert.Empty(t, removed)
+
+	_, err = loader.Reset("missing", ScopeGlobal)
+	assert.ErrorIs(t, err, ErrUnknownTemplate)
+}
@@ -0,0 +1,337 @@
+package testgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+)
+
+// testTable is the table-driven test of a parsed test file
+type testTable struct {
+	fset   *token.FileSet
+	file   *ast.File
+	lit    *ast.CompositeLit // The slice literal holding the cases
+	fields []string
+	rows   []*ast.CompositeLit
+	names  []string // Case names, one per row
+	keys   []string // Inputs in a canonical form, one per row
+	modes  []string // Comparisons, one per row
+
+	// Comparisons the test's assertions handle: the one of a table without
+	// a compare column, else those of its rows
+	handled []string
+}
+
+// parseTable parses Go test source and reads its table of test cases
+func parseTable(code []byte) (*testTable, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Go file: %w", err)
+	}
+	fn, lit, fields := findTable(file)
+	if lit == nil {
+		return nil, fmt.Errorf("no table of test cases found")
+	}
+
+	table := &testTable{fset: fset, file: file, lit: lit, fields: fields}
+	types := map[string]ast.Expr{}
+	for _, field := range lit.Type.(*ast.ArrayType).Elt.(*ast.StructType).Fields.List {
+		for _, name := range field.Names {
+			types[name.Name] = field.Type
+		}
+	}
+
+	// A table without a compare column compares every case the same way
+	mode := ""
+	if !slices.Contains(fields, "compare") {
+		if mode = tableCompare(fn); mode == "" {
+			mode = "exact"
+		}
+		table.handled = []string{mode}
+	}
+
+	for i, elt := range lit.Elts {
+		row, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			return nil, fmt.Errorf("test case %d is not a struct literal", i+1)
+		}
+		values, err := rowFields(fset, row, fields)
+		if err != nil {
+			return nil, fmt.Errorf("test case %d: %w", i+1, err)
+		}
+
+		var name string
+		if expr, ok := values["name"]; ok {
+			value, _ := exprValue(fset, expr)
+			name, _ = value.(string)
+		}
+		rowMode := mode
+		if rowMode == "" {
+			rowMode = "exact"
+			if expr, ok := values["compare"]; ok {
+				value, _ := exprValue(fset, expr)
+				if compare, _ := value.(string); compare != "" {
+					rowMode = compare
+				}
+			}
+		}
+
+		var key []string
+		for _, field := range fields {
+			if !isInput(field) {
+				continue
+			}
+			if expr, ok := values[field]; ok {
+				key = append(key, inputKey(fset, expr))
+			} else {
+				key = append(key, zeroKey(types[field]))
+			}
+		}
+
+		table.rows = append(table.rows, row)
+		table.names = append(table.names, name)
+		table.keys = append(table.keys, strings.Join(key, ", "))
+		table.modes = append(table.modes, rowMode)
+		if mode == "" && !slices.Contains(table.handled, rowMode) {
+			table.handled = append(table.handled, rowMode)
+		}
+	}
+	return table, nil
+}
+
+// inputKey writes an input in a canonical form, so literals of the same
+// value compare equal however they are written, e.g. a list built from
+// notation and the same list as an array. Expressions that are not test
+// values are compared as source.
+func inputKey(fset *token.FileSet, expr ast.Expr) string {
+	if value, err := exprValue(fset, expr); err == nil {
+		if data, err := json.Marshal(value); err == nil {
+			return string(data)
+		}
+	}
+	return source(fset, expr)
+}
+
+// zeroKey is the key of an input left out of a row, the zero value of its
+// type, so a missing pointer matches an explicit nil
+func zeroKey(typ ast.Expr) string {
+	switch typ := typ.(type) {
+	case *ast.StarExpr, *ast.MapType, *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+		return "null"
+	case *ast.ArrayType:
+		if typ.Len == nil {
+			return "null"
+		}
+	case *ast.Ident:
+		switch {
+		case typ.Name == "string":
+			return `""`
+		case typ.Name == "bool":
+			return "false"
+		case typ.Name == "any" || typ.Name == "error":
+			return "null"
+		case strings.HasPrefix(typ.Name, "int"), strings.HasPrefix(typ.Name, "uint"),
+			strings.HasPrefix(typ.Name, "float"), typ.Name == "byte", typ.Name == "rune":
+			return "0"
+		}
+	}
+	return "<zero>"
+}
+
+// appendTests adds test cases to the table of an existing test file and
+// returns the updated file and how many cases were added. Cases whose inputs
+// the table already has are skipped and names are made unique; the rest of
+// the file, including tests and comments written by hand, is kept.
+func (g *Generator) appendTests(existing []byte, prob *problem.ProblemDetails, newTestCases []*TestCase) ([]byte, int, error) {
+	table, err := parseTable(existing)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The new cases must use the comparisons the test's assertions handle.
+	// A table with a compare column gets one in the new rows too.
+	for _, tc := range newTestCases {
+		mode := tc.Compare
+		if mode == "" {
+			mode = "exact"
+		}
+		if !slices.Contains(table.handled, mode) {
+			return nil, 0, fmt.Errorf("test '%s' is compared with %s, which the existing tests do not handle", tc.Name, mode)
+		}
+	}
+	g.compareColumn = slices.Contains(table.fields, "compare")
+	defer func() { g.compareColumn = false }()
+
+	// Render the new cases alone to learn their rows' fields and inputs
+	rendered, err := g.render(prob, newTestCases)
+	if err != nil {
+		return nil, 0, err
+	}
+	generated, err := parseTable(rendered)
+	if err != nil {
+		return nil, 0, fmt.Errorf("generated tests: %w", err)
+	}
+	if !slices.Equal(table.fields, generated.fields) {
+		return nil, 0, fmt.Errorf("its table has the fields %s, but the generated one has %s",
+			strings.Join(table.fields, ", "), strings.Join(generated.fields, ", "))
+	}
+
+	seen := map[string]bool{}
+	for _, key := range table.keys {
+		seen[key] = true
+	}
+	names := map[string]bool{}
+	for _, name := range table.names {
+		names[name] = true
+	}
+
+	var kept []*TestCase
+	for i, tc := range newTestCases {
+		if seen[generated.keys[i]] {
+			continue
+		}
+		seen[generated.keys[i]] = true
+
+		unique := *tc
+		unique.Name = uniqueName(tc.Name, names)
+		names[unique.Name] = true
+		kept = append(kept, &unique)
+	}
+	if len(kept) == 0 {
+		return existing, 0, nil
+	}
+
+	if rendered, err = g.render(prob, kept); err != nil {
+		return nil, 0, err
+	}
+	if generated, err = parseTable(rendered); err != nil {
+		return nil, 0, fmt.Errorf("generated tests: %w", err)
+	}
+
+	updated := table.insertRows(existing, generated.rowSource(rendered))
+	updated, err = addImports(updated, generated.imports())
+	if err != nil {
+		return nil, 0, err
+	}
+	formatted, err := format.Source(updated)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to format Go code: %w", err)
+	}
+	return formatted, len(kept), nil
+}
+
+// uniqueName returns name, or name with a number appended if it is taken
+func uniqueName(name string, taken map[string]bool) string {
+	unique := name
+	for n := 2; taken[unique]; n++ {
+		unique = fmt.Sprintf("%s (%d)", name, n)
+	}
+	return unique
+}
+
+// rowSource returns the source of the table's rows, each followed by a comma
+func (t *testTable) rowSource(code []byte) string {
+	var rows strings.Builder
+	for _, row := range t.rows {
+		start, end := t.fset.Position(row.Pos()).Offset, t.fset.Position(row.End()).Offset
+		rows.Write(code[start:end])
+		rows.WriteString(",\n")
+	}
+	return rows.String()
+}
+
+// insertRows inserts rows at the end of the table, after any comments
+func (t *testTable) insertRows(code []byte, rows string) []byte {
+	insert := t.fset.Position(t.lit.Rbrace).Offset
+	lineStart := bytes.LastIndexByte(code[:insert], '\n') + 1
+	if len(bytes.TrimSpace(code[lineStart:insert])) == 0 {
+		insert = lineStart
+	} else {
+		rows = "\n" + rows
+	}
+
+	// The last row needs a comma before rows can follow it
+	comma := -1
+	if n := len(t.lit.Elts); n > 0 {
+		end := t.fset.Position(t.lit.Elts[n-1].End()).Offset
+		if !bytes.HasPrefix(bytes.TrimLeft(code[end:], " \t\r\n"), []byte(",")) {
+			comma = end
+		}
+	}
+
+	var updated []byte
+	if comma >= 0 {
+		updated = append(updated, code[:comma]...)
+		updated = append(updated, ',')
+		updated = append(updated, code[comma:insert]...)
+	} else {
+		updated = append(updated, code[:insert]...)
+	}
+	updated = append(updated, rows...)
+	return append(updated, code[insert:]...)
+}
+
+// imports returns the import specs of the file, keyed by path
+func (t *testTable) imports() map[string]string {
+	imports := map[string]string{}
+	for _, spec := range t.file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		imports[path] = source(t.fset, spec)
+	}
+	return imports
+}
+
+// addImports adds the imports a file is missing. New rows may use packages
+// the existing tests do not, such as the structures package.
+func addImports(code []byte, imports map[string]string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Go file: %w", err)
+	}
+	existing := map[string]bool{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		existing[path] = true
+	}
+
+	var missing []string
+	for path, spec := range imports {
+		if !existing[path] {
+			missing = append(missing, "\t"+spec+"\n")
+		}
+	}
+	if len(missing) == 0 {
+		return code, nil
+	}
+	slices.Sort(missing)
+
+	// At the end of the first parenthesized import block, else in a new
+	// block after the package clause
+	insert, text := fset.Position(file.Name.End()).Offset, "\n\nimport (\n"+strings.Join(missing, "")+")"
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && gen.Lparen.IsValid() {
+			insert, text = fset.Position(gen.Rparen).Offset, strings.Join(missing, "")
+			if lineStart := bytes.LastIndexByte(code[:insert], '\n') + 1; len(bytes.TrimSpace(code[lineStart:insert])) == 0 {
+				insert = lineStart
+			} else {
+				text = "\n" + text
+			}
+			break
+		}
+	}
+
+	updated := append([]byte{}, code[:insert]...)
+	updated = append(updated, text...)
+	return append(updated, code[insert:]...), nil
+}
@@ -0,0 +1,135 @@
+package testgen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Append(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "solutions"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "problems"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "solutions", "max_profit.go"), []byte(signatureSource), 0644))
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	require.NoError(t, os.Chdir(tmpDir))
+
+	prob := &problem.ProblemDetails{Problem: database.Problem{Slug: "max-profit", Title: "Max Profit"}}
+	testFile := filepath.Join("problems", "max_profit_test.go")
+	newCase := func(name string, price, fee string) *TestCase {
+		return &TestCase{Name: name, Inputs: []interface{}{[]interface{}{json.Number(price)}, json.Number(fee)}, Expected: json.Number("0")}
+	}
+
+	t.Run("skips duplicates and keeps the file", func(t *testing.T) {
+		require.NoError(t, NewGenerator().Generate(prob, []*TestCase{newCase("one", "1", "2")}, false))
+		content, err := os.ReadFile(testFile)
+		require.NoError(t, err)
+		edited := strings.Replace(string(content), "\t\t{\n\t\t\tname:", "\t\t// Cases from the shared file\n\t\t{\n\t\t\tname:", 1) +
+			"\n// Written by hand\nfunc TestMaxProfitEmpty(t *testing.T) {}\n"
+		require.NoError(t, os.WriteFile(testFile, []byte(edited), 0644))
+
+		testCases := []*TestCase{newCase("one", "1", "2"), newCase("one", "5", "2"), newCase("two", "5", "2.0")}
+		require.NoError(t, NewGenerator().Generate(prob, testCases, true))
+
+		content, err = os.ReadFile(testFile)
+		require.NoError(t, err)
+		source := string(content)
+		assert.Contains(t, source, "// Cases from the shared file")
+		assert.Contains(t, source, "func TestMaxProfitEmpty(t *testing.T) {}")
+
+		appended, err := ParseTestFile(testFile)
+		require.NoError(t, err)
+		require.Len(t, appended, 2, "cases with inputs already in the table are skipped")
+		assert.Equal(t, "one (2)", appended[1].Name)
+
+		require.NoError(t, NewGenerator().Generate(prob, testCases, true))
+		again, err := os.ReadFile(testFile)
+		require.NoError(t, err)
+		assert.Equal(t, source, string(again), "appending the same cases twice changes nothing")
+	})
+
+	t.Run("positional rows without a trailing comma", func(t *testing.T) {
+		existing := `package problems
+
+import "testing"
+
+func TestMaxProfit(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices []int64
+		fee    int
+		want   int64
+	}{{"a", []int64{1}, 2, 0}}
+	for _, tt := range tests {
+		if got := MaxProfit(tt.prices, tt.fee); got != tt.want {
+			t.Errorf("got %d", got)
+		}
+	}
+}
+`
+		require.NoError(t, os.WriteFile(testFile, []byte(existing), 0644))
+		require.NoError(t, NewGenerator().Generate(prob, []*TestCase{newCase("a", "3", "2")}, true))
+
+		appended, err := ParseTestFile(testFile)
+		require.NoError(t, err)
+		require.Len(t, appended, 2)
+		assert.Equal(t, "a (2)", appended[1].Name)
+	})
+
+	t.Run("comparisons the test does not handle", func(t *testing.T) {
+		tc := newCase("unordered", "9", "2")
+		tc.Compare = "validProfit"
+		err := NewGenerator().Generate(prob, []*TestCase{tc}, true)
+		assert.ErrorContains(t, err, "test 'unordered' is compared with validProfit, which the existing tests do not handle")
+	})
+}
+
+func TestGenerator_Append_AddsImports(t *testing.T) {
+	newNotationWorkspace(t)
+
+	existing := `package problems
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvertTree(t *testing.T) {
+	tests := []struct {
+		name string
+		root *TreeNode
+		want *TreeNode
+	}{
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, InvertTree(tt.root))
+	}
+}
+`
+	testFile := filepath.Join("problems", "invert_tree_test.go")
+	require.NoError(t, os.WriteFile(testFile, []byte(existing), 0644))
+
+	prob := &problem.ProblemDetails{Problem: database.Problem{Slug: "invert-tree", Title: "Invert Tree"}}
+	testCases := []*TestCase{
+		{Name: "empty again", Inputs: []interface{}{nil}, Expected: nil},
+		{Name: "one", Inputs: []interface{}{"[1]"}, Expected: "[1]"},
+	}
+	require.NoError(t, NewGenerator().Generate(prob, testCases, true))
+
+	content, err := os.ReadFile(testFile)
+	require.NoError(t, err)
+	source := string(content)
+	assert.Contains(t, source, "\t\"example.com/practice/structures\"\n\t\"github.com/stretchr/testify/assert\"\n)")
+	assert.Contains(t, source, `root: structures.MustParse[*TreeNode]("[1]"),`)
+	assert.NotContains(t, source, "empty again", "a missing field is nil, like null")
+}
@@ -0,0 +1,205 @@
+package testgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/snippet"
+	"github.com/ak95asb/dsa-dojo/structures"
+)
+
+// compareDirective returns the comparison named by a "//dsa:compare <mode>"
+// line in a function's doc comment
+func compareDirective(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	for _, comment := range doc.List {
+		if mode, ok := strings.CutPrefix(comment.Text, "//dsa:compare "); ok {
+			return strings.TrimSpace(mode)
+		}
+	}
+	return ""
+}
+
+// CheckCompare returns an error unless mode is a comparison of the
+// structures package (exact, unordered, unordered-nested, set or
+// float-tolerance:<tolerance>) or the name of a checker function
+func CheckCompare(mode string) error {
+	if _, _, err := structures.ParseMode(mode); err != nil && !isChecker(mode) {
+		return fmt.Errorf("%w, or the name of a checker function", err)
+	}
+	return nil
+}
+
+// isChecker reports whether a comparison names a custom checker function
+// rather than a built-in mode
+func isChecker(mode string) bool {
+	_, _, err := structures.ParseMode(mode)
+	return err != nil && token.IsIdentifier(mode)
+}
+
+// resolveCompare sets the comparison of each test case that has none to
+// fallback, then to the signature's directive, then to exact
+func resolveCompare(sig *Signature, testCases []*TestCase, fallback string) error {
+	for _, tc := range testCases {
+		for _, mode := range []string{fallback, sig.Compare, structures.Exact} {
+			if tc.Compare != "" {
+				break
+			}
+			tc.Compare = mode
+		}
+		if err := CheckCompare(tc.Compare); err != nil {
+			return fmt.Errorf("test '%s': %w", tc.Name, err)
+		}
+	}
+	return nil
+}
+
+// checks returns the assertions of each comparison used by the test cases,
+// in order of first use
+func (s *Signature) checks(testCases []*TestCase) ([]Check, error) {
+	var checks []Check
+	seen := map[string]bool{}
+	for _, tc := range testCases {
+		if seen[tc.Compare] {
+			continue
+		}
+		seen[tc.Compare] = true
+		assertions, err := s.Assertions(tc.Compare)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, Check{Mode: tc.Compare, Assertions: assertions})
+	}
+	return checks, nil
+}
+
+// Assertions returns the statements comparing the call's results with the
+// expected values under a comparison mode. Exact comparisons use
+// assert.Equal, other modes structures.Match and checkers are called with the
+// expected values, the results and the inputs.
+func (s *Signature) Assertions(mode string) ([]string, error) {
+	_, results := s.Columns()
+	got := s.Got()
+
+	name, _, err := structures.ParseMode(mode)
+	if err != nil && !isChecker(mode) {
+		return nil, err
+	}
+	if err == nil && name == structures.Exact {
+		assertions := make([]string, len(results))
+		for i, result := range results {
+			if result.Format != "" {
+				assertions[i] = fmt.Sprintf("assert.Equal(t, %s(tt.%s), %s(%s))", result.Format, result.Name, result.Format, got[i])
+			} else {
+				assertions[i] = fmt.Sprintf("assert.Equal(t, tt.%s, %s)", result.Name, got[i])
+			}
+		}
+		return assertions, nil
+	}
+
+	if s.structuresPath == "" {
+		return nil, fmt.Errorf("comparison %q needs the workspace's structures package: %w", mode, s.structuresErr)
+	}
+	s.imports[s.structuresPath] = true
+
+	if err == nil {
+		assertions := make([]string, len(results))
+		for i, result := range results {
+			assertions[i] = fmt.Sprintf(`assert.True(t, structures.Match(%q, tt.%s, %s), "%s: want %%s, got %%s", structures.Format(tt.%s), structures.Format(%s))`,
+				mode, result.Name, got[i], mode, result.Name, got[i])
+		}
+		return assertions, nil
+	}
+
+	var args, wantVerbs, gotVerbs, wantValues, gotValues []string
+	for i, result := range results {
+		args = append(args, "tt."+result.Name)
+		wantVerbs = append(wantVerbs, "%s")
+		wantValues = append(wantValues, fmt.Sprintf("structures.Format(tt.%s)", result.Name))
+		gotVerbs = append(gotVerbs, "%s")
+		gotValues = append(gotValues, fmt.Sprintf("structures.Format(%s)", got[i]))
+	}
+	args = append(args, got...)
+	for _, param := range s.Params {
+		args = append(args, "tt."+param.Name)
+	}
+	return []string{fmt.Sprintf(`assert.True(t, %s(%s), "%s rejected %s (want %s)", %s)`,
+		mode, strings.Join(args, ", "), mode, strings.Join(gotVerbs, ", "), strings.Join(wantVerbs, ", "),
+		strings.Join(append(gotValues, wantValues...), ", "))}, nil
+}
+
+// writeCheckers adds a stub for each checker the tests call that neither the
+// solution nor the problem's checkers file declares. A stub compares exactly
+// until the user rewrites it.
+func (s *Signature) writeCheckers(path string, checks []Check) error {
+	for _, check := range checks {
+		if !isChecker(check.Mode) || (s.pkg != nil && s.pkg.Scope().Lookup(check.Mode) != nil) {
+			continue
+		}
+
+		stub := s.checkerStub(check.Mode)
+		updated := stub
+		if data, err := os.ReadFile(path); err == nil {
+			var result *snippet.InsertResult
+			updated, result, err = snippet.Insert(string(data), snippet.Parse(check.Mode, stub))
+			if err != nil {
+				return fmt.Errorf("failed to add checker %s to %s: %w", check.Mode, path, err)
+			}
+			if len(result.Added) == 0 {
+				continue
+			}
+		}
+		if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+			return fmt.Errorf("failed to write checker %s: %w", check.Mode, err)
+		}
+		fmt.Printf("📝 Added checker stub %s to %s; edit it to accept every valid answer\n", check.Mode, path)
+	}
+	return nil
+}
+
+// checkerStub returns the source of a checker function taking the expected
+// values, the results and the inputs
+func (s *Signature) checkerStub(name string) string {
+	var params, wants, gots []string
+	for i, result := range s.Results {
+		gotName := "got"
+		if len(s.Results) > 1 {
+			gotName = fmt.Sprintf("got%d", i+1)
+		}
+		wants = append(wants, result.Name)
+		gots = append(gots, gotName)
+		params = append(params, result.Name+" "+s.TypeString(result.Type))
+	}
+	for i, result := range s.Results {
+		params = append(params, gots[i]+" "+s.TypeString(result.Type))
+	}
+	for _, param := range s.Params {
+		params = append(params, param.Name+" "+s.TypeString(param.Type))
+	}
+
+	want, got := "nil", "nil"
+	switch {
+	case len(s.Results) == 1:
+		want, got = wants[0], gots[0]
+	case len(s.Results) > 1:
+		want = "[]any{" + strings.Join(wants, ", ") + "}"
+		got = "[]any{" + strings.Join(gots, ", ") + "}"
+	}
+
+	return fmt.Sprintf(`package problems
+
+import "%s"
+
+// %s reports whether got is a correct result of %s for the inputs.
+// Tests compared with %q call it. Until rewritten to accept every valid
+// answer, it compares exactly.
+func %s(%s) bool {
+	return structures.Match(structures.Exact, %s, %s)
+}
+`, s.structuresPath, name, s.FuncName, name, name, strings.Join(params, ", "), want, got)
+}
@@ -0,0 +1,102 @@
+package testgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const compareSource = `package solutions
+
+// GroupAnagrams groups words that are anagrams of each other
+//
+//dsa:compare unordered-nested
+func GroupAnagrams(strs []string) [][]string { return nil }
+`
+
+func TestCheckCompare(t *testing.T) {
+	for _, mode := range []string{"exact", "unordered", "unordered-nested", "set", "float-tolerance", "float-tolerance:1e-6", "validGroups"} {
+		assert.NoError(t, CheckCompare(mode), mode)
+	}
+	assert.ErrorContains(t, CheckCompare("float-tolerance:x"), `invalid tolerance "x"`)
+	assert.ErrorContains(t, CheckCompare("sorted-desc"), "or the name of a checker function")
+}
+
+func TestGenerator_Generate_Compare(t *testing.T) {
+	dir := t.TempDir()
+	_, err := workspace.Init(dir, "example.com/practice")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "solutions", "group_anagrams.go"), []byte(compareSource), 0644))
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	require.NoError(t, os.Chdir(dir))
+
+	prob := &problem.ProblemDetails{Problem: database.Problem{Slug: "group-anagrams", Title: "Group Anagrams"}}
+	testFile := filepath.Join("problems", "group_anagrams_test.go")
+	newCases := func(compare ...string) []*TestCase {
+		var testCases []*TestCase
+		for _, mode := range compare {
+			testCases = append(testCases, &TestCase{
+				Name:     "case " + mode,
+				Inputs:   []interface{}{[]interface{}{"eat", "tea", "bat"}},
+				Expected: []interface{}{[]interface{}{"bat"}, []interface{}{"eat", "tea"}},
+				Compare:  mode,
+			})
+		}
+		return testCases
+	}
+
+	t.Run("directive", func(t *testing.T) {
+		require.NoError(t, NewGenerator().Generate(prob, newCases(""), false))
+		content, err := os.ReadFile(testFile)
+		require.NoError(t, err)
+		source := string(content)
+		assert.Contains(t, source, `assert.True(t, structures.Match("unordered-nested", tt.want, got),`)
+		assert.Contains(t, source, `"example.com/practice/structures"`)
+		assert.NotContains(t, source, "compare:")
+	})
+
+	t.Run("generator default overrides directive", func(t *testing.T) {
+		generator := NewGenerator()
+		generator.compare = "exact"
+		require.NoError(t, generator.Generate(prob, newCases(""), false))
+		content, err := os.ReadFile(testFile)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "assert.Equal(t, tt.want, got)")
+	})
+
+	t.Run("mixed comparisons and a checker", func(t *testing.T) {
+		require.NoError(t, NewGenerator().Generate(prob, newCases("", "exact", "validGroups"), false))
+		content, err := os.ReadFile(testFile)
+		require.NoError(t, err)
+		source := string(content)
+		assert.Contains(t, source, "compare string")
+		assert.Contains(t, source, `compare: "validGroups",`)
+		assert.Contains(t, source, "switch tt.compare {")
+		assert.Contains(t, source, `case "unordered-nested":`)
+		assert.Contains(t, source, `assert.True(t, validGroups(tt.want, got, tt.strs), "validGroups rejected %s (want %s)", structures.Format(got), structures.Format(tt.want))`)
+
+		checks := workspace.ProblemLayout(prob.Slug).Checks()
+		stub, err := os.ReadFile(checks)
+		require.NoError(t, err)
+		assert.Contains(t, string(stub), "func validGroups(want [][]string, got [][]string, strs []string) bool {")
+
+		// An existing checker is left alone
+		require.NoError(t, os.WriteFile(checks, []byte("package problems\n\nfunc validGroups(want, got [][]string, strs []string) bool { return true }\n"), 0644))
+		require.NoError(t, NewGenerator().Generate(prob, newCases("validGroups"), false))
+		stub, err = os.ReadFile(checks)
+		require.NoError(t, err)
+		assert.Contains(t, string(stub), "return true")
+	})
+
+	t.Run("invalid comparison names the test", func(t *testing.T) {
+		err := NewGenerator().Generate(prob, newCases("float-tolerance:x"), false)
+		assert.ErrorContains(t, err, "test 'case float-tolerance:x': invalid tolerance")
+	})
+}
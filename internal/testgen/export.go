@@ -0,0 +1,173 @@
+package testgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Export formats
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// ExportFormat returns the export format for an output file's extension,
+// JSON unless it is .yaml or .yml
+func ExportFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	}
+	return FormatJSON
+}
+
+// Export writes test cases in the JSON or YAML test file format, so they can
+// be imported again with --from-file. A comparison shared by every case is
+// written once for the file.
+func Export(w io.Writer, testCases []*TestCase, format string) error {
+	file := exportFile(testCases)
+
+	var data []byte
+	var err error
+	switch format {
+	case FormatJSON:
+		data, err = exportJSON(file)
+	case FormatYAML:
+		data, err = exportYAML(file)
+	default:
+		return fmt.Errorf("unknown export format %q (use %s or %s)", format, FormatJSON, FormatYAML)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", format, err)
+	}
+
+	_, err = w.Write(data)
+	return err
+}
+
+// exportFile converts test cases to the test file structure
+func exportFile(testCases []*TestCase) *JSONTestFile {
+	file := &JSONTestFile{Tests: make([]JSONTestCase, len(testCases))}
+
+	shared := len(testCases) > 0
+	for _, tc := range testCases {
+		shared = shared && tc.Compare == testCases[0].Compare
+	}
+	if shared && testCases[0].Compare != "exact" {
+		file.Compare = testCases[0].Compare
+	}
+
+	for i, tc := range testCases {
+		file.Tests[i] = JSONTestCase{Name: tc.Name, Inputs: tc.Inputs, Expected: tc.Expected}
+		if !shared {
+			file.Tests[i].Compare = tc.Compare
+		}
+	}
+	return file
+}
+
+// exportJSON writes one test case per line
+func exportJSON(file *JSONTestFile) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	if file.Compare != "" {
+		fmt.Fprintf(&buf, "  \"compare\": %q,\n", file.Compare)
+	}
+	buf.WriteString("  \"tests\": [\n")
+	for i, test := range file.Tests {
+		var line bytes.Buffer
+		encoder := json.NewEncoder(&line)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(test); err != nil {
+			return nil, fmt.Errorf("test '%s': %w", test.Name, err)
+		}
+		buf.WriteString("    ")
+		buf.Write(bytes.TrimSpace(line.Bytes()))
+		if i < len(file.Tests)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("  ]\n}\n")
+	return buf.Bytes(), nil
+}
+
+// exportYAML writes each test as a block with its values in flow style
+func exportYAML(file *JSONTestFile) ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	if file.Compare != "" {
+		root.Content = append(root.Content, yamlString("compare"), yamlString(file.Compare))
+	}
+
+	tests := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, test := range file.Tests {
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		node.Content = append(node.Content, yamlString("name"), yamlString(test.Name))
+		node.Content = append(node.Content, yamlString("inputs"), yamlNode(test.Inputs))
+		node.Content = append(node.Content, yamlString("expected"), yamlNode(test.Expected))
+		if test.Compare != "" {
+			node.Content = append(node.Content, yamlString("compare"), yamlString(test.Compare))
+		}
+		tests.Content = append(tests.Content, node)
+	}
+	root.Content = append(root.Content, yamlString("tests"), tests)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlNode converts a test value to a flow-style YAML node
+func yamlNode(v interface{}) *yaml.Node {
+	switch val := v.(type) {
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(val)}
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(val.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: val.String()}
+	case string:
+		return yamlString(val)
+	case []interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+		for _, item := range val {
+			node.Content = append(node.Content, yamlNode(item))
+		}
+		return node
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		node := &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+		for _, key := range keys {
+			node.Content = append(node.Content, yamlString(key), yamlNode(val[key]))
+		}
+		return node
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: fmt.Sprint(v)}
+}
+
+// yamlString returns a string node, quoted when it would read as another type
+func yamlString(s string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+}
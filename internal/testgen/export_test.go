@@ -0,0 +1,59 @@
+package testgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestCases() []*TestCase {
+	return []*TestCase{
+		{Name: "basic", Inputs: []interface{}{[]interface{}{json.Number("2"), json.Number("7")}, json.Number("9")}, Expected: []interface{}{json.Number("0"), json.Number("1")}, Compare: "unordered"},
+		{Name: "strings <&>", Inputs: []interface{}{[]interface{}{"true", "1"}, json.Number("0.5")}, Expected: nil, Compare: "unordered"},
+	}
+}
+
+func TestExport_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Export(&buf, exportTestCases(), FormatJSON))
+
+	assert.Equal(t, `{
+  "compare": "unordered",
+  "tests": [
+    {"name":"basic","inputs":[[2,7],9],"expected":[0,1]},
+    {"name":"strings <&>","inputs":[["true","1"],0.5],"expected":null}
+  ]
+}
+`, buf.String())
+}
+
+func TestExport_RoundTrip(t *testing.T) {
+	for _, format := range []string{FormatJSON, FormatYAML} {
+		t.Run(format, func(t *testing.T) {
+			testCases := exportTestCases()
+			testCases[1].Compare = "exact"
+
+			var buf bytes.Buffer
+			require.NoError(t, Export(&buf, testCases, format))
+			path := filepath.Join(t.TempDir(), "cases."+format)
+			require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+
+			importer, err := NewImporter(path)
+			require.NoError(t, err)
+			imported, err := importer.ImportFromFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, testCases, imported)
+		})
+	}
+}
+
+func TestExport_UnknownFormat(t *testing.T) {
+	assert.ErrorContains(t, Export(&bytes.Buffer{}, nil, "toml"), `unknown export format "toml"`)
+	assert.Equal(t, FormatYAML, ExportFormat("out/cases.yml"))
+	assert.Equal(t, FormatJSON, ExportFormat(""))
+}
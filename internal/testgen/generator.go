@@ -2,29 +2,66 @@ package testgen
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"go/format"
-	"go/parser"
-	"go/token"
 	"os"
-	"path/filepath"
 	"strings"
 	"text/template"
 
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/templates"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 )
 
 // Generator handles Go test file generation
-type Generator struct{}
+type Generator struct {
+	templates *templates.Loader
+	signature *Signature // Function under test; nil when unknown
+	compare   string     // Comparison of test cases that do not name one
 
-// NewGenerator creates a new test generator
+	compareColumn bool // Give the table a compare column even if all cases compare alike
+}
+
+// NewGenerator creates a new test generator using the testgen template,
+// which users can override (see 'dsa template')
 func NewGenerator() *Generator {
-	return &Generator{}
+	return &Generator{templates: templates.NewLoader()}
 }
 
-// Generate creates or appends to a test file with the provided test cases
+// Generate creates or appends to a test file with the provided test cases.
+// The values are written as literals of the function's parameter and result
+// types, read from its solution or boilerplate; without a signature the
+// untyped table of earlier versions is generated. Results are compared as
+// each test case's Compare says, falling back to the generator's comparison
+// and the function's //dsa:compare directive.
 func (g *Generator) Generate(prob *problem.ProblemDetails, testCases []*TestCase, appendMode bool) error {
-	testFilePath := filepath.Join("problems", prob.Slug+"_test.go")
+	testFilePath := workspace.ProblemLayout(prob.Slug).Tests()
+
+	sig, err := LoadSignature(prob.Slug)
+	switch {
+	case err == nil:
+		if err := typeTestCases(sig, testCases); err != nil {
+			return err
+		}
+		if err := resolveCompare(sig, testCases, g.compare); err != nil {
+			return err
+		}
+		g.signature = sig
+	case errors.Is(err, ErrNoSignature):
+		if mode := g.compare; mode != "" {
+			return fmt.Errorf("comparison %q needs the function's signature: %w", mode, err)
+		}
+		for _, tc := range testCases {
+			if tc.Compare != "" {
+				return fmt.Errorf("test '%s': comparison %q needs the function's signature: %w", tc.Name, tc.Compare, err)
+			}
+		}
+		fmt.Printf("⚠️  %v; generating untyped tests\n", err)
+	default:
+		return err
+	}
 
 	// Handle append mode
 	if appendMode {
@@ -37,37 +74,85 @@ func (g *Generator) Generate(prob *problem.ProblemDetails, testCases []*TestCase
 
 // generateNew creates a new test file from scratch
 func (g *Generator) generateNew(testFilePath string, prob *problem.ProblemDetails, testCases []*TestCase) error {
+	formatted, err := g.render(prob, testCases)
+	if err != nil {
+		return err
+	}
+
+	// Write to file
+	if err := os.WriteFile(testFilePath, formatted, 0644); err != nil {
+		return fmt.Errorf("failed to write test file: %w", err)
+	}
+
+	fmt.Printf("✅ Generated test file: %s\n", testFilePath)
+	return nil
+}
+
+// render executes the testgen template for the test cases and returns the
+// formatted test file
+func (g *Generator) render(prob *problem.ProblemDetails, testCases []*TestCase) ([]byte, error) {
 	// Prepare template data
 	data := struct {
-		FunctionName string
-		TestCases    []*TestCase
+		templates.Data
+		TestCases  []*TestCase
+		Typed      bool
+		Params     []Column
+		Results    []Column
+		Call       string
+		Got        []string
+		InPlace    bool
+		Compare    bool
+		Checks     []Check
+		StdImports []string
+		Imports    []string
 	}{
-		FunctionName: g.deriveFunctionName(prob.Slug),
-		TestCases:    testCases,
+		Data:      templates.NewData(&prob.Problem),
+		TestCases: testCases,
+	}
+	if sig := g.signature; sig != nil {
+		data.Typed = true
+		data.Params, data.Results = sig.Columns()
+		data.Call = sig.Call()
+		data.Got = sig.Got()
+		data.InPlace = sig.InPlace
+		checks, err := sig.checks(testCases)
+		if err != nil {
+			return nil, err
+		}
+		data.Checks = checks
+		data.Compare = len(checks) > 1 || g.compareColumn
+		if err := sig.writeCheckers(workspace.ProblemLayout(prob.Slug).Checks(), checks); err != nil {
+			return nil, err
+		}
+		data.StdImports, data.Imports = sig.Imports()
+		if sig.UsesStructures() {
+			if _, err := workspace.WriteStructures(workspace.Structures()); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Generate code from template
+	testTemplate, err := g.templates.Parse(templates.TestGen, template.FuncMap{"formatValue": formatValue})
+	if err != nil {
+		return nil, err
+	}
+
 	var buf bytes.Buffer
 	if err := testTemplate.Execute(&buf, data); err != nil {
-		return fmt.Errorf("failed to execute template: %w", err)
+		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
 
 	// Format the generated code
 	formatted, err := format.Source(buf.Bytes())
 	if err != nil {
-		return fmt.Errorf("failed to format Go code: %w", err)
+		return nil, fmt.Errorf("failed to format Go code: %w", err)
 	}
-
-	// Write to file
-	if err := os.WriteFile(testFilePath, formatted, 0644); err != nil {
-		return fmt.Errorf("failed to write test file: %w", err)
-	}
-
-	fmt.Printf("✅ Generated test file: %s\n", testFilePath)
-	return nil
+	return formatted, nil
 }
 
-// appendToExisting appends test cases to an existing test file
+// appendToExisting adds the test cases to the table of an existing test
+// file, leaving the rest of the file as it is (see appendTests)
 func (g *Generator) appendToExisting(testFilePath string, prob *problem.ProblemDetails, newTestCases []*TestCase) error {
 	// Check if file exists
 	if _, err := os.Stat(testFilePath); os.IsNotExist(err) {
@@ -81,52 +166,35 @@ func (g *Generator) appendToExisting(testFilePath string, prob *problem.ProblemD
 		return fmt.Errorf("failed to read existing test file: %w", err)
 	}
 
-	// Parse existing test cases
-	existingTestCases, err := g.parseExistingTests(existingCode)
+	updated, added, err := g.appendTests(existingCode, prob, newTestCases)
 	if err != nil {
-		// If parsing fails, just generate new file
-		fmt.Printf("⚠️  Could not parse existing tests, creating new file\n")
-		return g.generateNew(testFilePath, prob, newTestCases)
+		return fmt.Errorf("cannot append to %s: %w (run without --append to regenerate it)", testFilePath, err)
 	}
-
-	// Merge test cases
-	mergedTestCases := append(existingTestCases, newTestCases...)
-
-	// Generate new file with merged test cases
-	if err := g.generateNew(testFilePath, prob, mergedTestCases); err != nil {
-		return err
+	if skipped := len(newTestCases) - added; skipped > 0 {
+		fmt.Printf("⏭️  Skipped %d test case(s) whose inputs are already in %s\n", skipped, testFilePath)
 	}
-
-	fmt.Printf("✅ Appended %d test case(s) to %s\n", len(newTestCases), testFilePath)
-	return nil
-}
-
-// parseExistingTests attempts to extract test cases from existing Go test file
-func (g *Generator) parseExistingTests(code []byte) ([]*TestCase, error) {
-	// Parse the Go source file
-	fset := token.NewFileSet()
-	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse Go file: %w", err)
+	if added == 0 {
+		return nil
 	}
 
-	// This is a simplified parser - in production, you'd use go/ast more thoroughly
-	// For now, we'll return empty slice to indicate append will create new file
-	_ = file // Use the parsed file (avoid unused variable error)
+	if err := os.WriteFile(testFilePath, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write test file: %w", err)
+	}
 
-	return []*TestCase{}, nil
+	fmt.Printf("✅ Appended %d test case(s) to %s\n", added, testFilePath)
+	return nil
 }
 
-// deriveFunctionName derives the test function name from problem slug
-// Example: "two-sum" -> "TwoSum"
-func (g *Generator) deriveFunctionName(slug string) string {
-	parts := strings.Split(slug, "-")
-	for i := range parts {
-		if len(parts[i]) > 0 {
-			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+// typeTestCases writes the values of each test case as typed literals
+func typeTestCases(sig *Signature, testCases []*TestCase) error {
+	for _, tc := range testCases {
+		args, want, err := sig.Convert(tc)
+		if err != nil {
+			return fmt.Errorf("%w (signature from %s)", err, sig.Source)
 		}
+		tc.Args, tc.Want = args, want
 	}
-	return strings.Join(parts, "")
+	return nil
 }
 
 // formatValue converts an interface{} value to its Go code representation
@@ -142,6 +210,8 @@ func formatValue(v interface{}) string {
 		return fmt.Sprintf("[]int{%s}", strings.Join(parts, ", "))
 	case int, int64, int32:
 		return fmt.Sprintf("%v", val)
+	case json.Number:
+		return val.String()
 	case float64:
 		// Check if it's actually an integer
 		if val == float64(int(val)) {
@@ -154,31 +224,3 @@ func formatValue(v interface{}) string {
 		return fmt.Sprintf("%v", val)
 	}
 }
-
-// Test file template
-var testTemplate = template.Must(template.New("test").Funcs(template.FuncMap{
-	"formatValue": formatValue,
-}).Parse(`package problems
-
-import (
-	"testing"
-	"github.com/stretchr/testify/assert"
-)
-
-func Test{{.FunctionName}}(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    []int
-		expected int
-	}{
-{{range .TestCases}}		{"{{.Name}}", {{formatValue .Inputs}}, {{formatValue .Expected}}},
-{{end}}	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := {{$.FunctionName}}(tt.input...)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-`))
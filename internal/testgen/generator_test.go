@@ -10,27 +10,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestGenerator_DeriveFunctionName(t *testing.T) {
-	tests := []struct {
-		name     string
-		slug     string
-		expected string
-	}{
-		{"simple slug", "two-sum", "TwoSum"},
-		{"three words", "add-two-numbers", "AddTwoNumbers"},
-		{"single word", "palindrome", "Palindrome"},
-		{"hyphenated", "reverse-linked-list", "ReverseLinkedList"},
-	}
-
-	gen := NewGenerator()
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := gen.deriveFunctionName(tt.slug)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
 func TestGenerator_GenerateNew(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -117,7 +96,7 @@ func TestGenerator_AppendToNonExistentFile(t *testing.T) {
 	err = gen.Generate(prob, testCases, true)
 	assert.NoError(t, err)
 
-	testFilePath := filepath.Join(problemsDir, "new-problem_test.go")
+	testFilePath := filepath.Join(problemsDir, "new_problem_test.go")
 	assert.FileExists(t, testFilePath)
 }
 
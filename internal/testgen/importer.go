@@ -0,0 +1,220 @@
+package testgen
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Importer reads test cases from a file
+type Importer interface {
+	ImportFromFile(filePath string) ([]*TestCase, error)
+}
+
+// NewImporter returns the importer for a file's extension: .json, .yaml or
+// .yml, or .csv
+func NewImporter(filePath string) (Importer, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return NewJSONImporter(), nil
+	case ".yaml", ".yml":
+		return NewYAMLImporter(), nil
+	case ".csv":
+		return NewCSVImporter(), nil
+	}
+	return nil, fmt.Errorf("unsupported test file %s: use a .json, .yaml, .yml or .csv file", filePath)
+}
+
+// YAMLImporter handles importing test cases from YAML files, which have the
+// fields of the JSON format:
+//
+//	compare: unordered
+//	tests:
+//	  - name: basic
+//	    inputs: [[2, 7, 11, 15], 9]
+//	    expected: [0, 1]
+type YAMLImporter struct {
+	json *JSONImporter
+}
+
+// NewYAMLImporter creates a new YAML importer
+func NewYAMLImporter() *YAMLImporter {
+	return &YAMLImporter{json: NewJSONImporter()}
+}
+
+// ImportFromFile reads and parses test cases from a YAML file
+func (y *YAMLImporter) ImportFromFile(filePath string) ([]*TestCase, error) {
+	data, err := readTestFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	value, err := yamlValue(&document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	// Numbers are kept as written, like the JSON importer does
+	converted, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	testCases, err := y.json.parse(converted)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("📦 Imported %d test case(s) from %s\n", len(testCases), filePath)
+	return testCases, nil
+}
+
+// yamlValue converts a YAML node to the values the JSON decoder produces,
+// with numbers as json.Number
+func yamlValue(node *yaml.Node) (interface{}, error) {
+	switch node.Kind {
+	case 0:
+		return nil, nil
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return yamlValue(node.Content[0])
+	case yaml.AliasNode:
+		return yamlValue(node.Alias)
+	case yaml.SequenceNode:
+		items := make([]interface{}, len(node.Content))
+		for i, child := range node.Content {
+			item, err := yamlValue(child)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case yaml.MappingNode:
+		object := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := yamlValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			object[node.Content[i].Value] = value
+		}
+		return object, nil
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case "!!int":
+		n, ok := new(big.Int).SetString(strings.ReplaceAll(node.Value, "_", ""), 0)
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid integer %q", node.Line, node.Value)
+		}
+		return json.Number(n.String()), nil
+	case "!!float":
+		f, err := strconv.ParseFloat(node.Value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: unsupported number %q", node.Line, node.Value)
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	}
+	return node.Value, nil
+}
+
+// CSVImporter handles importing test cases from CSV files with a header
+// row. The name column names each test, expected holds the expected value
+// and an optional compare column its comparison; every other column is an
+// input, in column order. Cells are parsed as JSON, falling back to the text
+// itself, so strings need no quotes:
+//
+//	name,nums,target,expected
+//	basic,"[2,7,11,15]",9,"[0,1]"
+type CSVImporter struct{}
+
+// NewCSVImporter creates a new CSV importer
+func NewCSVImporter() *CSVImporter {
+	return &CSVImporter{}
+}
+
+// ImportFromFile reads and parses test cases from a CSV file
+func (c *CSVImporter) ImportFromFile(filePath string) ([]*TestCase, error) {
+	data, err := readTestFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, fmt.Errorf("invalid CSV: no test cases found in file")
+	}
+
+	header := records[0]
+	nameColumn, expectedColumn, compareColumn := -1, -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "name":
+			nameColumn = i
+		case "expected":
+			expectedColumn = i
+		case "compare":
+			compareColumn = i
+		}
+	}
+	if nameColumn < 0 || expectedColumn < 0 {
+		return nil, fmt.Errorf("invalid CSV: the header needs 'name' and 'expected' columns")
+	}
+
+	testCases := make([]*TestCase, 0, len(records)-1)
+	for line, record := range records[1:] {
+		tc := &TestCase{Name: record[nameColumn], Inputs: []interface{}{}}
+		if tc.Name == "" {
+			return nil, fmt.Errorf("invalid CSV: line %d is missing 'name'", line+2)
+		}
+		for i, cell := range record {
+			switch i {
+			case nameColumn:
+			case expectedColumn:
+				tc.Expected = csvValue(cell)
+			case compareColumn:
+				tc.Compare = strings.TrimSpace(cell)
+			default:
+				tc.Inputs = append(tc.Inputs, csvValue(cell))
+			}
+		}
+		testCases = append(testCases, tc)
+	}
+
+	fmt.Printf("📦 Imported %d test case(s) from %s\n", len(testCases), filePath)
+	return testCases, nil
+}
+
+// csvValue parses a CSV cell as JSON, or returns its text
+func csvValue(cell string) interface{} {
+	if value, err := parseJSONValue(cell); err == nil {
+		return value
+	}
+	return cell
+}
@@ -0,0 +1,89 @@
+package testgen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewImporter(t *testing.T) {
+	for path, want := range map[string]Importer{
+		"cases.json": &JSONImporter{},
+		"cases.YAML": &YAMLImporter{},
+		"cases.yml":  &YAMLImporter{},
+		"cases.csv":  &CSVImporter{},
+	} {
+		importer, err := NewImporter(path)
+		require.NoError(t, err, path)
+		assert.IsType(t, want, importer, path)
+	}
+
+	_, err := NewImporter("cases.txt")
+	assert.ErrorContains(t, err, "unsupported test file cases.txt")
+}
+
+func TestYAMLImporter_ImportFromFile(t *testing.T) {
+	yamlFile := filepath.Join(t.TempDir(), "tests.yaml")
+	content := `compare: unordered
+tests:
+  - name: basic
+    inputs: [[2, 7, 11, 15], 9]
+    expected: [0, 1]
+  - name: big and exact
+    inputs: [[9007199254740993, 0.5], "9"]
+    expected: null
+    compare: exact
+`
+	require.NoError(t, os.WriteFile(yamlFile, []byte(content), 0644))
+
+	testCases, err := NewYAMLImporter().ImportFromFile(yamlFile)
+	require.NoError(t, err)
+	require.Len(t, testCases, 2)
+	assert.Equal(t, "basic", testCases[0].Name)
+	assert.Equal(t, []interface{}{[]interface{}{json.Number("2"), json.Number("7"), json.Number("11"), json.Number("15")}, json.Number("9")}, testCases[0].Inputs)
+	assert.Equal(t, "unordered", testCases[0].Compare)
+	assert.Equal(t, []interface{}{[]interface{}{json.Number("9007199254740993"), json.Number("0.5")}, "9"}, testCases[1].Inputs)
+	assert.Nil(t, testCases[1].Expected)
+	assert.Equal(t, "exact", testCases[1].Compare)
+
+	t.Run("missing expected", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(yamlFile, []byte("tests:\n  - name: a\n    inputs: [1]\n"), 0644))
+		_, err := NewYAMLImporter().ImportFromFile(yamlFile)
+		assert.ErrorContains(t, err, "test case 'a' is missing 'expected' field")
+	})
+
+	t.Run("invalid YAML", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(yamlFile, []byte("tests: [\n"), 0644))
+		_, err := NewYAMLImporter().ImportFromFile(yamlFile)
+		assert.ErrorContains(t, err, "failed to parse YAML")
+	})
+}
+
+func TestCSVImporter_ImportFromFile(t *testing.T) {
+	csvFile := filepath.Join(t.TempDir(), "tests.csv")
+	content := `name, nums, target, expected, compare
+basic, "[2,7,11,15]", 9, "[0,1]", unordered
+words, "[""a"",""b""]", hello, null,
+`
+	require.NoError(t, os.WriteFile(csvFile, []byte(content), 0644))
+
+	testCases, err := NewCSVImporter().ImportFromFile(csvFile)
+	require.NoError(t, err)
+	require.Len(t, testCases, 2)
+	assert.Equal(t, []interface{}{[]interface{}{json.Number("2"), json.Number("7"), json.Number("11"), json.Number("15")}, json.Number("9")}, testCases[0].Inputs)
+	assert.Equal(t, []interface{}{json.Number("0"), json.Number("1")}, testCases[0].Expected)
+	assert.Equal(t, "unordered", testCases[0].Compare)
+	assert.Equal(t, []interface{}{[]interface{}{"a", "b"}, "hello"}, testCases[1].Inputs, "text that is not JSON is a string")
+	assert.Nil(t, testCases[1].Expected)
+	assert.Empty(t, testCases[1].Compare)
+
+	t.Run("missing columns", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(csvFile, []byte("name,nums\nbasic,[1]\n"), 0644))
+		_, err := NewCSVImporter().ImportFromFile(csvFile)
+		assert.ErrorContains(t, err, "needs 'name' and 'expected' columns")
+	})
+}
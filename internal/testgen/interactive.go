@@ -2,6 +2,7 @@ package testgen
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -88,6 +89,102 @@ func (i *InteractiveInput) Collect() ([]*TestCase, error) {
 	return testCases, nil
 }
 
+// CollectTyped prompts for test cases of a function with a known signature:
+// one value per parameter and result, each checked against its type. Values
+// are JSON, e.g. [1,2,3], null or {"val": 1}; strings may be left unquoted.
+func (i *InteractiveInput) CollectTyped(sig *Signature) ([]*TestCase, error) {
+	var testCases []*TestCase
+
+	fmt.Println("📝 Interactive Test Case Generator")
+	fmt.Printf("Signature from %s. Enter values as JSON, e.g. [1,2,3] or null.\n", sig.Source)
+	fmt.Println("Type 'done' as the test case name when finished.")
+
+	for {
+		fmt.Print("Enter test case name (or 'done' to finish): ")
+		if !i.scanner.Scan() {
+			return nil, fmt.Errorf("failed to read test case name")
+		}
+		name := strings.TrimSpace(i.scanner.Text())
+
+		if name == "done" {
+			break
+		}
+
+		if name == "" {
+			fmt.Println("❌ Test case name cannot be empty. Try again.")
+			continue
+		}
+
+		testCase := &TestCase{Name: name}
+		for _, param := range sig.Params {
+			value, err := i.promptValue(sig, param)
+			if err != nil {
+				return nil, err
+			}
+			testCase.Inputs = append(testCase.Inputs, value)
+		}
+
+		var expected []interface{}
+		for _, result := range sig.Results {
+			value, err := i.promptValue(sig, result)
+			if err != nil {
+				return nil, err
+			}
+			expected = append(expected, value)
+		}
+		if len(expected) == 1 {
+			testCase.Expected = expected[0]
+		} else {
+			testCase.Expected = expected
+		}
+
+		testCases = append(testCases, testCase)
+		fmt.Printf("✅ Added test case: %s\n\n", name)
+	}
+
+	if len(testCases) == 0 {
+		return nil, fmt.Errorf("no test cases provided")
+	}
+
+	fmt.Printf("\n📊 Collected %d test case(s)\n", len(testCases))
+	return testCases, nil
+}
+
+// promptValue reads one value, asking again until it converts to its type
+func (i *InteractiveInput) promptValue(sig *Signature, value Value) (interface{}, error) {
+	for {
+		fmt.Printf("  %s (%s): ", value.Name, sig.TypeString(value.Type))
+		if !i.scanner.Scan() {
+			return nil, fmt.Errorf("failed to read %s", value.Name)
+		}
+		text := strings.TrimSpace(i.scanner.Text())
+
+		parsed, err := parseJSONValue(text)
+		if err != nil {
+			parsed = text // Unquoted string
+		}
+		if _, err := sig.Literal(value.Type, parsed); err != nil {
+			fmt.Printf("❌ %v. Try again.\n", err)
+			continue
+		}
+		return parsed, nil
+	}
+}
+
+// parseJSONValue decodes one JSON value, keeping numbers exact
+func parseJSONValue(text string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("more than one value")
+	}
+	return value, nil
+}
+
 // parseInputs parses comma-separated input values
 func (i *InteractiveInput) parseInputs(inputsStr string) ([]interface{}, error) {
 	if inputsStr == "" {
@@ -1,6 +1,7 @@
 package testgen
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -16,7 +17,8 @@ func NewJSONImporter() *JSONImporter {
 
 // JSONTestFile represents the structure of the JSON test file
 type JSONTestFile struct {
-	Tests []JSONTestCase `json:"tests"`
+	Compare string         `json:"compare,omitempty"` // Default comparison of the tests
+	Tests   []JSONTestCase `json:"tests"`
 }
 
 // JSONTestCase represents a single test case in JSON format
@@ -24,29 +26,38 @@ type JSONTestCase struct {
 	Name     string        `json:"name"`
 	Inputs   []interface{} `json:"inputs"`
 	Expected interface{}   `json:"expected"`
+	Compare  string        `json:"compare,omitempty"`
 }
 
 // ImportFromFile reads and parses test cases from a JSON file
 func (j *JSONImporter) ImportFromFile(filePath string) ([]*TestCase, error) {
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("file not found: %s", filePath)
+	data, err := readTestFile(filePath)
+	if err != nil {
+		return nil, err
 	}
 
-	// Read file
-	data, err := os.ReadFile(filePath)
+	testCases, err := j.parse(data)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		return nil, err
 	}
 
-	// Parse JSON
+	fmt.Printf("📦 Imported %d test case(s) from %s\n", len(testCases), filePath)
+	return testCases, nil
+}
+
+// parse converts the JSON of a test file to test cases. A test without a
+// comparison takes the file's.
+func (j *JSONImporter) parse(data []byte) ([]*TestCase, error) {
+	// Parse JSON, keeping numbers exact so large int64 values survive
 	var jsonFile JSONTestFile
-	if err := json.Unmarshal(data, &jsonFile); err != nil {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&jsonFile); err != nil {
 		return nil, fmt.Errorf("failed to parse JSON: %w", err)
 	}
 
 	// Validate schema
-	if err := j.validate(&jsonFile); err != nil {
+	if err := j.validate(&jsonFile, data); err != nil {
 		return nil, fmt.Errorf("invalid JSON schema: %w", err)
 	}
 
@@ -57,17 +68,42 @@ func (j *JSONImporter) ImportFromFile(filePath string) ([]*TestCase, error) {
 			Name:     jsonTest.Name,
 			Inputs:   jsonTest.Inputs,
 			Expected: jsonTest.Expected,
+			Compare:  jsonTest.Compare,
+		}
+		if testCases[i].Compare == "" {
+			testCases[i].Compare = jsonFile.Compare
 		}
 	}
-
-	fmt.Printf("📦 Imported %d test case(s) from %s\n", len(testCases), filePath)
 	return testCases, nil
 }
 
-// validate checks that the JSON structure is valid
-func (j *JSONImporter) validate(jsonFile *JSONTestFile) error {
+// readTestFile reads a file of test cases
+func readTestFile(filePath string) ([]byte, error) {
+	// Check if file exists
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("file not found: %s", filePath)
+	}
+
+	// Read file
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return data, nil
+}
+
+// validate checks that the JSON structure is valid. An expected value of
+// null is allowed (e.g. a nil slice), so presence is checked on the raw data.
+func (j *JSONImporter) validate(jsonFile *JSONTestFile, data []byte) error {
 	if len(jsonFile.Tests) == 0 {
-		return fmt.Errorf("no test cases found in JSON file")
+		return fmt.Errorf("no test cases found in file")
+	}
+
+	var raw struct {
+		Tests []map[string]json.RawMessage `json:"tests"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
 	}
 
 	for i, test := range jsonFile.Tests {
@@ -77,7 +113,7 @@ func (j *JSONImporter) validate(jsonFile *JSONTestFile) error {
 		if test.Inputs == nil {
 			return fmt.Errorf("test case '%s' is missing 'inputs' field", test.Name)
 		}
-		if test.Expected == nil {
+		if _, ok := raw.Tests[i]["expected"]; !ok {
 			return fmt.Errorf("test case '%s' is missing 'expected' field", test.Name)
 		}
 	}
@@ -1,6 +1,7 @@
 package testgen
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -38,8 +39,26 @@ func TestJSONImporter_ImportFromFile_ValidFile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, testCases, 2)
 	assert.Equal(t, "test case 1", testCases[0].Name)
-	assert.Equal(t, []interface{}{float64(1), float64(2), float64(3)}, testCases[0].Inputs)
-	assert.Equal(t, float64(6), testCases[0].Expected)
+	assert.Equal(t, []interface{}{json.Number("1"), json.Number("2"), json.Number("3")}, testCases[0].Inputs)
+	assert.Equal(t, json.Number("6"), testCases[0].Expected)
+}
+
+func TestJSONImporter_ImportFromFile_Compare(t *testing.T) {
+	jsonFile := filepath.Join(t.TempDir(), "tests.json")
+	jsonContent := `{
+  "compare": "unordered",
+  "tests": [
+    {"name": "file default", "inputs": [[1, 2]], "expected": [2, 1]},
+    {"name": "own", "inputs": [[0.1]], "expected": [0.1], "compare": "float-tolerance:1e-6"}
+  ]
+}`
+	assert.NoError(t, os.WriteFile(jsonFile, []byte(jsonContent), 0644))
+
+	testCases, err := NewJSONImporter().ImportFromFile(jsonFile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "unordered", testCases[0].Compare)
+	assert.Equal(t, "float-tolerance:1e-6", testCases[1].Compare)
 }
 
 func TestJSONImporter_ImportFromFile_FileNotFound(t *testing.T) {
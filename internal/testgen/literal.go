@@ -0,0 +1,363 @@
+package testgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ak95asb/dsa-dojo/structures"
+)
+
+// Literal writes a test value as a Go literal of type t. Values are what the
+// JSON decoder (with UseNumber) or the interactive parser produce: numbers,
+// strings, bools, nil, []interface{} and map[string]interface{}. JSON null is
+// nil for slices, maps, pointers and interfaces, so nil and empty slices stay
+// distinct. Lists, trees and graphs are written in LeetCode notation unless
+// given as an object of their fields.
+func (s *Signature) Literal(t types.Type, v interface{}) (string, error) {
+	if shape := shapeOf(t); shape != structures.NoShape {
+		if _, isObject := v.(map[string]interface{}); !isObject {
+			return s.notationLiteral(t, shape, v)
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return s.basicLiteral(t, u, v)
+
+	case *types.Slice:
+		if v == nil {
+			return "nil", nil
+		}
+		if str, ok := v.(string); ok && isByte(u.Elem()) {
+			return s.TypeString(t) + "(" + strconv.Quote(str) + ")", nil
+		}
+		items, ok := v.([]interface{})
+		if !ok {
+			return "", fmt.Errorf("cannot convert %s to %s: expected an array", describeValue(v), s.TypeString(t))
+		}
+		return s.elements(t, u.Elem(), items)
+
+	case *types.Array:
+		items, ok := v.([]interface{})
+		if !ok {
+			return "", fmt.Errorf("cannot convert %s to %s: expected an array", describeValue(v), s.TypeString(t))
+		}
+		if int64(len(items)) != u.Len() {
+			return "", fmt.Errorf("cannot convert an array of %d values to %s", len(items), s.TypeString(t))
+		}
+		return s.elements(t, u.Elem(), items)
+
+	case *types.Map:
+		if v == nil {
+			return "nil", nil
+		}
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("cannot convert %s to %s: expected an object", describeValue(v), s.TypeString(t))
+		}
+		return s.mapLiteral(t, u, object)
+
+	case *types.Struct:
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("cannot convert %s to %s: expected an object", describeValue(v), s.TypeString(t))
+		}
+		return s.structLiteral(t, u, object)
+
+	case *types.Pointer:
+		if v == nil {
+			return "nil", nil
+		}
+		if _, isStruct := u.Elem().Underlying().(*types.Struct); !isStruct {
+			return "", fmt.Errorf("cannot write a %s literal; only nil and pointers to structs are supported", s.TypeString(t))
+		}
+		literal, err := s.Literal(u.Elem(), v)
+		if err != nil {
+			return "", err
+		}
+		return "&" + literal, nil
+
+	case *types.Interface:
+		if v == nil {
+			return "nil", nil
+		}
+		if !u.Empty() {
+			return "", fmt.Errorf("cannot convert %s to %s: only null is supported", describeValue(v), s.TypeString(t))
+		}
+		return s.dynamicLiteral(v)
+
+	default:
+		return "", fmt.Errorf("values of type %s are not supported", s.TypeString(t))
+	}
+}
+
+// elements writes a slice or array literal
+func (s *Signature) elements(t, elem types.Type, items []interface{}) (string, error) {
+	parts := make([]string, len(items))
+	for i, item := range items {
+		literal, err := s.Literal(elem, item)
+		if err != nil {
+			return "", fmt.Errorf("element %d: %w", i, err)
+		}
+		parts[i] = literal
+	}
+	return s.TypeString(t) + "{" + strings.Join(parts, ", ") + "}", nil
+}
+
+// mapLiteral writes a map literal with keys in sorted order. JSON object keys
+// are strings; they are converted to the map's key type.
+func (s *Signature) mapLiteral(t types.Type, m *types.Map, object map[string]interface{}) (string, error) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		var keyValue interface{} = key
+		if basic, ok := m.Key().Underlying().(*types.Basic); ok && basic.Info()&types.IsString == 0 {
+			keyValue = json.Number(key)
+			if basic.Info()&types.IsBoolean != 0 {
+				keyValue = key == "true"
+			}
+		}
+		keyLiteral, err := s.Literal(m.Key(), keyValue)
+		if err != nil {
+			return "", fmt.Errorf("key %q: %w", key, err)
+		}
+		valueLiteral, err := s.Literal(m.Elem(), object[key])
+		if err != nil {
+			return "", fmt.Errorf("key %q: %w", key, err)
+		}
+		parts[i] = keyLiteral + ": " + valueLiteral
+	}
+	return s.TypeString(t) + "{" + strings.Join(parts, ", ") + "}", nil
+}
+
+// structLiteral writes a keyed struct literal. Object keys match field names
+// case-insensitively; unknown keys are an error so typos are not dropped.
+func (s *Signature) structLiteral(t types.Type, st *types.Struct, object map[string]interface{}) (string, error) {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	assigned := map[int]string{}
+	for _, key := range keys {
+		index := -1
+		for i := 0; i < st.NumFields(); i++ {
+			if strings.EqualFold(st.Field(i).Name(), key) {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return "", fmt.Errorf("%s has no field %q", s.TypeString(t), key)
+		}
+		literal, err := s.Literal(st.Field(index).Type(), object[key])
+		if err != nil {
+			return "", fmt.Errorf("field %s: %w", st.Field(index).Name(), err)
+		}
+		assigned[index] = st.Field(index).Name() + ": " + literal
+	}
+
+	var parts []string
+	for i := 0; i < st.NumFields(); i++ {
+		if field, ok := assigned[i]; ok {
+			parts = append(parts, field)
+		}
+	}
+	return s.TypeString(t) + "{" + strings.Join(parts, ", ") + "}", nil
+}
+
+// basicLiteral writes a number, string, bool or rune. Untyped constants are
+// written bare since the table's fields give them their type.
+func (s *Signature) basicLiteral(t types.Type, basic *types.Basic, v interface{}) (string, error) {
+	info := basic.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		if b, ok := v.(bool); ok {
+			return strconv.FormatBool(b), nil
+		}
+
+	case info&types.IsString != 0:
+		if str, ok := v.(string); ok {
+			return strconv.Quote(str), nil
+		}
+
+	case info&types.IsInteger != 0:
+		if str, ok := v.(string); ok && (basic.Name() == "byte" || basic.Name() == "rune") {
+			r, size := utf8.DecodeRuneInString(str)
+			if size == 0 || size != len(str) || (basic.Name() == "byte" && r > math.MaxUint8) {
+				return "", fmt.Errorf("cannot convert %q to %s: expected a single character", str, basic.Name())
+			}
+			return strconv.QuoteRune(r), nil
+		}
+		n, ok := toBigInt(v)
+		if !ok {
+			break
+		}
+		if min, max := intRange(basic); n.Cmp(min) < 0 || n.Cmp(max) > 0 {
+			return "", fmt.Errorf("%s overflows %s", n, s.TypeString(t))
+		}
+		return n.String(), nil
+
+	case info&types.IsFloat != 0:
+		f, ok := toFloat(v)
+		if !ok {
+			break
+		}
+		bits := 64
+		if basic.Kind() == types.Float32 {
+			bits = 32
+			if math.Abs(f) > math.MaxFloat32 {
+				return "", fmt.Errorf("%v overflows float32", f)
+			}
+		}
+		return strconv.FormatFloat(f, 'g', -1, bits), nil
+	}
+
+	return "", fmt.Errorf("cannot convert %s to %s", describeValue(v), s.TypeString(t))
+}
+
+// intRange returns the smallest and largest value of an integer type
+func intRange(basic *types.Basic) (*big.Int, *big.Int) {
+	bits := map[types.BasicKind]uint{
+		types.Int8: 8, types.Int16: 16, types.Int32: 32, types.Int64: 64, types.Int: 64,
+		types.Uint8: 8, types.Uint16: 16, types.Uint32: 32, types.Uint64: 64, types.Uint: 64, types.Uintptr: 64,
+	}[basic.Kind()]
+	if bits == 0 {
+		bits = 64
+	}
+
+	one := big.NewInt(1)
+	if basic.Info()&types.IsUnsigned != 0 {
+		max := new(big.Int).Lsh(one, bits)
+		return big.NewInt(0), max.Sub(max, one)
+	}
+	max := new(big.Int).Lsh(one, bits-1)
+	min := new(big.Int).Neg(max)
+	return min, max.Sub(max, one)
+}
+
+// toBigInt converts an integral number to a big.Int
+func toBigInt(v interface{}) (*big.Int, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		if i, ok := new(big.Int).SetString(n.String(), 10); ok {
+			return i, true
+		}
+		f, err := n.Float64()
+		if err != nil || f != math.Trunc(f) {
+			return nil, false
+		}
+		i, _ := big.NewFloat(f).Int(nil)
+		return i, true
+	case int:
+		return big.NewInt(int64(n)), true
+	case int64:
+		return big.NewInt(n), true
+	case float64:
+		if n != math.Trunc(n) || math.IsInf(n, 0) {
+			return nil, false
+		}
+		i, _ := big.NewFloat(n).Int(nil)
+		return i, true
+	}
+	return nil, false
+}
+
+// toFloat converts a number to a float64
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// dynamicLiteral writes a value for an interface{} field using the natural Go
+// type of the JSON value
+func (s *Signature) dynamicLiteral(v interface{}) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "nil", nil
+	case bool:
+		return strconv.FormatBool(val), nil
+	case string:
+		return strconv.Quote(val), nil
+	case json.Number, int, int64, float64:
+		if n, ok := toBigInt(val); ok && n.IsInt64() {
+			return n.String(), nil
+		}
+		f, _ := toFloat(val)
+		return "float64(" + strconv.FormatFloat(f, 'g', -1, 64) + ")", nil
+	case []interface{}:
+		parts := make([]string, len(val))
+		for i, item := range val {
+			literal, err := s.dynamicLiteral(item)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = literal
+		}
+		return "[]interface{}{" + strings.Join(parts, ", ") + "}", nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			literal, err := s.dynamicLiteral(val[key])
+			if err != nil {
+				return "", err
+			}
+			parts[i] = strconv.Quote(key) + ": " + literal
+		}
+		return "map[string]interface{}{" + strings.Join(parts, ", ") + "}", nil
+	}
+	return "", fmt.Errorf("unsupported value %v", v)
+}
+
+// isByte reports whether a type is byte (uint8)
+func isByte(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Uint8
+}
+
+// describeValue describes a test value for error messages, e.g. `"a" (string)`
+func describeValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("%q (string)", val)
+	case json.Number, int, int64, float64:
+		return fmt.Sprintf("%v (number)", val)
+	case bool:
+		return fmt.Sprintf("%v (bool)", val)
+	case []interface{}:
+		return fmt.Sprintf("an array of %d values", len(val))
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,147 @@
+package testgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/structures"
+)
+
+// shapeOf returns the data structure a type is shaped like, mirroring
+// structures.ShapeOf for types read by go/types
+func shapeOf(t types.Type) structures.Shape {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return structures.NoShape
+	}
+	node, ok := ptr.Elem().Underlying().(*types.Struct)
+	if !ok || fieldType(node, "Val") == nil {
+		return structures.NoShape
+	}
+
+	is := func(name string, want types.Type) bool {
+		field := fieldType(node, name)
+		return field != nil && types.Identical(field, want)
+	}
+	switch {
+	case is("Next", ptr):
+		return structures.List
+	case is("Left", ptr) && is("Right", ptr):
+		return structures.Tree
+	case is("Neighbors", types.NewSlice(ptr)):
+		return structures.Graph
+	}
+	return structures.NoShape
+}
+
+// hasShape reports whether a type is a list, tree or graph node, or a slice
+// or array of them, so its values are compared in LeetCode notation
+func hasShape(t types.Type) bool {
+	if shapeOf(t) != structures.NoShape {
+		return true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return hasShape(u.Elem())
+	case *types.Array:
+		return hasShape(u.Elem())
+	}
+	return false
+}
+
+// fieldType returns the type of a struct field, or nil
+func fieldType(st *types.Struct, name string) types.Type {
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Name() == name {
+			return st.Field(i).Type()
+		}
+	}
+	return nil
+}
+
+// notationLiteral writes a list, tree or graph value as a call building it
+// from LeetCode notation, e.g. structures.MustParse[*TreeNode]("[3,9,20]").
+// The value is the notation as a string, or the same notation as a JSON array.
+func (s *Signature) notationLiteral(t types.Type, shape structures.Shape, v interface{}) (string, error) {
+	var notation string
+	switch val := v.(type) {
+	case nil:
+		return "nil", nil
+	case string:
+		notation = val
+	case []interface{}:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return "", fmt.Errorf("cannot write %s in notation: %w", describeValue(v), err)
+		}
+		notation = string(data)
+	default:
+		return "", fmt.Errorf("cannot convert %s to %s: expected notation such as %s", describeValue(v), s.TypeString(t), example(shape))
+	}
+
+	if err := s.checkNotation(t, shape, notation); err != nil {
+		return "", fmt.Errorf("cannot convert %q to %s: %w (expected notation such as %s)", notation, s.TypeString(t), err, example(shape))
+	}
+
+	if s.structuresPath == "" {
+		return "", fmt.Errorf("%s values need the structures package: %w", s.TypeString(t), s.structuresErr)
+	}
+	s.imports[s.structuresPath] = true
+	return fmt.Sprintf("structures.MustParse[%s](%s)", s.TypeString(t), strconv.Quote(notation)), nil
+}
+
+// checkNotation checks notation the way structures.Parse will read it, so
+// mistakes are reported when generating rather than when the tests run
+func (s *Signature) checkNotation(t types.Type, shape structures.Shape, notation string) error {
+	if shape == structures.Graph {
+		var adjacency [][]int
+		if err := json.Unmarshal([]byte(notation), &adjacency); err != nil {
+			return fmt.Errorf("not a list of adjacency lists")
+		}
+		for i, neighbors := range adjacency {
+			for _, neighbor := range neighbors {
+				if neighbor < 1 || neighbor > len(adjacency) {
+					return fmt.Errorf("node %d: neighbor %d out of range 1..%d", i+1, neighbor, len(adjacency))
+				}
+			}
+		}
+		return nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(notation))
+	decoder.UseNumber()
+	var elems []interface{}
+	if err := decoder.Decode(&elems); err != nil {
+		return fmt.Errorf("not a JSON array")
+	}
+
+	node := t.(*types.Pointer).Elem().Underlying().(*types.Struct)
+	valType := fieldType(node, "Val")
+	for i, elem := range elems {
+		if elem == nil {
+			if shape == structures.List || i == 0 && len(elems) > 1 {
+				return fmt.Errorf("element %d is null", i)
+			}
+			continue
+		}
+		if _, err := s.Literal(valType, elem); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// example returns sample notation for a shape
+func example(shape structures.Shape) string {
+	switch shape {
+	case structures.List:
+		return "[1,2,3]"
+	case structures.Tree:
+		return "[3,9,20,null,null,15,7]"
+	default:
+		return "[[2,4],[1,3],[2,4],[1,3]]"
+	}
+}
@@ -0,0 +1,110 @@
+package testgen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const notationSource = `package solutions
+
+import "example.com/practice/structures"
+
+type TreeNode struct {
+	Val         int
+	Left, Right *TreeNode
+}
+
+func InvertTree(root *TreeNode) *TreeNode { return root }
+
+func MergeKLists(lists []*structures.ListNode) *structures.ListNode { return nil }
+
+func CloneGraph(node *structures.Node) *structures.Node { return node }
+`
+
+// newNotationWorkspace creates a workspace whose solution uses both its own
+// tree type and the structures package
+func newNotationWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	_, err := workspace.Init(dir, "example.com/practice")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "solutions", "invert_tree.go"), []byte(notationSource), 0644))
+
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	require.NoError(t, os.Chdir(dir))
+}
+
+func TestSignature_Notation(t *testing.T) {
+	newNotationWorkspace(t)
+
+	tree, err := ParseSignature(filepath.Join("solutions", "invert_tree.go"), "InvertTree")
+	require.NoError(t, err)
+	lists, err := ParseSignature(filepath.Join("solutions", "invert_tree.go"), "MergeKLists")
+	require.NoError(t, err)
+	graph, err := ParseSignature(filepath.Join("solutions", "invert_tree.go"), "CloneGraph")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		sig   *Signature
+		value interface{}
+		want  string
+	}{
+		{"tree from string", tree, "[3,9,20,null,null,15,7]", `structures.MustParse[*TreeNode]("[3,9,20,null,null,15,7]")`},
+		{"tree from array", tree, []interface{}{json.Number("1"), nil, json.Number("2")}, `structures.MustParse[*TreeNode]("[1,null,2]")`},
+		{"nil tree", tree, nil, "nil"},
+		{"lists", lists, []interface{}{"[1,4,5]", []interface{}{}}, `[]*structures.ListNode{structures.MustParse[*structures.ListNode]("[1,4,5]"), structures.MustParse[*structures.ListNode]("[]")}`},
+		{"graph", graph, "[[2,4],[1,3],[2,4],[1,3]]", `structures.MustParse[*structures.Node]("[[2,4],[1,3],[2,4],[1,3]]")`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sig.Literal(tt.sig.Params[0].Type, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			_, other := tt.sig.Imports()
+			assert.Contains(t, other, "example.com/practice/structures")
+		})
+	}
+
+	_, results := tree.Columns()
+	assert.Equal(t, "structures.Format", results[0].Format)
+
+	t.Run("invalid notation", func(t *testing.T) {
+		_, err := tree.Literal(tree.Params[0].Type, `[1,"x"]`)
+		assert.ErrorContains(t, err, `cannot convert "[1,\"x\"]" to *TreeNode: element 1: cannot convert "x" (string) to int`)
+
+		_, err = graph.Literal(graph.Params[0].Type, "[[2],[3]]")
+		assert.ErrorContains(t, err, "node 2: neighbor 3 out of range 1..2")
+
+		_, err = lists.Literal(lists.Params[0].Type, []interface{}{"[1,null]"})
+		assert.ErrorContains(t, err, "element 1 is null")
+	})
+}
+
+func TestGenerator_Generate_Notation(t *testing.T) {
+	newNotationWorkspace(t)
+	require.NoError(t, os.RemoveAll("structures"))
+
+	prob := &problem.ProblemDetails{Problem: database.Problem{Slug: "invert-tree", Title: "Invert Tree"}}
+	testCases := []*TestCase{
+		{Name: "example", Inputs: []interface{}{"[4,2,7,1,3,6,9]"}, Expected: "[4,7,2,9,6,3,1]"},
+	}
+	require.NoError(t, NewGenerator().Generate(prob, testCases, false))
+
+	content, err := os.ReadFile(filepath.Join("problems", "invert_tree_test.go"))
+	require.NoError(t, err)
+	source := string(content)
+	assert.Contains(t, source, `"example.com/practice/structures"`)
+	assert.Contains(t, source, `root: structures.MustParse[*TreeNode]("[4,2,7,1,3,6,9]"),`)
+	assert.Contains(t, source, "assert.Equal(t, structures.Format(tt.want), structures.Format(got))")
+	assert.FileExists(t, filepath.Join("structures", "notation.go"), "the helper package is restored when tests need it")
+}
@@ -0,0 +1,408 @@
+package testgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"math/big"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseTestFile reads the cases of the table-driven test of a test file, such
+// as one written by Generate, back into test values. The first slice of
+// structs in the file's first Test function is the table; its name field is
+// the case name, want (or want1, want2, ...) or expected the expected
+// value, compare the comparison and every other field an input.
+func ParseTestFile(path string) ([]*TestCase, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test file: %w", err)
+	}
+	testCases, err := parseTests(code)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return testCases, nil
+}
+
+// parseTests extracts the test cases of the table in Go test source
+func parseTests(code []byte) ([]*TestCase, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", code, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Go file: %w", err)
+	}
+
+	fn, table, fields := findTable(file)
+	if table == nil {
+		return nil, fmt.Errorf("no table of test cases found")
+	}
+
+	// A table without a compare column compares every case the same way
+	compare := ""
+	if !slices.Contains(fields, "compare") {
+		compare = tableCompare(fn)
+	}
+
+	var testCases []*TestCase
+	for i, elt := range table.Elts {
+		row, ok := elt.(*ast.CompositeLit)
+		if !ok {
+			return nil, fmt.Errorf("test case %d is not a struct literal", i+1)
+		}
+		tc, err := rowTestCase(fset, row, fields)
+		if err != nil {
+			return nil, fmt.Errorf("test case %d: %w", i+1, err)
+		}
+		if tc.Compare == "" {
+			tc.Compare = compare
+		}
+		testCases = append(testCases, tc)
+	}
+	return testCases, nil
+}
+
+// findTable returns the first Test function declaring a slice of structs,
+// the slice literal and the struct's field names
+func findTable(file *ast.File) (*ast.FuncDecl, *ast.CompositeLit, []string) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || fn.Body == nil || !strings.HasPrefix(fn.Name.Name, "Test") {
+			continue
+		}
+
+		var table *ast.CompositeLit
+		var fields []string
+		ast.Inspect(fn.Body, func(node ast.Node) bool {
+			if table != nil {
+				return false
+			}
+			lit, ok := node.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+			slice, ok := lit.Type.(*ast.ArrayType)
+			if !ok {
+				return true
+			}
+			st, ok := slice.Elt.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range st.Fields.List {
+				for _, name := range field.Names {
+					fields = append(fields, name.Name)
+				}
+			}
+			table = lit
+			return false
+		})
+		if table != nil {
+			return fn, table, fields
+		}
+	}
+	return nil, nil, nil
+}
+
+// wantField matches the fields holding expected values
+var wantField = regexp.MustCompile(`^(want\d*|expected)$`)
+
+// rowTestCase converts one row of the table, keyed or positional
+func rowTestCase(fset *token.FileSet, row *ast.CompositeLit, fields []string) (*TestCase, error) {
+	values, err := rowFields(fset, row, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	tc := &TestCase{Inputs: []interface{}{}}
+	var wants []interface{}
+	for _, field := range fields {
+		expr, ok := values[field]
+		var value interface{}
+		if ok {
+			var err error
+			if value, err = exprValue(fset, expr); err != nil {
+				return nil, fmt.Errorf("field %s: %w", field, err)
+			}
+		}
+
+		switch {
+		case field == "name":
+			name, _ := value.(string)
+			tc.Name = name
+		case field == "compare":
+			mode, _ := value.(string)
+			tc.Compare = mode
+		case wantField.MatchString(field):
+			wants = append(wants, value)
+		case field == "input" && isUntyped(fields):
+			items, _ := value.([]interface{})
+			tc.Inputs = append(tc.Inputs, items...)
+		default:
+			tc.Inputs = append(tc.Inputs, value)
+		}
+	}
+
+	if len(wants) == 1 {
+		tc.Expected = wants[0]
+	} else if len(wants) > 1 {
+		tc.Expected = wants
+	}
+	return tc, nil
+}
+
+// rowFields returns the expressions of a table row by field name. Fields a
+// keyed row leaves out are missing from the map.
+func rowFields(fset *token.FileSet, row *ast.CompositeLit, fields []string) (map[string]ast.Expr, error) {
+	values := map[string]ast.Expr{}
+	for i, elt := range row.Elts {
+		if pair, ok := elt.(*ast.KeyValueExpr); ok {
+			key, ok := pair.Key.(*ast.Ident)
+			if !ok {
+				return nil, fmt.Errorf("unexpected key %s", source(fset, pair.Key))
+			}
+			values[key.Name] = pair.Value
+		} else if i < len(fields) {
+			values[fields[i]] = elt
+		}
+	}
+	return values, nil
+}
+
+// isInput reports whether a table field holds an input of the function
+func isInput(field string) bool {
+	return field != "name" && field != "compare" && !wantField.MatchString(field)
+}
+
+// isUntyped reports whether a table is the untyped table of earlier
+// versions, which calls the function with tt.input...
+func isUntyped(fields []string) bool {
+	return slices.Equal(fields, []string{"name", "input", "expected"})
+}
+
+// tableCompare returns the comparison of a test's assertions, found from
+// the structures.Match call or the checker passed to assert.True, or ""
+func tableCompare(fn *ast.FuncDecl) string {
+	compare := ""
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if compare != "" || !ok {
+			return compare == ""
+		}
+		switch {
+		case isSelector(call.Fun, "structures", "Match") && len(call.Args) > 0:
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				compare, _ = strconv.Unquote(lit.Value)
+			}
+		case isSelector(call.Fun, "assert", "True") && len(call.Args) > 1:
+			if check, ok := call.Args[1].(*ast.CallExpr); ok {
+				if name, ok := check.Fun.(*ast.Ident); ok && isChecker(name.Name) {
+					compare = name.Name
+				}
+			}
+		}
+		return true
+	})
+	return compare
+}
+
+// exprValue converts a Go literal back to the values Literal accepts:
+// numbers as json.Number, strings, bools, nil, []interface{} and
+// map[string]interface{}. Lists, trees and graphs built from LeetCode
+// notation come back as their notation.
+func exprValue(fset *token.FileSet, expr ast.Expr) (interface{}, error) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return exprValue(fset, e.X)
+
+	case *ast.BasicLit:
+		return basicValue(e)
+
+	case *ast.Ident:
+		switch e.Name {
+		case "true", "false":
+			return e.Name == "true", nil
+		case "nil":
+			return nil, nil
+		}
+
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case token.SUB, token.ADD:
+			value, err := exprValue(fset, e.X)
+			if n, ok := value.(json.Number); ok && err == nil {
+				if e.Op == token.SUB {
+					return json.Number("-" + n.String()), nil
+				}
+				return n, nil
+			}
+		case token.AND:
+			return exprValue(fset, e.X)
+		}
+
+	case *ast.CompositeLit:
+		return compositeValue(fset, e)
+
+	case *ast.CallExpr:
+		return callValue(fset, e)
+	}
+	return nil, fmt.Errorf("cannot read %s as a test value", source(fset, expr))
+}
+
+// basicValue converts a number, string or rune literal
+func basicValue(lit *ast.BasicLit) (interface{}, error) {
+	switch lit.Kind {
+	case token.INT:
+		n, ok := new(big.Int).SetString(strings.ReplaceAll(lit.Value, "_", ""), 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %s", lit.Value)
+		}
+		return json.Number(n.String()), nil
+	case token.FLOAT:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(lit.Value, "_", ""), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", lit.Value)
+		}
+		return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+	case token.STRING:
+		return strconv.Unquote(lit.Value)
+	case token.CHAR:
+		r, _, _, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+		if err != nil {
+			return nil, fmt.Errorf("invalid character %s", lit.Value)
+		}
+		return string(r), nil
+	}
+	return nil, fmt.Errorf("unsupported literal %s", lit.Value)
+}
+
+// compositeValue converts a slice, array, map or struct literal. Elements
+// keyed by field names make an object, keyed by values a map, and
+// unkeyed elements an array.
+func compositeValue(fset *token.FileSet, lit *ast.CompositeLit) (interface{}, error) {
+	_, isMap := lit.Type.(*ast.MapType)
+	_, isSlice := lit.Type.(*ast.ArrayType)
+
+	keyed := len(lit.Elts) > 0
+	for _, elt := range lit.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); !ok {
+			keyed = false
+		}
+	}
+
+	if !keyed && (isSlice || len(lit.Elts) > 0) {
+		items := make([]interface{}, len(lit.Elts))
+		for i, elt := range lit.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				return nil, fmt.Errorf("cannot read %s: mixed keyed and unkeyed elements", source(fset, lit))
+			}
+			item, err := exprValue(fset, elt)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	}
+
+	object := make(map[string]interface{}, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		pair := elt.(*ast.KeyValueExpr)
+		var key string
+		if ident, ok := pair.Key.(*ast.Ident); ok && !isMap {
+			key = ident.Name
+		} else {
+			keyValue, err := exprValue(fset, pair.Key)
+			if err != nil {
+				return nil, err
+			}
+			if str, ok := keyValue.(string); ok {
+				key = str
+			} else {
+				key = fmt.Sprint(keyValue)
+			}
+		}
+		value, err := exprValue(fset, pair.Value)
+		if err != nil {
+			return nil, err
+		}
+		object[key] = value
+	}
+	return object, nil
+}
+
+// callValue converts a type conversion, such as []byte("abc") or
+// float64(1), or a structures call building a list, tree or graph
+func callValue(fset *token.FileSet, call *ast.CallExpr) (interface{}, error) {
+	fun := call.Fun
+	switch index := fun.(type) {
+	case *ast.IndexExpr:
+		fun = index.X
+	case *ast.IndexListExpr:
+		fun = index.X
+	}
+
+	switch {
+	case isSelector(fun, "structures", "MustParse"), isSelector(fun, "structures", "NewTree"), isSelector(fun, "structures", "NewGraph"):
+		if len(call.Args) == 1 {
+			value, err := exprValue(fset, call.Args[0])
+			if notation, ok := value.(string); ok && err == nil {
+				return parseJSONValue(notation)
+			}
+		}
+	case isSelector(fun, "structures", "NewList"):
+		items := make([]interface{}, len(call.Args))
+		for i, arg := range call.Args {
+			item, err := exprValue(fset, arg)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	case len(call.Args) == 1 && isConversion(fun):
+		return exprValue(fset, call.Args[0])
+	}
+	return nil, fmt.Errorf("cannot read %s as a test value", source(fset, call))
+}
+
+// isConversion reports whether a called expression looks like a type:
+// a predeclared type name or a slice type
+func isConversion(fun ast.Expr) bool {
+	switch f := fun.(type) {
+	case *ast.ArrayType:
+		return true
+	case *ast.Ident:
+		switch f.Name {
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64", "string", "byte", "rune", "bool":
+			return true
+		}
+	}
+	return false
+}
+
+// isSelector reports whether an expression is pkg.name
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+// source prints an expression as Go source for error messages
+func source(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, node)
+	return buf.String()
+}
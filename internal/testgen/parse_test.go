@@ -0,0 +1,133 @@
+package testgen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTests_Typed(t *testing.T) {
+	code := `package problems
+
+import (
+	"testing"
+
+	"example.com/practice/structures"
+)
+
+func helper() {}
+
+func TestMergeKLists(t *testing.T) {
+	tests := []struct {
+		name  string
+		lists []*structures.ListNode
+		k     int64
+		opts  map[string]bool
+		data  []byte
+		want  *structures.ListNode
+	}{
+		{
+			name:  "keyed",
+			lists: []*structures.ListNode{structures.MustParse[*structures.ListNode]("[1,4]"), nil},
+			k:     -3,
+			opts:  map[string]bool{"fast": true},
+			data:  []byte("hi"),
+			want:  structures.NewList(1, 4),
+		},
+		{"positional", nil, 0x10, nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MergeKLists(tt.lists)
+			assert.True(t, structures.Match("unordered", tt.want, got))
+		})
+	}
+}
+`
+	testCases, err := parseTests([]byte(code))
+	require.NoError(t, err)
+	require.Len(t, testCases, 2)
+
+	assert.Equal(t, &TestCase{
+		Name: "keyed",
+		Inputs: []interface{}{
+			[]interface{}{[]interface{}{json.Number("1"), json.Number("4")}, nil},
+			json.Number("-3"),
+			map[string]interface{}{"fast": true},
+			"hi",
+		},
+		Expected: []interface{}{json.Number("1"), json.Number("4")},
+		Compare:  "unordered",
+	}, testCases[0])
+	assert.Equal(t, &TestCase{
+		Name:    "positional",
+		Inputs:  []interface{}{nil, json.Number("16"), nil, nil},
+		Compare: "unordered",
+	}, testCases[1])
+}
+
+func TestParseTests_Shapes(t *testing.T) {
+	code := `package problems
+
+func TestDivMod(t *testing.T) {
+	tests := []struct {
+		name         string
+		a, b         int
+		point        Point
+		want1, want2 int
+		compare      string
+	}{
+		{name: "one", a: 7, b: 2, point: Point{X: 1, Y: 'a'}, want1: 3, want2: 1, compare: "checkDiv"},
+	}
+	_ = tests
+}
+`
+	testCases, err := parseTests([]byte(code))
+	require.NoError(t, err)
+	require.Len(t, testCases, 1)
+	assert.Equal(t, []interface{}{json.Number("7"), json.Number("2"), map[string]interface{}{"X": json.Number("1"), "Y": "a"}}, testCases[0].Inputs)
+	assert.Equal(t, []interface{}{json.Number("3"), json.Number("1")}, testCases[0].Expected)
+	assert.Equal(t, "checkDiv", testCases[0].Compare)
+}
+
+func TestParseTests_Untyped(t *testing.T) {
+	code := `package problems
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int
+		expected int
+	}{
+		{"basic", []int{1, 2}, 3},
+	}
+	_ = tests
+}
+`
+	testCases, err := parseTests([]byte(code))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{json.Number("1"), json.Number("2")}, testCases[0].Inputs)
+	assert.Equal(t, json.Number("3"), testCases[0].Expected)
+}
+
+func TestParseTests_Errors(t *testing.T) {
+	_, err := parseTests([]byte("package problems\n\nfunc TestX(t *testing.T) {}\n"))
+	assert.ErrorContains(t, err, "no table of test cases found")
+
+	_, err = parseTests([]byte(`package problems
+
+func TestX(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+	}{
+		{name: "call", in: compute()},
+	}
+	_ = tests
+}
+`))
+	assert.ErrorContains(t, err, "test case 1: field in: cannot read compute() as a test value")
+}
@@ -1,13 +1,21 @@
 package testgen
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 )
 
+// ErrNoTests is returned when exporting a problem that has no test file
+var ErrNoTests = errors.New("no tests found")
+
 // Service handles test case generation operations
 type Service struct {
 	interactive *InteractiveInput
-	jsonImport  *JSONImporter
 	generator   *Generator
 }
 
@@ -15,15 +23,32 @@ type Service struct {
 func NewService() *Service {
 	return &Service{
 		interactive: NewInteractiveInput(),
-		jsonImport:  NewJSONImporter(),
 		generator:   NewGenerator(),
 	}
 }
 
+// SetCompare sets the comparison of test cases that do not name one,
+// overriding the problem's //dsa:compare directive
+func (s *Service) SetCompare(mode string) error {
+	if err := CheckCompare(mode); err != nil {
+		return err
+	}
+	s.generator.compare = mode
+	return nil
+}
+
 // GenerateInteractive generates test cases from interactive user input
+// When the function's signature is known, each parameter is prompted for
+// separately and parsed as JSON
 func (s *Service) GenerateInteractive(prob *problem.ProblemDetails, append bool) error {
 	// Get test cases from interactive input
-	testCases, err := s.interactive.Collect()
+	var testCases []*TestCase
+	sig, err := LoadSignature(prob.Slug)
+	if err == nil {
+		testCases, err = s.interactive.CollectTyped(sig)
+	} else {
+		testCases, err = s.interactive.Collect()
+	}
 	if err != nil {
 		return err
 	}
@@ -32,10 +57,13 @@ func (s *Service) GenerateInteractive(prob *problem.ProblemDetails, append bool)
 	return s.generator.Generate(prob, testCases, append)
 }
 
-// GenerateFromFile generates test cases from JSON file
+// GenerateFromFile generates test cases from a JSON, YAML or CSV file
 func (s *Service) GenerateFromFile(prob *problem.ProblemDetails, filePath string, append bool) error {
-	// Import test cases from JSON file
-	testCases, err := s.jsonImport.ImportFromFile(filePath)
+	importer, err := NewImporter(filePath)
+	if err != nil {
+		return err
+	}
+	testCases, err := importer.ImportFromFile(filePath)
 	if err != nil {
 		return err
 	}
@@ -44,9 +72,49 @@ func (s *Service) GenerateFromFile(prob *problem.ProblemDetails, filePath string
 	return s.generator.Generate(prob, testCases, append)
 }
 
+// Export writes the cases of a problem's test table in the JSON or YAML test
+// file format and returns how many were written
+func (s *Service) Export(slug string, w io.Writer, format string) (int, error) {
+	layout := workspace.ProblemLayout(slug)
+	path := layout.Tests()
+	if _, err := os.Stat(path); err != nil {
+		path = layout.ReferenceTests()
+	}
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("%w for %s (looked for %s)", ErrNoTests, slug, layout.Tests())
+	}
+
+	testCases, err := ParseTestFile(path)
+	if err != nil {
+		return 0, err
+	}
+	if err := Export(w, testCases, format); err != nil {
+		return 0, err
+	}
+	return len(testCases), nil
+}
+
 // TestCase represents a single test case
 type TestCase struct {
 	Name     string
 	Inputs   []interface{}
 	Expected interface{}
+	Compare  string // Comparison of the results, e.g. unordered; see CheckCompare
+
+	// Set by the generator when the function's signature is known
+	Args []string // Inputs as typed Go literals
+	Want []string // Expected values as typed Go literals, one per result
+}
+
+// Check is how the generated tests compare results for one comparison mode
+type Check struct {
+	Mode       string   // e.g. exact, float-tolerance:1e-6 or a checker function
+	Assertions []string // Go statements run after the call
+}
+
+// Column is a field of the generated test table
+type Column struct {
+	Name   string
+	Type   string // Go source, e.g. []int64
+	Format string // For lists, trees and graphs, the function writing values in LeetCode notation for comparison
 }
@@ -0,0 +1,349 @@
+package testgen
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+)
+
+// ErrNoSignature is returned when none of the problem's source files declares
+// the function under test
+var ErrNoSignature = errors.New("function signature not found")
+
+// Signature is the function under test with its parameter and result types,
+// read with go/types so test values can be written as typed Go literals
+type Signature struct {
+	FuncName string
+	Source   string  // File the signature was read from
+	Params   []Value // Inputs, in call order
+	Results  []Value // Expected values, in result order
+	Variadic bool    // The last parameter is variadic (its Type is the slice)
+	InPlace  bool    // No results: the expected value is the first parameter after the call
+	Compare  string  // Comparison from a //dsa:compare directive in the doc comment, if any
+
+	pkg     *types.Package
+	module  string // Module containing the source; empty outside a module
+	imports map[string]bool
+
+	structuresPath string // Import path of the workspace's structures package
+	structuresErr  error  // Why structuresPath is unknown
+}
+
+// UsesStructures reports whether the generated tests import the structures
+// package, which must then exist in the workspace
+func (s *Signature) UsesStructures() bool {
+	return s.structuresPath != "" && s.imports[s.structuresPath]
+}
+
+// Value is one column of the test table: a parameter or an expected result
+type Value struct {
+	Name string // Field name in the test table
+	Type types.Type
+}
+
+// reservedFields are the test table fields that parameters must not shadow
+var reservedFields = map[string]bool{"name": true, "tt": true, "tests": true, "t": true}
+
+// LoadSignature reads the signature of the problem's function from its
+// solution, falling back to its boilerplate and the reference boilerplate
+func LoadSignature(slug string) (*Signature, error) {
+	layout := workspace.ProblemLayout(slug)
+	funcName := workspace.FunctionName(slug)
+
+	var searched []string
+	for _, path := range []string{layout.Solution(), layout.Boilerplate(), layout.ReferenceBoilerplate()} {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		searched = append(searched, path)
+		sig, err := ParseSignature(path, funcName)
+		if errors.Is(err, ErrNoSignature) {
+			continue
+		}
+		return sig, err
+	}
+
+	if len(searched) == 0 {
+		return nil, fmt.Errorf("%w: no solution or boilerplate for %s (looked for %s)", ErrNoSignature, slug, layout.Solution())
+	}
+	return nil, fmt.Errorf("%w: func %s is not declared in %s", ErrNoSignature, funcName, strings.Join(searched, ", "))
+}
+
+// ParseSignature type-checks a Go source file and returns the signature of
+// the named top-level function. Type errors elsewhere in the file (such as an
+// unfinished solution) are ignored; the signature's own types must resolve.
+func ParseSignature(path, funcName string) (*Signature, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var decl *ast.FuncDecl
+	for _, d := range file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == funcName {
+			decl = fn
+			break
+		}
+	}
+	if decl == nil {
+		return nil, fmt.Errorf("%w: func %s is not declared in %s", ErrNoSignature, funcName, path)
+	}
+
+	imports := &moduleImporter{fset: fset, fallback: importer.ForCompiler(fset, "source", nil), packages: map[string]*types.Package{}}
+	imports.module, imports.root, _ = workspace.Module(filepath.Dir(path))
+
+	info := &types.Info{Defs: map[*ast.Ident]types.Object{}}
+	config := types.Config{
+		Importer: imports,
+		Error:    func(error) {}, // Keep checking past errors in the function bodies
+	}
+	pkg, _ := config.Check(file.Name.Name, fset, []*ast.File{file}, info)
+
+	fn, ok := info.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("failed to type-check func %s in %s", funcName, path)
+	}
+	signature := fn.Type().(*types.Signature)
+
+	sig := &Signature{
+		FuncName: funcName,
+		Source:   path,
+		Variadic: signature.Variadic(),
+		Compare:  compareDirective(decl.Doc),
+		pkg:      pkg,
+		module:   imports.module,
+		imports:  map[string]bool{},
+	}
+	sig.structuresPath, sig.structuresErr = workspace.ImportPath(workspace.Structures())
+
+	for i := 0; i < signature.Params().Len(); i++ {
+		param := signature.Params().At(i)
+		name := param.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		if reservedFields[name] || strings.HasPrefix(name, "want") || strings.HasPrefix(name, "got") {
+			name += "Arg"
+		}
+		sig.Params = append(sig.Params, Value{Name: name, Type: param.Type()})
+	}
+
+	results := signature.Results()
+	switch {
+	case results.Len() == 0 && len(sig.Params) > 0:
+		sig.InPlace = true
+		sig.Results = []Value{{Name: "want", Type: sig.Params[0].Type}}
+	case results.Len() == 1:
+		sig.Results = []Value{{Name: "want", Type: results.At(0).Type()}}
+	default:
+		for i := 0; i < results.Len(); i++ {
+			sig.Results = append(sig.Results, Value{Name: fmt.Sprintf("want%d", i+1), Type: results.At(i).Type()})
+		}
+	}
+
+	for _, value := range append(append([]Value{}, sig.Params...), sig.Results...) {
+		if err := checkResolved(value.Type); err != nil {
+			return nil, fmt.Errorf("func %s in %s: %s: %w", funcName, path, value.Name, err)
+		}
+	}
+
+	return sig, nil
+}
+
+// checkResolved returns an error if a type could not be resolved, typically
+// because it comes from a package the type checker could not import
+func checkResolved(t types.Type) error {
+	switch t := t.(type) {
+	case *types.Basic:
+		if t.Kind() == types.Invalid {
+			return fmt.Errorf("type could not be resolved")
+		}
+	case *types.Slice:
+		return checkResolved(t.Elem())
+	case *types.Array:
+		return checkResolved(t.Elem())
+	case *types.Pointer:
+		return checkResolved(t.Elem())
+	case *types.Map:
+		if err := checkResolved(t.Key()); err != nil {
+			return err
+		}
+		return checkResolved(t.Elem())
+	}
+	return nil
+}
+
+// TypeString returns a type as Go source in the package under test,
+// recording the packages the test file must import
+func (s *Signature) TypeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == s.pkg {
+			return ""
+		}
+		s.imports[p.Path()] = true
+		return p.Name()
+	})
+}
+
+// Imports returns the packages used by the literals and type strings
+// returned so far: standard library packages, then all others
+func (s *Signature) Imports() (std, other []string) {
+	for path := range s.imports {
+		inModule := s.module != "" && (path == s.module || strings.HasPrefix(path, s.module+"/"))
+		if inModule || strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	return std, other
+}
+
+// Columns returns the parameter and result columns as Go source
+func (s *Signature) Columns() (params, results []Column) {
+	for _, param := range s.Params {
+		params = append(params, Column{Name: param.Name, Type: s.TypeString(param.Type)})
+	}
+	for _, result := range s.Results {
+		column := Column{Name: result.Name, Type: s.TypeString(result.Type)}
+		if hasShape(result.Type) && s.structuresPath != "" {
+			s.imports[s.structuresPath] = true
+			column.Format = "structures.Format"
+		}
+		results = append(results, column)
+	}
+	return params, results
+}
+
+// Call returns the call of the function under test with the table's inputs,
+// e.g. "TwoSum(tt.nums, tt.target)"
+func (s *Signature) Call() string {
+	args := make([]string, len(s.Params))
+	for i, param := range s.Params {
+		args[i] = "tt." + param.Name
+	}
+	call := s.FuncName + "(" + strings.Join(args, ", ")
+	if s.Variadic {
+		call += "..."
+	}
+	return call + ")"
+}
+
+// Got returns the variables compared against the expected values: the call's
+// results, or the first input for a function that works in place
+func (s *Signature) Got() []string {
+	if s.InPlace {
+		return []string{"tt." + s.Params[0].Name}
+	}
+	if len(s.Results) == 1 {
+		return []string{"got"}
+	}
+	got := make([]string, len(s.Results))
+	for i := range s.Results {
+		got[i] = fmt.Sprintf("got%d", i+1)
+	}
+	return got
+}
+
+// Convert writes a test case's inputs and expected value as typed Go
+// literals. A function with several results expects an array of values.
+func (s *Signature) Convert(tc *TestCase) (args, want []string, err error) {
+	if len(tc.Inputs) != len(s.Params) {
+		return nil, nil, fmt.Errorf("test '%s' has %d input(s), but %s takes %d (%s)",
+			tc.Name, len(tc.Inputs), s.FuncName, len(s.Params), s.describe(s.Params))
+	}
+	for i, param := range s.Params {
+		literal, err := s.Literal(param.Type, tc.Inputs[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("test '%s' input %d (%s %s): %w", tc.Name, i+1, param.Name, s.TypeString(param.Type), err)
+		}
+		args = append(args, literal)
+	}
+
+	expected := []interface{}{tc.Expected}
+	if len(s.Results) > 1 {
+		values, ok := tc.Expected.([]interface{})
+		if !ok || len(values) != len(s.Results) {
+			return nil, nil, fmt.Errorf("test '%s': %s returns %d values (%s), so expected must be an array of %d values",
+				tc.Name, s.FuncName, len(s.Results), s.describe(s.Results), len(s.Results))
+		}
+		expected = values
+	}
+	for i, result := range s.Results {
+		literal, err := s.Literal(result.Type, expected[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("test '%s' expected %s (%s): %w", tc.Name, result.Name, s.TypeString(result.Type), err)
+		}
+		want = append(want, literal)
+	}
+
+	return args, want, nil
+}
+
+// moduleImporter imports packages of the module containing the solution,
+// such as the workspace's structures package, by type-checking their source,
+// and everything else with the fallback importer
+type moduleImporter struct {
+	fset     *token.FileSet
+	module   string // Module path; empty outside a module
+	root     string // Module directory
+	fallback types.Importer
+	packages map[string]*types.Package
+}
+
+// Import implements types.Importer
+func (m *moduleImporter) Import(path string) (*types.Package, error) {
+	if m.module == "" || (path != m.module && !strings.HasPrefix(path, m.module+"/")) {
+		return m.fallback.Import(path)
+	}
+	if pkg, ok := m.packages[path]; ok {
+		return pkg, nil
+	}
+
+	dir := filepath.Join(m.root, filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(path, m.module), "/")))
+	sources, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, source := range sources {
+		if strings.HasSuffix(source, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(m.fset, source, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files for %s in %s", path, dir)
+	}
+
+	config := types.Config{Importer: m, Error: func(error) {}}
+	pkg, _ := config.Check(path, m.fset, files, nil)
+	m.packages[path] = pkg
+	return pkg, nil
+}
+
+// describe lists values as "name type" pairs for error messages
+func (s *Signature) describe(values []Value) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = value.Name + " " + s.TypeString(value.Type)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,233 @@
+package testgen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const signatureSource = `package solutions
+
+import "container/list"
+
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+func MaxProfit(prices []int64, fee int) int64 {
+	return undefinedHelper(prices) // Unfinished solutions still type-check the signature
+}
+
+func DivMod(a, b int) (int, int) { return a / b, a % b }
+
+func Sum(name string, nums ...int) int { return 0 }
+
+func Reverse(s []byte) {}
+
+func Reverse2(head *ListNode) *ListNode { return head }
+
+func Queue(l *list.List) bool { return false }
+
+func Groups(byKey map[string][]int, grid [][]rune, weights [3]float32, any interface{}) uint8 { return 0 }
+`
+
+func parseTestSignature(t *testing.T, funcName string) *Signature {
+	path := filepath.Join(t.TempDir(), "solution.go")
+	require.NoError(t, os.WriteFile(path, []byte(signatureSource), 0644))
+	sig, err := ParseSignature(path, funcName)
+	require.NoError(t, err)
+	return sig
+}
+
+func TestParseSignature(t *testing.T) {
+	sig := parseTestSignature(t, "MaxProfit")
+	params, results := sig.Columns()
+	assert.Equal(t, []Column{{Name: "prices", Type: "[]int64"}, {Name: "fee", Type: "int"}}, params)
+	assert.Equal(t, []Column{{Name: "want", Type: "int64"}}, results)
+	assert.Equal(t, "MaxProfit(tt.prices, tt.fee)", sig.Call())
+	assert.Equal(t, []string{"got"}, sig.Got())
+
+	t.Run("multiple results", func(t *testing.T) {
+		sig := parseTestSignature(t, "DivMod")
+		_, results := sig.Columns()
+		assert.Equal(t, []Column{{Name: "want1", Type: "int"}, {Name: "want2", Type: "int"}}, results)
+		assert.Equal(t, []string{"got1", "got2"}, sig.Got())
+	})
+
+	t.Run("variadic and reserved names", func(t *testing.T) {
+		sig := parseTestSignature(t, "Sum")
+		assert.Equal(t, "Sum(tt.nameArg, tt.nums...)", sig.Call())
+	})
+
+	t.Run("in place", func(t *testing.T) {
+		sig := parseTestSignature(t, "Reverse")
+		assert.True(t, sig.InPlace)
+		_, results := sig.Columns()
+		assert.Equal(t, []Column{{Name: "want", Type: "[]byte"}}, results)
+		assert.Equal(t, []string{"tt.s"}, sig.Got())
+	})
+
+	t.Run("imported types", func(t *testing.T) {
+		sig := parseTestSignature(t, "Queue")
+		params, _ := sig.Columns()
+		assert.Equal(t, "*list.List", params[0].Type)
+		std, other := sig.Imports()
+		assert.Equal(t, []string{"container/list"}, std)
+		assert.Empty(t, other)
+	})
+
+	t.Run("missing function", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "solution.go")
+		require.NoError(t, os.WriteFile(path, []byte(signatureSource), 0644))
+		_, err := ParseSignature(path, "TwoSum")
+		assert.ErrorIs(t, err, ErrNoSignature)
+	})
+}
+
+func TestSignature_Literal(t *testing.T) {
+	sig := parseTestSignature(t, "Groups")
+	byKey, grid, weights, dynamic := sig.Params[0].Type, sig.Params[1].Type, sig.Params[2].Type, sig.Params[3].Type
+	prices := parseTestSignature(t, "MaxProfit").Params[0].Type
+	list := parseTestSignature(t, "Reverse2")
+	head := list.Params[0].Type
+	bytes := parseTestSignature(t, "Reverse").Params[0].Type
+
+	tests := []struct {
+		name  string
+		value func() (string, error)
+		want  string
+	}{
+		{"int64 slice", func() (string, error) {
+			return sig.Literal(prices, []interface{}{json.Number("9223372036854775807"), 1})
+		}, "[]int64{9223372036854775807, 1}"},
+		{"nil slice", func() (string, error) { return sig.Literal(prices, nil) }, "nil"},
+		{"empty slice", func() (string, error) { return sig.Literal(prices, []interface{}{}) }, "[]int64{}"},
+		{"map of slices", func() (string, error) {
+			return sig.Literal(byKey, map[string]interface{}{"b": []interface{}{2}, "a": nil})
+		}, `map[string][]int{"a": nil, "b": []int{2}}`},
+		{"runes from strings", func() (string, error) {
+			return sig.Literal(grid, []interface{}{[]interface{}{"a", "é"}})
+		}, `[][]rune{[]rune{'a', 'é'}}`},
+		{"float array", func() (string, error) {
+			return sig.Literal(weights, []interface{}{json.Number("0.5"), 1, 2.25})
+		}, "[3]float32{0.5, 1, 2.25}"},
+		{"bytes from string", func() (string, error) { return sig.Literal(bytes, "hello") }, `[]byte("hello")`},
+		{"struct pointer", func() (string, error) {
+			return list.Literal(head, map[string]interface{}{"val": 1, "next": map[string]interface{}{"val": 2}})
+		}, "&ListNode{Val: 1, Next: &ListNode{Val: 2}}"},
+		{"empty interface", func() (string, error) {
+			return sig.Literal(dynamic, []interface{}{json.Number("1"), "x", 1.5})
+		}, `[]interface{}{1, "x", float64(1.5)}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.value()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSignature_Literal_Errors(t *testing.T) {
+	groups := parseTestSignature(t, "Groups")
+	prices := parseTestSignature(t, "MaxProfit").Params[0].Type
+	uint8Type := groups.Results[0].Type
+	list := parseTestSignature(t, "Reverse2")
+	head := list.Params[0].Type
+
+	tests := []struct {
+		name  string
+		value func() error
+		want  string
+	}{
+		{"string as number", func() error {
+			_, err := groups.Literal(prices, []interface{}{1, "a"})
+			return err
+		}, `element 1: cannot convert "a" (string) to int64`},
+		{"fraction as integer", func() error {
+			_, err := groups.Literal(prices, []interface{}{json.Number("1.5")})
+			return err
+		}, "cannot convert 1.5 (number) to int64"},
+		{"overflow", func() error {
+			_, err := groups.Literal(uint8Type, json.Number("256"))
+			return err
+		}, "256 overflows uint8"},
+		{"unknown field", func() error {
+			_, err := list.Literal(head, map[string]interface{}{"value": 1})
+			return err
+		}, `ListNode has no field "value"`},
+		{"object as slice", func() error {
+			_, err := groups.Literal(prices, map[string]interface{}{})
+			return err
+		}, "cannot convert an object to []int64: expected an array"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.value()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestSignature_Convert(t *testing.T) {
+	sig := parseTestSignature(t, "DivMod")
+
+	args, want, err := sig.Convert(&TestCase{Name: "ok", Inputs: []interface{}{7, 2}, Expected: []interface{}{3, 1}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"7", "2"}, args)
+	assert.Equal(t, []string{"3", "1"}, want)
+
+	_, _, err = sig.Convert(&TestCase{Name: "short", Inputs: []interface{}{7}, Expected: []interface{}{3, 1}})
+	assert.ErrorContains(t, err, "test 'short' has 1 input(s), but DivMod takes 2 (a int, b int)")
+
+	_, _, err = sig.Convert(&TestCase{Name: "single", Inputs: []interface{}{7, 2}, Expected: 3})
+	assert.ErrorContains(t, err, "expected must be an array of 2 values")
+
+	_, _, err = sig.Convert(&TestCase{Name: "bad", Inputs: []interface{}{7, "x"}, Expected: []interface{}{3, 1}})
+	assert.ErrorContains(t, err, `test 'bad' input 2 (b int): cannot convert "x" (string) to int`)
+}
+
+func TestGenerator_Generate_Typed(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "solutions"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "problems"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "solutions", "max_profit.go"), []byte(signatureSource), 0644))
+
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
+	prob := &problem.ProblemDetails{Problem: database.Problem{Slug: "max-profit", Title: "Max Profit"}}
+	testCases := []*TestCase{
+		{Name: "no prices", Inputs: []interface{}{nil, json.Number("2")}, Expected: json.Number("0")},
+		{Name: "big", Inputs: []interface{}{[]interface{}{json.Number("4000000000")}, 1}, Expected: json.Number("4000000000")},
+	}
+
+	require.NoError(t, NewGenerator().Generate(prob, testCases, false))
+
+	content, err := os.ReadFile(filepath.Join("problems", "max_profit_test.go"))
+	require.NoError(t, err)
+	source := string(content)
+	assert.Contains(t, source, "prices []int64")
+	assert.Contains(t, source, "want   int64")
+	assert.Contains(t, source, "prices: nil,")
+	assert.Contains(t, source, "prices: []int64{4000000000},")
+	assert.Contains(t, source, "got := MaxProfit(tt.prices, tt.fee)")
+	assert.Contains(t, source, "assert.Equal(t, tt.want, got)")
+
+	t.Run("conversion errors name the test", func(t *testing.T) {
+		bad := []*TestCase{{Name: "typo", Inputs: []interface{}{[]interface{}{"x"}, 1}, Expected: 0}}
+		err := NewGenerator().Generate(prob, bad, false)
+		assert.ErrorContains(t, err, "test 'typo' input 1 (prices []int64): element 0: cannot convert \"x\" (string) to int64")
+	})
+}
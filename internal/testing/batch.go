@@ -0,0 +1,84 @@
+package testing
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+)
+
+// BatchResult is the outcome of testing one problem of a multi-problem run
+type BatchResult struct {
+	Problem  problem.ProblemWithStatus
+	Result   *TestResult // Nil if the tests could not run or were skipped
+	Err      error
+	Skipped  bool // The problem has no tests
+	Duration time.Duration
+}
+
+// Passed reports whether all of the problem's tests passed
+func (r *BatchResult) Passed() bool {
+	return r.Result != nil && r.Result.AllPassed
+}
+
+// Regressed reports whether a problem that was solved before the run no
+// longer passes its tests
+func (r *BatchResult) Regressed() bool {
+	return r.Problem.IsSolved && !r.Skipped && !r.Passed()
+}
+
+// RunAll tests the problems concurrently on a pool of workers and sends each
+// result as soon as it is ready, so callers can report progress live. The
+// channel is closed once every problem has been tested.
+func (s *Service) RunAll(problems []problem.ProblemWithStatus, workers int, verbose, race bool) <-chan *BatchResult {
+	return runAll(problems, workers, func(prob *problem.ProblemDetails) (*TestResult, error) {
+		return s.ExecuteTests(prob, verbose, race)
+	})
+}
+
+// runAll runs execute for each problem with a pool of workers
+func runAll(problems []problem.ProblemWithStatus, workers int, execute func(*problem.ProblemDetails) (*TestResult, error)) <-chan *BatchResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan problem.ProblemWithStatus)
+	results := make(chan *BatchResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for prob := range jobs {
+				results <- runOne(prob, execute)
+			}
+		}()
+	}
+
+	go func() {
+		for _, prob := range problems {
+			jobs <- prob
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// runOne tests a single problem, skipping problems without tests
+func runOne(prob problem.ProblemWithStatus, execute func(*problem.ProblemDetails) (*TestResult, error)) *BatchResult {
+	result := &BatchResult{Problem: prob}
+	if len(workspace.ProblemLayout(prob.Slug).TestFiles()) == 0 {
+		result.Skipped = true
+		return result
+	}
+
+	start := time.Now()
+	result.Result, result.Err = execute(&problem.ProblemDetails{Problem: prob.Problem})
+	result.Duration = time.Since(start)
+	return result
+}
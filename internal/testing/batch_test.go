@@ -0,0 +1,68 @@
+package testing
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAll(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	require.NoError(t, os.Chdir(tmpDir))
+	require.NoError(t, os.MkdirAll("problems", 0755))
+	for _, name := range []string{"two_sum", "binary_search", "broken"} {
+		require.NoError(t, os.WriteFile(filepath.Join("problems", name+"_test.go"), []byte("package problems\n"), 0644))
+	}
+
+	problems := []problem.ProblemWithStatus{
+		{Problem: database.Problem{Slug: "two-sum"}, IsSolved: true},
+		{Problem: database.Problem{Slug: "binary-search"}, IsSolved: true},
+		{Problem: database.Problem{Slug: "broken"}},
+		{Problem: database.Problem{Slug: "no-tests"}, IsSolved: true},
+	}
+
+	var mu sync.Mutex
+	running, most := 0, 0
+	results := map[string]*BatchResult{}
+	for result := range runAll(problems, 2, func(prob *problem.ProblemDetails) (*TestResult, error) {
+		mu.Lock()
+		running++
+		most = max(most, running)
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+
+		switch prob.Slug {
+		case "broken":
+			return nil, errors.New("failed to execute go test")
+		case "binary-search":
+			return &TestResult{PassedCount: 1, TotalCount: 2}, nil
+		}
+		return &TestResult{AllPassed: true}, nil
+	}) {
+		results[result.Problem.Slug] = result
+	}
+
+	require.Len(t, results, 4)
+	assert.Equal(t, 2, most, "problems are tested by two workers at once")
+
+	assert.True(t, results["two-sum"].Passed())
+	assert.False(t, results["two-sum"].Regressed())
+	assert.True(t, results["binary-search"].Regressed(), "a solved problem that fails regressed")
+	assert.Error(t, results["broken"].Err)
+	assert.False(t, results["broken"].Regressed(), "an unsolved problem cannot regress")
+	assert.True(t, results["no-tests"].Skipped)
+	assert.False(t, results["no-tests"].Regressed(), "skipped problems do not regress")
+}
@@ -0,0 +1,139 @@
+package testing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+	"gorm.io/gorm"
+)
+
+// Cache keeps the latest test result of each problem and variant. Results
+// are keyed by the Go version, the go test flags and the contents of the
+// solution, test and shared workspace files, so any change to them runs the
+// tests again.
+type Cache struct {
+	db *gorm.DB
+	mu sync.Mutex // Multi-problem runs use the cache from several workers
+}
+
+// NewCache creates a new test result cache
+func NewCache(db *gorm.DB) *Cache {
+	return &Cache{db: db}
+}
+
+// Key returns the cache key of a test run of the given files with the given
+// go test flags
+func (c *Cache) Key(slug string, files []string, flags ...string) (string, error) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%s\x00", toolchainVersion(), slug, strings.Join(flags, " "))
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", path, err)
+		}
+		fmt.Fprintf(hash, "%s\x00", keyPath(path))
+		_, err = io.Copy(hash, file)
+		file.Close()
+		if err != nil {
+			return "", fmt.Errorf("failed to hash %s: %w", path, err)
+		}
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// keyPath names a file in cache keys relative to the workspace root, so runs
+// from any directory of the workspace share their entries
+func keyPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	root, err := filepath.Abs(workspace.Root())
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Get returns the result cached under key, marked as cached
+func (c *Cache) Get(key string) (*TestResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Find rather than First, so a miss is not logged as an error
+	var entries []database.TestCache
+	if err := c.db.Where("key = ?", key).Limit(1).Find(&entries).Error; err != nil || len(entries) == 0 {
+		return nil, false
+	}
+	var result TestResult
+	if err := json.Unmarshal([]byte(entries[0].Result), &result); err != nil {
+		return nil, false
+	}
+	result.Cached = true
+	return &result, true
+}
+
+// Put caches a result under key, replacing the problem's previous result
+func (c *Cache) Put(key string, problemID uint, variant string, result *TestResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode test result: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("problem_id = ? AND variant = ?", problemID, variant).Delete(&database.TestCache{}).Error; err != nil {
+			return fmt.Errorf("failed to replace cached result: %w", err)
+		}
+		entry := &database.TestCache{Key: key, ProblemID: problemID, Variant: variant, Result: string(data)}
+		if err := tx.Save(entry).Error; err != nil {
+			return fmt.Errorf("failed to cache test result: %w", err)
+		}
+		return nil
+	})
+}
+
+// sharedFiles returns the workspace files the tests of every problem build
+// with: the structures helper package and the module files
+func sharedFiles() []string {
+	files, _ := filepath.Glob(workspace.Structures("*.go"))
+	for _, path := range []string{workspace.Path("go.mod"), workspace.Path("go.sum")} {
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+var (
+	toolchainOnce sync.Once
+	toolchain     string
+)
+
+// toolchainVersion returns the version of the go command that runs tests
+func toolchainVersion() string {
+	toolchainOnce.Do(func() {
+		toolchain = runtime.Version()
+		if output, err := exec.Command("go", "env", "GOVERSION").Output(); err == nil {
+			toolchain = strings.TrimSpace(string(output))
+		}
+	})
+	return toolchain
+}
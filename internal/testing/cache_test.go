@@ -0,0 +1,168 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupCacheDB creates an in-memory SQLite database for testing
+func setupCacheDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&database.TestCache{}))
+	return db
+}
+
+func TestCache_Key(t *testing.T) {
+	dir := t.TempDir()
+	solutionFile := filepath.Join(dir, "two_sum.go")
+	testFile := filepath.Join(dir, "two_sum_test.go")
+	require.NoError(t, os.WriteFile(solutionFile, []byte("package problems\n"), 0644))
+	require.NoError(t, os.WriteFile(testFile, []byte("package problems\n"), 0644))
+
+	cache := NewCache(setupCacheDB(t))
+	files := []string{solutionFile, testFile}
+	key, err := cache.Key("two-sum", files)
+	require.NoError(t, err)
+
+	again, err := cache.Key("two-sum", files)
+	require.NoError(t, err)
+	assert.Equal(t, key, again, "unchanged files have the same key")
+
+	raced, err := cache.Key("two-sum", files, "-race")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, raced, "flags are part of the key")
+
+	require.NoError(t, os.WriteFile(solutionFile, []byte("package problems\n\n// edited\n"), 0644))
+	edited, err := cache.Key("two-sum", files)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, edited, "an edited solution has a new key")
+
+	_, err = cache.Key("two-sum", []string{filepath.Join(dir, "missing.go")})
+	assert.Error(t, err)
+}
+
+func TestCache_Key_FromSubdirectory(t *testing.T) {
+	dir := t.TempDir()
+	_, err := workspace.Init(dir, "")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "problems", "two_sum_test.go"), []byte("package problems\n"), 0644))
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+
+	cache := NewCache(setupCacheDB(t))
+
+	require.NoError(t, os.Chdir(dir))
+	atRoot, err := cache.Key("two-sum", []string{workspace.ProblemLayout("two-sum").Tests()})
+	require.NoError(t, err)
+
+	require.NoError(t, os.Chdir(filepath.Join(dir, "solutions")))
+	below, err := cache.Key("two-sum", []string{workspace.ProblemLayout("two-sum").Tests()})
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join("..", "problems", "two_sum_test.go"), workspace.ProblemLayout("two-sum").Tests())
+	assert.Equal(t, atRoot, below, "the key does not depend on the current directory")
+}
+
+func TestCache_GetPut(t *testing.T) {
+	cache := NewCache(setupCacheDB(t))
+
+	_, ok := cache.Get("first")
+	assert.False(t, ok)
+
+	result := &TestResult{PassedCount: 1, TotalCount: 2, FailedTests: []FailedTest{{Name: "TestTwoSum", Message: "Not equal"}}}
+	require.NoError(t, cache.Put("first", 1, "", result))
+
+	cached, ok := cache.Get("first")
+	require.True(t, ok)
+	assert.True(t, cached.Cached)
+	cached.Cached = false
+	assert.Equal(t, result, cached)
+
+	require.NoError(t, cache.Put("hashmap", 1, "hashmap", result))
+	require.NoError(t, cache.Put("second", 1, "", &TestResult{AllPassed: true}))
+	_, ok = cache.Get("first")
+	assert.False(t, ok, "a new result replaces the problem's previous one")
+	_, ok = cache.Get("hashmap")
+	assert.True(t, ok, "variants are cached separately")
+}
+
+func TestService_ExecuteTests_Cached(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	require.NoError(t, os.Chdir(tmpDir))
+	require.NoError(t, os.MkdirAll("problems", 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("problems", "two_sum_test.go"), []byte("package problems\n"), 0644))
+
+	svc := NewService(setupCacheDB(t))
+	prob := &problem.ProblemDetails{Problem: database.Problem{ID: 1, Slug: "two-sum"}}
+	runs := 0
+	run := func() (*TestResult, error) {
+		runs++
+		return &TestResult{AllPassed: true}, nil
+	}
+
+	first, err := svc.cached(prob, "", false, false, run)
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+
+	second, err := svc.cached(prob, "", false, false, run)
+	require.NoError(t, err)
+	assert.True(t, second.Cached)
+	assert.Equal(t, 1, runs, "unchanged code is not tested again")
+
+	svc.UseCache(false)
+	third, err := svc.cached(prob, "", false, false, run)
+	require.NoError(t, err)
+	assert.False(t, third.Cached)
+	assert.Equal(t, 2, runs)
+}
+
+func TestService_ExecuteTests_SharedFilesInvalidate(t *testing.T) {
+	tmpDir := t.TempDir()
+	oldWd, _ := os.Getwd()
+	t.Cleanup(func() { os.Chdir(oldWd) })
+	require.NoError(t, os.Chdir(tmpDir))
+	require.NoError(t, os.MkdirAll("problems", 0755))
+	require.NoError(t, os.MkdirAll("structures", 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("problems", "two_sum_test.go"), []byte("package problems\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join("structures", "structures.go"), []byte("package structures\n"), 0644))
+	require.NoError(t, os.WriteFile("go.mod", []byte("module practice\n"), 0644))
+
+	svc := NewService(setupCacheDB(t))
+	prob := &problem.ProblemDetails{Problem: database.Problem{ID: 1, Slug: "two-sum"}}
+	runs := 0
+	run := func() (*TestResult, error) {
+		runs++
+		return &TestResult{AllPassed: true}, nil
+	}
+
+	_, err := svc.cached(prob, "", false, false, run)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join("structures", "structures.go"), []byte("package structures\n\n// edited\n"), 0644))
+	result, err := svc.cached(prob, "", false, false, run)
+	require.NoError(t, err)
+	assert.False(t, result.Cached, "an edited structures file runs the tests again")
+	assert.Equal(t, 2, runs)
+
+	require.NoError(t, os.WriteFile("go.mod", []byte("module practice\n\ngo 1.25\n"), 0644))
+	result, err = svc.cached(prob, "", false, false, run)
+	require.NoError(t, err)
+	assert.False(t, result.Cached, "an edited go.mod runs the tests again")
+	assert.Equal(t, 3, runs)
+
+	result, err = svc.cached(prob, "", false, false, run)
+	require.NoError(t, err)
+	assert.True(t, result.Cached)
+}
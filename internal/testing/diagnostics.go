@@ -0,0 +1,88 @@
+package testing
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Diagnostic is a compile error or vet finding reported by go test for one
+// of the files it built
+type Diagnostic struct {
+	File       string // Path in the workspace, e.g. solutions/two_sum.go
+	Line       int
+	Column     int // 0 when the compiler reports none
+	Message    string
+	InSolution bool // The error is in the solution rather than its tests
+}
+
+// diagnosticPattern matches the first line of a diagnostic, e.g.
+// "problems/.dsa-stage-1/solution.go:7:9: undefined: x"
+var diagnosticPattern = regexp.MustCompile(`^(\S+\.go):(\d+)(?::(\d+))?: (.+)$`)
+
+// testRun describes the files of a go test run
+type testRun struct {
+	files    []string          // Files passed to go test
+	sources  map[string]string // Workspace file each staged file was copied from
+	solution string            // Workspace solution file, if one is tested
+}
+
+// unstage rewrites the paths of staged files in go test output, e.g. in
+// assertion traces and panics, to the workspace files they were copied from,
+// since the stage is removed after the run
+func (r testRun) unstage(output string) string {
+	if len(r.sources) == 0 {
+		return output
+	}
+
+	// Absolute paths come first so a relative path inside one is not
+	// replaced on its own
+	var absolute, relative []string
+	for staged, source := range r.sources {
+		if abs, err := filepath.Abs(staged); err == nil {
+			absolute = append(absolute, abs, source)
+		}
+		relative = append(relative, filepath.Clean(staged), source)
+	}
+	return strings.NewReplacer(append(absolute, relative...)...).Replace(output)
+}
+
+// parseDiagnostics reads compiler diagnostics from the output of a build that
+// failed, or of a setup that failed, e.g. on an import no module provides,
+// mapping staged files back to the workspace files they came from. Indented
+// lines following a diagnostic, such as have/want signatures, are part of its
+// message.
+func parseDiagnostics(output string, run testRun) []Diagnostic {
+	if !strings.Contains(output, "[build failed]") && !strings.Contains(output, "[setup failed]") {
+		return nil
+	}
+
+	sources := map[string]string{}
+	for staged, source := range run.sources {
+		sources[filepath.Clean(staged)] = source
+	}
+
+	var diagnostics []Diagnostic
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "\t") && len(diagnostics) > 0 {
+			last := &diagnostics[len(diagnostics)-1]
+			last.Message += "\n" + strings.TrimSpace(line)
+			continue
+		}
+
+		matches := diagnosticPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		file := filepath.Clean(matches[1])
+		if source, ok := sources[file]; ok {
+			file = source
+		}
+		d := Diagnostic{File: file, Message: matches[4], InSolution: run.solution != "" && file == run.solution}
+		d.Line, _ = strconv.Atoi(matches[2])
+		d.Column, _ = strconv.Atoi(matches[3])
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
@@ -0,0 +1,96 @@
+package testing
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	run := testRun{
+		files: []string{"problems/.dsa-stage-1/solution.go", "problems/.dsa-stage-1/two_sum_test.go"},
+		sources: map[string]string{
+			"problems/.dsa-stage-1/solution.go":     "solutions/two_sum.go",
+			"problems/.dsa-stage-1/two_sum_test.go": "problems/two_sum_test.go",
+		},
+		solution: "solutions/two_sum.go",
+	}
+
+	t.Run("maps staged files back to the workspace", func(t *testing.T) {
+		output := `# command-line-arguments [command-line-arguments.test]
+problems/.dsa-stage-1/solution.go:7:9: undefined: x
+./problems/.dsa-stage-1/two_sum_test.go:12:15: too many arguments in call to TwoSum
+	have ([]int, int, int)
+	want ([]int, int)
+FAIL	command-line-arguments [build failed]`
+
+		diagnostics := parseDiagnostics(output, run)
+		require.Len(t, diagnostics, 2)
+
+		assert.Equal(t, Diagnostic{File: "solutions/two_sum.go", Line: 7, Column: 9, Message: "undefined: x", InSolution: true}, diagnostics[0])
+		assert.Equal(t, "problems/two_sum_test.go", diagnostics[1].File)
+		assert.Equal(t, 12, diagnostics[1].Line)
+		assert.Equal(t, 15, diagnostics[1].Column)
+		assert.False(t, diagnostics[1].InSolution)
+		assert.Equal(t, "too many arguments in call to TwoSum\nhave ([]int, int, int)\nwant ([]int, int)", diagnostics[1].Message)
+	})
+
+	t.Run("column is optional", func(t *testing.T) {
+		output := "problems/.dsa-stage-1/solution.go:3: syntax error\nFAIL\tcommand-line-arguments [build failed]"
+
+		diagnostics := parseDiagnostics(output, run)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, 3, diagnostics[0].Line)
+		assert.Equal(t, 0, diagnostics[0].Column)
+	})
+
+	t.Run("setup failures", func(t *testing.T) {
+		output := `# command-line-arguments
+problems/.dsa-stage-1/two_sum_test.go:6:2: no required module provides package github.com/stretchr/testify/assert; to add it:
+	go get github.com/stretchr/testify/assert
+FAIL	command-line-arguments [setup failed]`
+
+		diagnostics := parseDiagnostics(output, run)
+		require.Len(t, diagnostics, 1)
+		assert.Equal(t, "problems/two_sum_test.go", diagnostics[0].File)
+		assert.Equal(t, 6, diagnostics[0].Line)
+		assert.Equal(t, 2, diagnostics[0].Column)
+		assert.False(t, diagnostics[0].InSolution)
+		assert.Equal(t, "no required module provides package github.com/stretchr/testify/assert; to add it:\ngo get github.com/stretchr/testify/assert", diagnostics[0].Message)
+	})
+
+	t.Run("ignores output of builds that succeeded", func(t *testing.T) {
+		output := `--- FAIL: TestTwoSum (0.00s)
+    two_sum_test.go:20: expected [0 1], got []
+FAIL`
+
+		assert.Empty(t, parseDiagnostics(output, run))
+	})
+}
+
+func TestTestRun_Unstage(t *testing.T) {
+	run := testRun{
+		sources: map[string]string{
+			"problems/.dsa-stage-1/solution.go":     "solutions/two_sum.go",
+			"problems/.dsa-stage-1/two_sum_test.go": "problems/two_sum_test.go",
+		},
+	}
+	abs, err := filepath.Abs("problems/.dsa-stage-1/two_sum_test.go")
+	require.NoError(t, err)
+
+	output := run.unstage("Error Trace:\t" + abs + ":41\n" +
+		"panic: boom\n\tproblems/.dsa-stage-1/solution.go:7 +0x1d\n" +
+		"two_sum_test.go:12: got 1")
+
+	assert.Equal(t, "Error Trace:\tproblems/two_sum_test.go:41\n"+
+		"panic: boom\n\tsolutions/two_sum.go:7 +0x1d\n"+
+		"two_sum_test.go:12: got 1", output)
+	assert.Equal(t, "unchanged", testRun{}.unstage("unchanged"))
+}
+
+func TestCaretIndent(t *testing.T) {
+	assert.Equal(t, "\t\t    ", caretIndent("\t\tx :="))
+	assert.Equal(t, "", caretIndent(""))
+}
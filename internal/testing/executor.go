@@ -3,28 +3,79 @@ package testing
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/ak95asb/dsa-dojo/internal/coverage"
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 )
 
 // Executor handles test execution
-type Executor struct{}
+type Executor struct {
+	cover bool // Measure the solution's coverage
+}
 
 // NewExecutor creates a new test executor
 func NewExecutor() *Executor {
 	return &Executor{}
 }
 
-// Execute runs go test for the specified problem
+// Execute runs the problem's tests against its default solution. Without a
+// solution file the tests run on their own.
 func (e *Executor) Execute(prob *problem.ProblemDetails, verbose, race bool) (*TestResult, error) {
-	// Construct test file path: problems/<slug>_test.go
-	testFile := filepath.Join("problems", problem.SlugToSnakeCase(prob.Slug)+"_test.go")
+	layout := workspace.ProblemLayout(prob.Slug)
+	if _, err := os.Stat(layout.Solution()); err == nil {
+		return e.ExecuteSolution(prob, layout.Solution(), verbose, race)
+	}
+
+	files := layout.TestFiles()
+	if len(files) == 0 {
+		files = []string{layout.Tests()} // Let go test report the missing file
+	}
+	return e.run(testRun{files: files}, verbose, race)
+}
+
+// ExecuteSolution runs the problem's tests against a specific solution file,
+// such as a named variant. The test files (see workspace.Layout) and solution
+// are staged together in a temporary directory under problems/ so they
+// compile as one package within the current module.
+func (e *Executor) ExecuteSolution(prob *problem.ProblemDetails, solutionPath string, verbose, race bool) (*TestResult, error) {
+	layout := workspace.ProblemLayout(prob.Slug)
+	testFiles := layout.TestFiles()
+	if len(testFiles) == 0 {
+		return nil, fmt.Errorf("no tests found for %s: expected %s", prob.Slug, layout.Tests())
+	}
+
+	stageDir, err := solution.StageSolution(testFiles[0], solutionPath, testFiles[1:]...)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stageDir)
+
+	staged := filepath.Join(stageDir, "solution.go")
+	run := testRun{
+		files:    []string{staged},
+		sources:  map[string]string{staged: solutionPath},
+		solution: solutionPath,
+	}
+	for _, testFile := range testFiles {
+		staged := filepath.Join(stageDir, filepath.Base(testFile))
+		run.files = append(run.files, staged)
+		run.sources[staged] = testFile
+	}
+	return e.run(run, verbose, race)
+}
 
+// run executes go test on the run's files and parses the results, including
+// compile errors. With coverage enabled the coverage of the solution, staged
+// as solution.go, is measured too.
+func (e *Executor) run(run testRun, verbose, race bool) (*TestResult, error) {
 	// Build go test command arguments
 	args := []string{"test"}
 
@@ -36,7 +87,19 @@ func (e *Executor) Execute(prob *problem.ProblemDetails, verbose, race bool) (*T
 		args = append(args, "-race")
 	}
 
-	args = append(args, testFile)
+	var profile string
+	if e.cover && run.solution != "" {
+		file, err := os.CreateTemp("", "dsa-cover-*.out")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cover profile: %w", err)
+		}
+		file.Close()
+		profile = file.Name()
+		defer os.Remove(profile)
+		args = append(args, "-coverprofile="+profile)
+	}
+
+	args = append(args, run.files...)
 
 	// Execute go test
 	cmd := exec.Command("go", args...)
@@ -55,8 +118,10 @@ func (e *Executor) Execute(prob *problem.ProblemDetails, verbose, race bool) (*T
 		}
 	}
 
-	// Combine stdout and stderr
-	output := stdout.String() + stderr.String()
+	// Combine stdout and stderr. Staged paths would point at files removed
+	// after the run.
+	rawOutput := stdout.String() + stderr.String()
+	output := run.unstage(rawOutput)
 
 	// Parse test results
 	result := &TestResult{
@@ -67,6 +132,21 @@ func (e *Executor) Execute(prob *problem.ProblemDetails, verbose, race bool) (*T
 	}
 
 	e.parseTestResults(result, output)
+	if result.Diagnostics = parseDiagnostics(rawOutput, run); len(result.Diagnostics) > 0 {
+		result.TotalCount, result.PassedCount = 1, 0
+		result.FailedTests = []FailedTest{{Name: "Compilation", Message: result.Diagnostics[0].Message}}
+	}
+
+	// Without a profile, e.g. when the tests did not compile, coverage is
+	// left out
+	if profile != "" {
+		if info, err := os.Stat(profile); err == nil && info.Size() > 0 {
+			result.Coverage, err = coverage.Analyze(profile, run.solution, "solution.go")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	return result, nil
 }
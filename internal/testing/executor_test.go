@@ -1,10 +1,16 @@
 package testing
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ak95asb/dsa-dojo/internal/database"
+	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/testgen"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseTestResults(t *testing.T) {
@@ -176,3 +182,57 @@ func TestServiceRecordSolution(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestExecuteSolution_FreshWorkspace(t *testing.T) {
+	// A workspace straight from dsa init runs generated tests, which import
+	// testify, without any manual go.mod changes
+	dir := t.TempDir()
+	_, err := workspace.Init(dir, "")
+	require.NoError(t, err)
+	originalDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(originalDir)
+
+	solution := filepath.Join("solutions", "add_two.go")
+	require.NoError(t, os.WriteFile(solution, []byte("package solutions\n\nfunc AddTwo(n int) int {\n\treturn n + 2\n}\n"), 0644))
+
+	prob := &problem.ProblemDetails{Problem: database.Problem{Slug: "add-two", Title: "Add Two"}}
+	testCases := []*testgen.TestCase{
+		{Name: "one", Inputs: []interface{}{1}, Expected: 3},
+		{Name: "zero", Inputs: []interface{}{0}, Expected: 2},
+	}
+	require.NoError(t, testgen.NewGenerator().Generate(prob, testCases, false))
+
+	result, err := NewExecutor().ExecuteSolution(prob, solution, true, false)
+	require.NoError(t, err)
+	assert.True(t, result.AllPassed, "output: %+v", result.FailedTests)
+	assert.Positive(t, result.TotalCount)
+	assert.Equal(t, result.TotalCount, result.PassedCount)
+}
+
+func TestExecuteSolution_FailuresPointAtWorkspaceFiles(t *testing.T) {
+	// Assertion traces name the staged test file, which is removed after the
+	// run, so they are mapped back to the workspace
+	dir := t.TempDir()
+	_, err := workspace.Init(dir, "")
+	require.NoError(t, err)
+	originalDir, _ := os.Getwd()
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(originalDir)
+
+	solution := filepath.Join("solutions", "add_two.go")
+	require.NoError(t, os.WriteFile(solution, []byte("package solutions\n\nfunc AddTwo(n int) int {\n\treturn n + 1\n}\n"), 0644))
+
+	prob := &problem.ProblemDetails{Problem: database.Problem{Slug: "add-two", Title: "Add Two"}}
+	testCases := []*testgen.TestCase{{Name: "one", Inputs: []interface{}{1}, Expected: 3}}
+	require.NoError(t, testgen.NewGenerator().Generate(prob, testCases, false))
+
+	result, err := NewExecutor().ExecuteSolution(prob, solution, false, false)
+	require.NoError(t, err)
+	assert.False(t, result.AllPassed)
+	assert.NotContains(t, result.Output, ".dsa-stage")
+	assert.Contains(t, result.Output, filepath.Join("problems", "add_two_test.go")+":")
+	for _, failed := range result.FailedTests {
+		assert.NotContains(t, failed.Message, ".dsa-stage")
+	}
+}
@@ -3,7 +3,11 @@ package testing
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/ak95asb/dsa-dojo/internal/coverage"
 	"github.com/fatih/color"
 )
 
@@ -51,25 +55,42 @@ func (f *Formatter) Display(result *TestResult) {
 		fmt.Println("================")
 		fmt.Println(result.Output)
 	}
+
+	if result.Coverage != nil {
+		fmt.Print(coverage.NewFormatter().FormatReport(result.Coverage))
+	}
 }
 
 // displaySuccess shows results when all tests pass
 func (f *Formatter) displaySuccess(result *TestResult) {
 	if f.useColor {
 		green := color.New(color.FgGreen, color.Bold)
-		green.Printf("✓ All tests passed! (%d/%d)\n", result.PassedCount, result.TotalCount)
+		green.Printf("✓ All tests passed! (%d/%d)%s\n", result.PassedCount, result.TotalCount, cachedSuffix(result))
 	} else {
-		fmt.Printf("✓ All tests passed! (%d/%d)\n", result.PassedCount, result.TotalCount)
+		fmt.Printf("✓ All tests passed! (%d/%d)%s\n", result.PassedCount, result.TotalCount, cachedSuffix(result))
 	}
 }
 
+// cachedSuffix marks results returned from the cache
+func cachedSuffix(result *TestResult) string {
+	if result.Cached {
+		return " (cached)"
+	}
+	return ""
+}
+
 // displayFailure shows results when tests fail
 func (f *Formatter) displayFailure(result *TestResult) {
+	if len(result.Diagnostics) > 0 {
+		f.displayCompileErrors(result)
+		return
+	}
+
 	if f.useColor {
 		red := color.New(color.FgRed, color.Bold)
-		red.Printf("✗ Tests failed (%d/%d passed)\n", result.PassedCount, result.TotalCount)
+		red.Printf("✗ Tests failed (%d/%d passed)%s\n", result.PassedCount, result.TotalCount, cachedSuffix(result))
 	} else {
-		fmt.Printf("✗ Tests failed (%d/%d passed)\n", result.PassedCount, result.TotalCount)
+		fmt.Printf("✗ Tests failed (%d/%d passed)%s\n", result.PassedCount, result.TotalCount, cachedSuffix(result))
 	}
 
 	// Display failed test details
@@ -104,3 +125,164 @@ func (f *Formatter) displayFailure(result *TestResult) {
 		}
 	}
 }
+
+// displayCompileErrors shows compile errors grouped by file, those in the
+// solution first, each with its source line and a caret under the column
+func (f *Formatter) displayCompileErrors(result *TestResult) {
+	headline := fmt.Sprintf("✗ Tests failed to compile (%d error(s))%s", len(result.Diagnostics), cachedSuffix(result))
+	if f.useColor {
+		color.New(color.FgRed, color.Bold).Println(headline)
+	} else {
+		fmt.Println(headline)
+	}
+
+	var files []string
+	byFile := map[string][]Diagnostic{}
+	for _, d := range result.Diagnostics {
+		if _, ok := byFile[d.File]; !ok {
+			files = append(files, d.File)
+		}
+		byFile[d.File] = append(byFile[d.File], d)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return byFile[files[i]][0].InSolution && !byFile[files[j]][0].InSolution
+	})
+
+	for _, file := range files {
+		diagnostics := byFile[file]
+		switch {
+		case diagnostics[0].InSolution:
+			fmt.Printf("\nIn your solution (%s):\n", file)
+		case strings.HasSuffix(file, "_test.go"):
+			fmt.Printf("\nIn the test file %s:\n", file)
+		default:
+			fmt.Printf("\nIn %s:\n", file)
+		}
+
+		data, _ := os.ReadFile(file)
+		lines := strings.Split(string(data), "\n")
+		for _, d := range diagnostics {
+			location := fmt.Sprint(d.Line)
+			if d.Column > 0 {
+				location += fmt.Sprintf(":%d", d.Column)
+			}
+			message := strings.ReplaceAll(d.Message, "\n", "\n      ")
+			fmt.Printf("\n  %s: %s\n", location, message)
+
+			if d.Line < 1 || d.Line > len(lines) || len(data) == 0 {
+				continue
+			}
+			source := lines[d.Line-1]
+			fmt.Printf("  %5d | %s\n", d.Line, source)
+			if d.Column > 0 && d.Column <= len(source)+1 {
+				caret := "^"
+				if f.useColor {
+					caret = color.New(color.FgRed, color.Bold).Sprint(caret)
+				}
+				fmt.Printf("  %5s | %s%s\n", "", caretIndent(source[:d.Column-1]), caret)
+			}
+		}
+	}
+}
+
+// caretIndent returns whitespace as wide as prefix, keeping its tabs so the
+// caret lines up with the source line above it
+func caretIndent(prefix string) string {
+	var indent strings.Builder
+	for _, r := range prefix {
+		if r == '\t' {
+			indent.WriteRune('\t')
+		} else {
+			indent.WriteRune(' ')
+		}
+	}
+	return indent.String()
+}
+
+// batchRow is the layout of a row of the live table of a multi-problem run
+const batchRow = "%-9s %-6s %-32s %-10s %7s %7s  %s\n"
+
+// DisplayBatchHeader prints the header of the live table of a multi-problem run
+func (f *Formatter) DisplayBatchHeader(total, workers int) {
+	fmt.Printf("Testing %d problem(s) with %d worker(s)\n\n", total, workers)
+	fmt.Printf(batchRow, "", "STATUS", "PROBLEM", "DIFFICULTY", "TESTS", "TIME", "")
+}
+
+// DisplayBatchRow prints a problem's row as soon as its tests finish; n is
+// the number of problems finished so far
+func (f *Formatter) DisplayBatchRow(n, total int, r *BatchResult) {
+	status, note, tests, elapsed := "PASS", "", "-", "-"
+	switch {
+	case r.Skipped:
+		status, note = "SKIP", "no tests"
+	case r.Err != nil:
+		status, note = "ERROR", r.Err.Error()
+	case !r.Result.AllPassed:
+		status = "FAIL"
+		if len(r.Result.Diagnostics) > 0 {
+			note = "does not compile"
+		}
+	}
+	if r.Result != nil && r.Result.TotalCount > 0 {
+		tests = fmt.Sprintf("%d/%d", r.Result.PassedCount, r.Result.TotalCount)
+	}
+	if r.Result != nil && r.Result.Cached {
+		elapsed = "cached"
+	} else if !r.Skipped {
+		elapsed = fmt.Sprintf("%.1fs", r.Duration.Seconds())
+	}
+	if r.Regressed() {
+		note = strings.TrimSpace("regressed " + note)
+	} else if r.Passed() && !r.Problem.IsSolved {
+		note = "newly solved"
+	}
+	if r.Result != nil && r.Result.Coverage != nil {
+		note = strings.TrimSpace(fmt.Sprintf("%.1f%% covered %s", r.Result.Coverage.Percent(), note))
+	}
+
+	// Pad before coloring so escape codes do not break the alignment
+	status = fmt.Sprintf("%-6s", status)
+	if f.useColor {
+		switch {
+		case r.Skipped:
+			status = color.New(color.FgYellow).Sprint(status)
+		case r.Passed():
+			status = color.New(color.FgGreen, color.Bold).Sprint(status)
+		default:
+			status = color.New(color.FgRed, color.Bold).Sprint(status)
+		}
+	}
+	fmt.Printf(batchRow, fmt.Sprintf("[%d/%d]", n, total), status, r.Problem.Slug, r.Problem.Difficulty, tests, elapsed, note)
+}
+
+// DisplayBatchSummary prints the totals of a multi-problem run and lists the
+// problems that regressed
+func (f *Formatter) DisplayBatchSummary(results []*BatchResult, elapsed time.Duration) {
+	var passed, failed, skipped int
+	var regressed []string
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			skipped++
+		case r.Passed():
+			passed++
+		default:
+			failed++
+		}
+		if r.Regressed() {
+			regressed = append(regressed, r.Problem.Slug)
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d failed, %d skipped in %.1fs\n", passed, failed, skipped, elapsed.Seconds())
+	if len(regressed) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("✗ %d previously solved problem(s) regressed: %s", len(regressed), strings.Join(regressed, ", "))
+	if f.useColor {
+		color.New(color.FgRed, color.Bold).Println(message)
+	} else {
+		fmt.Println(message)
+	}
+}
@@ -1,8 +1,14 @@
 package testing
 
 import (
+	"os"
+	"time"
+
+	"github.com/ak95asb/dsa-dojo/internal/coverage"
 	"github.com/ak95asb/dsa-dojo/internal/database"
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/solution"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"gorm.io/gorm"
 )
 
@@ -15,6 +21,9 @@ type TestResult struct {
 	Output       string
 	Verbose      bool
 	RaceDetector bool
+	Cached       bool             // Returned from the cache without running go test
+	Coverage     *coverage.Report // Coverage of the solution, when measured
+	Diagnostics  []Diagnostic     // Compile errors, when the tests did not build
 }
 
 // FailedTest represents a single failed test case
@@ -30,6 +39,8 @@ type Service struct {
 	db        *gorm.DB
 	executor  *Executor
 	formatter *Formatter
+	cache     *Cache
+	useCache  bool
 }
 
 // NewService creates a new testing service
@@ -38,12 +49,82 @@ func NewService(db *gorm.DB) *Service {
 		db:        db,
 		executor:  NewExecutor(),
 		formatter: NewFormatter(),
+		cache:     NewCache(db),
+		useCache:  true,
 	}
 }
 
+// UseCache sets whether results of unchanged code are returned from the
+// cache. Fresh results are cached either way.
+func (s *Service) UseCache(enabled bool) {
+	s.useCache = enabled
+}
+
+// SetCover sets whether tests measure the coverage of the solution file
+func (s *Service) SetCover(enabled bool) {
+	s.executor.cover = enabled
+}
+
 // ExecuteTests runs go test for the specified problem
 func (s *Service) ExecuteTests(prob *problem.ProblemDetails, verbose, race bool) (*TestResult, error) {
-	return s.executor.Execute(prob, verbose, race)
+	return s.ExecuteVariantTests(prob, "", verbose, race)
+}
+
+// ExecuteVariantTests runs the problem's tests against a named variant of the
+// solution. An empty variant runs the default tests.
+func (s *Service) ExecuteVariantTests(prob *problem.ProblemDetails, variant string, verbose, race bool) (*TestResult, error) {
+	return s.cached(prob, variant, verbose, race, func() (*TestResult, error) {
+		if variant == "" {
+			return s.executor.Execute(prob, verbose, race)
+		}
+		return s.executor.ExecuteSolution(prob, solution.VariantPath(prob.Slug, variant), verbose, race)
+	})
+}
+
+// cached returns the cached result of the tests if their files are unchanged,
+// else runs them and caches the result. The files include the structures
+// helpers and go.mod/go.sum, which every problem builds with, so editing
+// shared code runs the tests of every problem again.
+func (s *Service) cached(prob *problem.ProblemDetails, variant string, verbose, race bool, run func() (*TestResult, error)) (*TestResult, error) {
+	layout := workspace.ProblemLayout(prob.Slug)
+	files := layout.TestFiles()
+	if len(files) == 0 {
+		return run() // Let go test report the missing tests
+	}
+	solutionPath := layout.Variant(variant)
+	if _, err := os.Stat(solutionPath); err == nil {
+		files = append([]string{solutionPath}, files...)
+	}
+	files = append(files, sharedFiles()...)
+
+	var flags []string
+	if verbose {
+		flags = append(flags, "-v")
+	}
+	if race {
+		flags = append(flags, "-race")
+	}
+	if s.executor.cover {
+		flags = append(flags, "-cover")
+	}
+	key, err := s.cache.Key(prob.Slug, files, flags...)
+	if err != nil {
+		return run()
+	}
+	if s.useCache {
+		if result, ok := s.cache.Get(key); ok {
+			return result, nil
+		}
+	}
+
+	result, err := run()
+	if err != nil {
+		return nil, err
+	}
+	// Caching only saves time later, so a result that cannot be cached is
+	// returned all the same
+	_ = s.cache.Put(key, prob.ID, variant, result)
+	return result, nil
 }
 
 // DisplayResults formats and displays test results to stdout
@@ -51,8 +132,28 @@ func (s *Service) DisplayResults(result *TestResult) {
 	s.formatter.Display(result)
 }
 
+// DisplayBatchHeader prints the header of the live table of a multi-problem run
+func (s *Service) DisplayBatchHeader(total, workers int) {
+	s.formatter.DisplayBatchHeader(total, workers)
+}
+
+// DisplayBatchRow prints a problem's row of a multi-problem run
+func (s *Service) DisplayBatchRow(n, total int, result *BatchResult) {
+	s.formatter.DisplayBatchRow(n, total, result)
+}
+
+// DisplayBatchSummary prints the totals of a multi-problem run
+func (s *Service) DisplayBatchSummary(results []*BatchResult, elapsed time.Duration) {
+	s.formatter.DisplayBatchSummary(results, elapsed)
+}
+
 // RecordSolution creates or updates a solution record in the database
 func (s *Service) RecordSolution(problemID uint, result *TestResult) error {
+	return s.RecordVariantSolution(problemID, "", result)
+}
+
+// RecordVariantSolution creates a solution record for a named variant
+func (s *Service) RecordVariantSolution(problemID uint, variant string, result *TestResult) error {
 	// Check if solution already exists
 	var existing database.Solution
 	err := s.db.Where("problem_id = ?", problemID).Order("created_at DESC").First(&existing).Error
@@ -67,6 +168,7 @@ func (s *Service) RecordSolution(problemID uint, result *TestResult) error {
 		Code:      "", // We don't store the actual code in test command
 		Language:  "go",
 		Passed:    result.AllPassed,
+		Variant:   variant,
 	}
 
 	return s.db.Create(solution).Error
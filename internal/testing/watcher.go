@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ak95asb/dsa-dojo/internal/problem"
+	"github.com/ak95asb/dsa-dojo/internal/workspace"
 	"github.com/fsnotify/fsnotify"
 )
 
@@ -40,9 +41,15 @@ func (s *TestState) DetectTransition(current *TestResult) string {
 
 // Watch monitors the solution file for changes and re-runs tests automatically
 func (s *Service) Watch(prob *problem.ProblemDetails, problemSvc *problem.Service, verbose, race bool) error {
+	return s.WatchVariant(prob, problemSvc, "", verbose, race)
+}
+
+// WatchVariant monitors the solution file of a named variant and re-runs its
+// tests automatically. An empty variant watches the default solution.
+func (s *Service) WatchVariant(prob *problem.ProblemDetails, problemSvc *problem.Service, variant string, verbose, race bool) error {
 	// Construct solution file path
-	solutionPath := filepath.Join("solutions", prob.Slug+".go")
-	solutionDir := "solutions"
+	solutionPath := workspace.ProblemLayout(prob.Slug).Variant(variant)
+	solutionDir := filepath.Dir(solutionPath)
 
 	// Verify solution file exists
 	if _, err := os.Stat(solutionPath); os.IsNotExist(err) {
@@ -59,7 +66,7 @@ func (s *Service) Watch(prob *problem.ProblemDetails, problemSvc *problem.Servic
 	}
 	defer watcher.Close()
 
-	// Watch the parent directory (solutions/) not individual file
+	// Watch the parent directory not individual file
 	// This handles atomic saves where editors write to temp file then rename
 	if err := watcher.Add(solutionDir); err != nil {
 		return fmt.Errorf("failed to watch directory %s: %w", solutionDir, err)
@@ -76,7 +83,7 @@ func (s *Service) Watch(prob *problem.ProblemDetails, problemSvc *problem.Servic
 	fmt.Printf("👀 Watching %s for changes... (Press Ctrl+C to stop)\n\n", absPath)
 
 	// Run tests immediately before starting watch
-	s.runTestsInWatchMode(prob, problemSvc, verbose, race, testState, true)
+	s.runTestsInWatchMode(prob, problemSvc, variant, verbose, race, testState, true)
 
 	// Debouncing variables
 	var debounceTimer *time.Timer
@@ -107,7 +114,7 @@ func (s *Service) Watch(prob *problem.ProblemDetails, problemSvc *problem.Servic
 				}
 
 				debounceTimer = time.AfterFunc(debounceDuration, func() {
-					s.runTestsInWatchMode(prob, problemSvc, verbose, race, testState, false)
+					s.runTestsInWatchMode(prob, problemSvc, variant, verbose, race, testState, false)
 				})
 			}
 
@@ -121,7 +128,7 @@ func (s *Service) Watch(prob *problem.ProblemDetails, problemSvc *problem.Servic
 }
 
 // runTestsInWatchMode executes tests and displays results with transition detection
-func (s *Service) runTestsInWatchMode(prob *problem.ProblemDetails, problemSvc *problem.Service, verbose, race bool, testState *TestState, isInitial bool) {
+func (s *Service) runTestsInWatchMode(prob *problem.ProblemDetails, problemSvc *problem.Service, variant string, verbose, race bool, testState *TestState, isInitial bool) {
 	// Clear terminal and show re-running message (skip on initial run)
 	if !isInitial {
 		clearTerminal()
@@ -133,7 +140,7 @@ func (s *Service) runTestsInWatchMode(prob *problem.ProblemDetails, problemSvc *
 	fmt.Printf("⏰ %s\n\n", time.Now().Format("15:04:05"))
 
 	// Execute tests
-	result, err := s.ExecuteTests(prob, verbose, race)
+	result, err := s.ExecuteVariantTests(prob, variant, verbose, race)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running tests: %v\n", err)
 		return
@@ -156,7 +163,7 @@ func (s *Service) runTestsInWatchMode(prob *problem.ProblemDetails, problemSvc *
 		}
 
 		// Create/update solution record
-		if err := s.RecordSolution(prob.ID, result); err != nil {
+		if err := s.RecordVariantSolution(prob.ID, variant, result); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: Failed to record solution: %v\n", err)
 		}
 
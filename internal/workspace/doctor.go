@@ -0,0 +1,190 @@
+package workspace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ak95asb/dsa-dojo/structures"
+)
+
+// ErrConflict is returned when a file cannot be moved because its place in
+// the layout holds a different file
+var ErrConflict = errors.New("destination exists with different content")
+
+// stagePattern matches directories left behind by interrupted test runs
+const stagePattern = ".dsa-stage-*"
+
+// Issue is something wrong with the workspace found by Diagnose
+type Issue struct {
+	Description string
+	Fix         func() error // Repairs the issue; nil if it must be fixed by hand
+	Hint        string       // How to fix it by hand
+}
+
+// Move relocates a problem file from where an earlier version of dsa put it
+// to its place in the layout
+type Move struct {
+	From     string
+	To       string
+	Conflict bool // To exists with different content
+}
+
+// Apply moves the file. If the destination already holds the same content
+// the legacy copy is removed; if it differs, ErrConflict is returned.
+func (m Move) Apply() error {
+	if existing, err := os.ReadFile(m.To); err == nil {
+		legacy, err := os.ReadFile(m.From)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", m.From, err)
+		}
+		if !bytes.Equal(existing, legacy) {
+			return fmt.Errorf("cannot move %s to %s: %w", m.From, m.To, ErrConflict)
+		}
+		if err := os.Remove(m.From); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", m.From, err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.To), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(m.To), err)
+	}
+	if err := os.Rename(m.From, m.To); err != nil {
+		return fmt.Errorf("failed to move %s to %s: %w", m.From, m.To, err)
+	}
+	return nil
+}
+
+// LegacyFiles finds problem files named after the hyphenated slug, such as
+// solutions/two-sum.go, and returns where each belongs in the layout
+func LegacyFiles() ([]Move, error) {
+	var moves []Move
+
+	scans := []struct {
+		pattern string
+		target  func(l Layout, dir, suffix string) string
+	}{
+		{Solutions("*.go"), func(l Layout, dir, suffix string) string {
+			return pick(suffix, l.Solution(), "", "")
+		}},
+		{filepath.Join(VariantsDir(), "*", "*.go"), func(l Layout, dir, suffix string) string {
+			return pick(suffix, l.Variant(filepath.Base(dir)), "", "")
+		}},
+		{Problems("*.go"), func(l Layout, dir, suffix string) string {
+			return pick(suffix, l.Boilerplate(), l.Tests(), l.Benchmarks())
+		}},
+		{Problems("templates", "*.go"), func(l Layout, dir, suffix string) string {
+			return pick(suffix, l.ReferenceBoilerplate(), l.ReferenceTests(), "")
+		}},
+	}
+
+	for _, scan := range scans {
+		paths, err := filepath.Glob(scan.pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", scan.pattern, err)
+		}
+		for _, path := range paths {
+			slug, suffix := splitFileName(filepath.Base(path))
+			if !strings.Contains(slug, "-") || strings.Contains(slug, "_") {
+				continue // Already in the layout, or not a problem file
+			}
+			to := scan.target(ProblemLayout(slug), filepath.Dir(path), suffix)
+			if to == "" {
+				continue
+			}
+			move := Move{From: path, To: to}
+			if existing, err := os.ReadFile(to); err == nil {
+				legacy, _ := os.ReadFile(path)
+				move.Conflict = !bytes.Equal(existing, legacy)
+			}
+			moves = append(moves, move)
+		}
+	}
+
+	return moves, nil
+}
+
+// splitFileName splits a Go file name into its stem and its test suffix:
+// "two-sum_bench_test.go" -> "two-sum", "_bench_test"
+func splitFileName(name string) (string, string) {
+	stem := strings.TrimSuffix(name, ".go")
+	for _, suffix := range []string{"_bench_test", "_test"} {
+		if trimmed, found := strings.CutSuffix(stem, suffix); found {
+			return trimmed, suffix
+		}
+	}
+	return stem, ""
+}
+
+// pick returns the layout path for a file suffix, "" if it has none
+func pick(suffix, source, tests, benchmarks string) string {
+	switch suffix {
+	case "_test":
+		return tests
+	case "_bench_test":
+		return benchmarks
+	default:
+		return source
+	}
+}
+
+// Diagnose checks the workspace for missing layout files (including the
+// structures helper package), problem files in
+// legacy locations and leftover staging directories
+func Diagnose() ([]Issue, error) {
+	var issues []Issue
+
+	if _, err := Find("."); err == nil {
+		paths := []string{Path("go.mod"), Solutions(), Problems()}
+		for _, name := range structures.SourceFiles {
+			paths = append(paths, Structures(name))
+		}
+		for _, path := range paths {
+			if !exists(path) {
+				root := Root()
+				issues = append(issues, Issue{
+					Description: fmt.Sprintf("Missing %s", path),
+					Fix: func() error {
+						_, err := Init(root, DefaultModule)
+						return err
+					},
+				})
+			}
+		}
+	}
+
+	moves, err := LegacyFiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, move := range moves {
+		move := move
+		issue := Issue{Description: fmt.Sprintf("%s should be %s", move.From, move.To)}
+		if move.Conflict {
+			issue.Hint = fmt.Sprintf("both files exist; merge %s into %s and delete it", move.From, move.To)
+		} else {
+			issue.Fix = move.Apply
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, pattern := range []string{Problems(stagePattern), Problems("templates", stagePattern)} {
+		dirs, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", pattern, err)
+		}
+		for _, dir := range dirs {
+			dir := dir
+			issues = append(issues, Issue{
+				Description: fmt.Sprintf("Leftover staging directory %s", dir),
+				Fix:         func() error { return os.RemoveAll(dir) },
+			})
+		}
+	}
+
+	return issues, nil
+}
@@ -0,0 +1,115 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile creates a file and its directory
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLegacyFiles(t *testing.T) {
+	chdir(t, newWorkspace(t))
+	writeFile(t, filepath.Join("solutions", "two-sum.go"), "package solutions\n")
+	writeFile(t, filepath.Join("solutions", "variants", "hashmap", "two-sum.go"), "package solutions\n")
+	writeFile(t, filepath.Join("problems", "two-sum_test.go"), "package problems\n")
+	writeFile(t, filepath.Join("problems", "templates", "two-sum_bench_test.go"), "package problems\n")
+	writeFile(t, filepath.Join("problems", "binary_search_test.go"), "package problems\n") // In place
+	writeFile(t, filepath.Join("solutions", "helpers.go"), "package solutions\n")          // Not hyphenated
+
+	moves, err := LegacyFiles()
+	require.NoError(t, err)
+
+	layout := ProblemLayout("two-sum")
+	assert.ElementsMatch(t, []Move{
+		{From: filepath.Join("solutions", "two-sum.go"), To: layout.Solution()},
+		{From: filepath.Join("solutions", "variants", "hashmap", "two-sum.go"), To: layout.Variant("hashmap")},
+		{From: filepath.Join("problems", "two-sum_test.go"), To: layout.Tests()},
+	}, moves)
+}
+
+func TestMove_Apply(t *testing.T) {
+	t.Run("moves the file", func(t *testing.T) {
+		chdir(t, t.TempDir())
+		writeFile(t, filepath.Join("solutions", "variants", "fast", "two-sum.go"), "fast")
+		move := Move{From: filepath.Join("solutions", "variants", "fast", "two-sum.go"), To: ProblemLayout("two-sum").Variant("fast")}
+
+		require.NoError(t, move.Apply())
+		assert.NoFileExists(t, move.From)
+		content, err := os.ReadFile(move.To)
+		require.NoError(t, err)
+		assert.Equal(t, "fast", string(content))
+	})
+
+	t.Run("removes an identical copy", func(t *testing.T) {
+		chdir(t, t.TempDir())
+		writeFile(t, filepath.Join("solutions", "two-sum.go"), "same")
+		writeFile(t, filepath.Join("solutions", "two_sum.go"), "same")
+
+		require.NoError(t, Move{From: filepath.Join("solutions", "two-sum.go"), To: filepath.Join("solutions", "two_sum.go")}.Apply())
+		assert.NoFileExists(t, filepath.Join("solutions", "two-sum.go"))
+	})
+
+	t.Run("never overwrites a different file", func(t *testing.T) {
+		chdir(t, t.TempDir())
+		writeFile(t, filepath.Join("solutions", "two-sum.go"), "old")
+		writeFile(t, filepath.Join("solutions", "two_sum.go"), "new")
+
+		err := Move{From: filepath.Join("solutions", "two-sum.go"), To: filepath.Join("solutions", "two_sum.go")}.Apply()
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.FileExists(t, filepath.Join("solutions", "two-sum.go"))
+	})
+}
+
+func TestDiagnose(t *testing.T) {
+	t.Run("healthy workspace", func(t *testing.T) {
+		chdir(t, newWorkspace(t))
+
+		issues, err := Diagnose()
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("fixes every fixable issue", func(t *testing.T) {
+		root := newWorkspace(t)
+		chdir(t, root)
+		require.NoError(t, os.Remove("go.mod"))
+		writeFile(t, filepath.Join("solutions", "two-sum.go"), "package solutions\n")
+		require.NoError(t, os.MkdirAll(filepath.Join("problems", ".dsa-stage-123"), 0755))
+
+		issues, err := Diagnose()
+		require.NoError(t, err)
+		require.Len(t, issues, 3)
+		for _, issue := range issues {
+			require.NotNil(t, issue.Fix, issue.Description)
+			require.NoError(t, issue.Fix())
+		}
+
+		assert.FileExists(t, filepath.Join(root, "go.mod"))
+		assert.FileExists(t, filepath.Join(root, "solutions", "two_sum.go"))
+		assert.NoDirExists(t, filepath.Join(root, "problems", ".dsa-stage-123"))
+
+		issues, err = Diagnose()
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("conflicts must be merged by hand", func(t *testing.T) {
+		chdir(t, newWorkspace(t))
+		writeFile(t, filepath.Join("solutions", "two-sum.go"), "old")
+		writeFile(t, filepath.Join("solutions", "two_sum.go"), "new")
+
+		issues, err := Diagnose()
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Nil(t, issues[0].Fix)
+		assert.Contains(t, issues[0].Hint, "merge")
+	})
+}
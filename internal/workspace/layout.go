@@ -0,0 +1,138 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Layout is where the files of one problem live in the workspace. Solving,
+// testing, benchmarking and test generation all locate problem files through
+// it, so generated tests are the ones executed:
+//
+//	solutions/<name>.go                      Solution
+//	solutions/variants/<variant>/<name>.go   Named solution variants
+//	problems/<name>.go                       Boilerplate
+//	problems/<name>_test.go                  Tests
+//	problems/<name>_bench_test.go            Benchmarks, when kept apart from tests
+//	problems/<name>_check_test.go            Custom result checkers for generated tests
+//	problems/testdata/<name>/                Fixtures read by tests
+//	problems/testdata/fuzz/Fuzz<Function>/   Fuzz corpus
+//	problems/templates/<name>.go             Reference boilerplate shipped with dsa
+//	problems/templates/<name>_test.go        Reference tests shipped with dsa
+//
+// where <name> is the slug in snake_case, e.g. two_sum for two-sum.
+type Layout struct {
+	Slug string // e.g. two-sum
+	Name string // File name stem, e.g. two_sum
+}
+
+// ProblemLayout returns the layout of a problem
+func ProblemLayout(slug string) Layout {
+	return Layout{Slug: slug, Name: strings.ReplaceAll(slug, "-", "_")}
+}
+
+// VariantsDir returns the directory holding one subdirectory per variant
+func VariantsDir() string {
+	return Solutions("variants")
+}
+
+// Solution returns the default solution file
+func (l Layout) Solution() string {
+	return Solutions(l.Name + ".go")
+}
+
+// Variant returns the solution file of a named variant, or the default
+// solution for the empty variant
+func (l Layout) Variant(variant string) string {
+	if variant == "" {
+		return l.Solution()
+	}
+	return filepath.Join(VariantsDir(), variant, l.Name+".go")
+}
+
+// Boilerplate returns the problem's boilerplate file
+func (l Layout) Boilerplate() string {
+	return Problems(l.Name + ".go")
+}
+
+// Tests returns the problem's test file
+func (l Layout) Tests() string {
+	return Problems(l.Name + "_test.go")
+}
+
+// Benchmarks returns the file for benchmarks kept apart from the tests
+func (l Layout) Benchmarks() string {
+	return Problems(l.Name + "_bench_test.go")
+}
+
+// Checks returns the file of custom checker functions that generated tests
+// call to compare results (see 'dsa test-gen --compare')
+func (l Layout) Checks() string {
+	return Problems(l.Name + "_check_test.go")
+}
+
+// Fixtures returns the directory of fixture files read by the tests
+func (l Layout) Fixtures() string {
+	return Problems("testdata", l.Name)
+}
+
+// FuzzCorpus returns the seed corpus directory of the problem's fuzz test,
+// Fuzz<Function>, where go test looks for it
+func (l Layout) FuzzCorpus() string {
+	return Problems("testdata", "fuzz", "Fuzz"+FunctionName(l.Slug))
+}
+
+// ReferenceBoilerplate returns the boilerplate shipped with dsa
+func (l Layout) ReferenceBoilerplate() string {
+	return Problems("templates", l.Name+".go")
+}
+
+// ReferenceTests returns the tests shipped with dsa
+func (l Layout) ReferenceTests() string {
+	return Problems("templates", l.Name+"_test.go")
+}
+
+// TestFiles returns the test files to run: the problem's tests, falling back
+// to the reference tests when it has none, followed by its checkers and
+// benchmarks files if there are any. Missing files are left out.
+func (l Layout) TestFiles() []string {
+	var files []string
+	if tests := firstExisting(l.Tests(), l.ReferenceTests()); tests != "" {
+		files = append(files, tests)
+	}
+	for _, path := range []string{l.Checks(), l.Benchmarks()} {
+		if exists(path) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// firstExisting returns the first path that exists, or ""
+func firstExisting(paths ...string) string {
+	for _, path := range paths {
+		if exists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// exists reports whether a file exists
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// FunctionName converts a problem slug to the name of its solution function,
+// "two-sum" -> "TwoSum"
+func FunctionName(slug string) string {
+	parts := strings.Split(slug, "-")
+	for i, part := range parts {
+		if len(part) > 0 {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
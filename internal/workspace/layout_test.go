@@ -0,0 +1,72 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblemLayout(t *testing.T) {
+	chdir(t, t.TempDir())
+	layout := ProblemLayout("two-sum")
+
+	assert.Equal(t, "two_sum", layout.Name)
+	assert.Equal(t, filepath.Join("solutions", "two_sum.go"), layout.Solution())
+	assert.Equal(t, layout.Solution(), layout.Variant(""))
+	assert.Equal(t, filepath.Join("solutions", "variants", "hashmap", "two_sum.go"), layout.Variant("hashmap"))
+	assert.Equal(t, filepath.Join("problems", "two_sum.go"), layout.Boilerplate())
+	assert.Equal(t, filepath.Join("problems", "two_sum_test.go"), layout.Tests())
+	assert.Equal(t, filepath.Join("problems", "two_sum_bench_test.go"), layout.Benchmarks())
+	assert.Equal(t, filepath.Join("problems", "two_sum_check_test.go"), layout.Checks())
+	assert.Equal(t, filepath.Join("problems", "testdata", "two_sum"), layout.Fixtures())
+	assert.Equal(t, filepath.Join("problems", "testdata", "fuzz", "FuzzTwoSum"), layout.FuzzCorpus())
+	assert.Equal(t, filepath.Join("problems", "templates", "two_sum.go"), layout.ReferenceBoilerplate())
+	assert.Equal(t, filepath.Join("problems", "templates", "two_sum_test.go"), layout.ReferenceTests())
+}
+
+func TestLayout_FromSubdirectory(t *testing.T) {
+	root := newWorkspace(t)
+	chdir(t, filepath.Join(root, "solutions"))
+
+	assert.Equal(t, filepath.Join("..", "problems", "two_sum_test.go"), ProblemLayout("two-sum").Tests())
+}
+
+func TestLayout_TestFiles(t *testing.T) {
+	chdir(t, t.TempDir())
+	layout := ProblemLayout("two-sum")
+	require.NoError(t, os.MkdirAll(filepath.Join("problems", "templates"), 0755))
+
+	assert.Empty(t, layout.TestFiles())
+
+	// Reference tests are used until the problem has its own
+	require.NoError(t, os.WriteFile(layout.ReferenceTests(), []byte("package problems\n"), 0644))
+	assert.Equal(t, []string{layout.ReferenceTests()}, layout.TestFiles())
+
+	require.NoError(t, os.WriteFile(layout.Tests(), []byte("package problems\n"), 0644))
+	assert.Equal(t, []string{layout.Tests()}, layout.TestFiles())
+
+	require.NoError(t, os.WriteFile(layout.Benchmarks(), []byte("package problems\n"), 0644))
+	assert.Equal(t, []string{layout.Tests(), layout.Benchmarks()}, layout.TestFiles())
+
+	require.NoError(t, os.WriteFile(layout.Checks(), []byte("package problems\n"), 0644))
+	assert.Equal(t, []string{layout.Tests(), layout.Checks(), layout.Benchmarks()}, layout.TestFiles())
+}
+
+func TestFunctionName(t *testing.T) {
+	tests := []struct {
+		slug string
+		want string
+	}{
+		{"two-sum", "TwoSum"},
+		{"binary-search-tree", "BinarySearchTree"},
+		{"3sum", "3sum"},
+		{"arrays", "Arrays"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FunctionName(tt.slug), tt.slug)
+	}
+}
@@ -0,0 +1,254 @@
+// Package workspace locates the practice workspace: the directory holding the
+// .dsa-workspace marker, found by walking up from the current directory. Every
+// path to solutions, problems and workspace settings is built from its root so
+// commands work from any subdirectory, including when the workspace lives
+// inside a larger repository.
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/ak95asb/dsa-dojo/structures"
+)
+
+// Marker is the file that marks the root of a workspace
+const Marker = ".dsa-workspace"
+
+// DefaultModule is the module path of the go.mod created by Init
+const DefaultModule = "dsa-workspace"
+
+// Directories of a workspace, relative to its root
+const (
+	SolutionsDir  = "solutions"
+	ProblemsDir   = "problems"
+	SettingsDir   = ".dsa"       // Workspace config, templates and snippets
+	StructuresDir = "structures" // List, tree and graph helpers imported by tests
+)
+
+// testRequires are the go.mod requirements of the workspace tests: the
+// reference tests and the test templates assert with testify
+const testRequires = `require github.com/stretchr/testify v1.11.1
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+`
+
+// testSums are the go.sum checksums of testRequires
+const testSums = `github.com/davecgh/go-spew v1.1.1 h1:vj9j/u1bqnvCEfJOwUhtlOARqs3+rkHYY13jYWTU97c=
+github.com/davecgh/go-spew v1.1.1/go.mod h1:J7Y8YcW2NihsgmVo/mv3lAwl/skON4iLHjSsI+c5H38=
+github.com/pmezard/go-difflib v1.0.0 h1:4DBwDE0NGyQoBHbLQYPwSUPoCMWR5BEzIk/f1lZbAQM=
+github.com/pmezard/go-difflib v1.0.0/go.mod h1:iKH77koFhYxTK1pcRnkKkqfTogsbg7gZNVY4sRDYZ/4=
+github.com/stretchr/testify v1.11.1 h1:7s2iGBzp5EwR7/aIZr8ao5+dra3wiQyKjjFuvgVKu7U=
+github.com/stretchr/testify v1.11.1/go.mod h1:wZwfW3scLgRK+23gO65QZefKpKQRnfz6sD981Nm4B6U=
+gopkg.in/check.v1 v0.0.0-20161208181325-20d25e280405 h1:yhCVgyC4o1eVCa2tZl7eS0r+SDo693bJlVdllGtEeKM=
+gopkg.in/check.v1 v0.0.0-20161208181325-20d25e280405/go.mod h1:Co6ibVJAznAaIkqp8huTwlJQCZ016jof/cbN4VW5Yz0=
+gopkg.in/yaml.v3 v3.0.1 h1:fxVm/GzAzEWqLHuvctI91KS9hhNmmWOoWu0XTYJS7CA=
+gopkg.in/yaml.v3 v3.0.1/go.mod h1:K4uyk7z7BCEPqu6E+C64Yfv1cQ7kz7rIZviUmN+EgEM=
+`
+
+// modulePattern extracts the module path from go.mod
+var modulePattern = regexp.MustCompile(`(?m)^module\s+"?([^"\s]+)"?`)
+
+// goVersionPattern extracts the language version from runtime.Version
+var goVersionPattern = regexp.MustCompile(`^go(\d+\.\d+)`)
+
+// ErrNotFound is returned when no directory above the start has a marker
+var ErrNotFound = errors.New("no " + Marker + " found in this directory or any parent")
+
+// Find returns the absolute path of the nearest directory at or above dir
+// that contains the marker, or ErrNotFound
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	for {
+		if info, err := os.Stat(filepath.Join(dir, Marker)); err == nil && !info.IsDir() {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotFound
+		}
+		dir = parent
+	}
+}
+
+// Root returns the workspace root relative to the current directory: "." at
+// the root and e.g. ".." one level below it. Outside a workspace the current
+// directory is the root, as it was before workspaces had markers.
+func Root() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	root, err := Find(cwd)
+	if err != nil {
+		return "."
+	}
+	rel, err := filepath.Rel(cwd, root)
+	if err != nil {
+		return root
+	}
+	return rel
+}
+
+// Path joins path elements onto the workspace root
+func Path(elem ...string) string {
+	return filepath.Join(append([]string{Root()}, elem...)...)
+}
+
+// Solutions returns a path in the solutions directory
+func Solutions(elem ...string) string {
+	return Path(append([]string{SolutionsDir}, elem...)...)
+}
+
+// Problems returns a path in the problems directory
+func Problems(elem ...string) string {
+	return Path(append([]string{ProblemsDir}, elem...)...)
+}
+
+// Settings returns a path in the workspace settings directory, .dsa
+func Settings(elem ...string) string {
+	return Path(append([]string{SettingsDir}, elem...)...)
+}
+
+// Structures returns a path in the structures helper package
+func Structures(elem ...string) string {
+	return Path(append([]string{StructuresDir}, elem...)...)
+}
+
+// Module returns the path and directory of the module containing dir, read
+// from the nearest go.mod at or above it
+func Module(dir string) (string, string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			match := modulePattern.FindSubmatch(data)
+			if match == nil {
+				return "", "", fmt.Errorf("no module directive in %s", filepath.Join(dir, "go.mod"))
+			}
+			return string(match[1]), dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", fmt.Errorf("no go.mod found for %s; run 'dsa init'", dir)
+		}
+		dir = parent
+	}
+}
+
+// ImportPath returns the import path of the package in dir
+func ImportPath(dir string) (string, error) {
+	module, root, err := Module(dir)
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+	if rel == "." {
+		return module, nil
+	}
+	return module + "/" + filepath.ToSlash(rel), nil
+}
+
+// WriteStructures copies the structures helper package into dir, leaving
+// existing files alone. Returns the files created, relative to dir.
+func WriteStructures(dir string) ([]string, error) {
+	var created []string
+	for _, name := range structures.SourceFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		source, err := structures.Source.ReadFile(name)
+		if err != nil {
+			return created, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return created, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+		if err := os.WriteFile(path, source, 0644); err != nil {
+			return created, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		created = append(created, name)
+	}
+	return created, nil
+}
+
+// Init lays out a workspace in dir: the marker, a go.mod declaring module
+// (so solutions build as their own module inside a larger repository) with
+// the testify requirement of the tests and its go.sum, the solutions and
+// problems directories and the structures helper package.
+// Existing files are left alone. Returns what was created, relative to dir.
+func Init(dir, module string) ([]string, error) {
+	if module == "" {
+		module = DefaultModule
+	}
+
+	var created []string
+	for _, sub := range []string{SolutionsDir, ProblemsDir} {
+		path := filepath.Join(dir, sub)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return created, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		created = append(created, sub+string(filepath.Separator))
+	}
+
+	files := []struct{ name, content string }{
+		{"go.mod", fmt.Sprintf("module %s\n\ngo %s\n\n%s", module, goVersion(), testRequires)},
+		{"go.sum", testSums},
+		{Marker, "# Marks the root of a dsa workspace; dsa commands run from any subdirectory\n"},
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(file.content), 0644); err != nil {
+			return created, fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		created = append(created, file.name)
+	}
+
+	helpers, err := WriteStructures(filepath.Join(dir, StructuresDir))
+	for _, name := range helpers {
+		created = append(created, filepath.Join(StructuresDir, name))
+	}
+	if err != nil {
+		return created, err
+	}
+
+	return created, nil
+}
+
+// goVersion returns the language version for go.mod, e.g. "1.25"
+func goVersion() string {
+	if match := goVersionPattern.FindStringSubmatch(runtime.Version()); match != nil {
+		return match[1]
+	}
+	return "1.21"
+}
@@ -0,0 +1,145 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chdir changes to dir for the rest of the test
+func chdir(t *testing.T, dir string) {
+	originalDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { os.Chdir(originalDir) })
+}
+
+// newWorkspace creates a workspace with a nested subdirectory and returns
+// its resolved root
+func newWorkspace(t *testing.T) string {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	_, err = Init(root, "")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "solutions", "variants", "fast"), 0755))
+	return root
+}
+
+func TestFind(t *testing.T) {
+	root := newWorkspace(t)
+
+	found, err := Find(filepath.Join(root, "solutions", "variants", "fast"))
+	require.NoError(t, err)
+	assert.Equal(t, root, found)
+
+	found, err = Find(root)
+	require.NoError(t, err)
+	assert.Equal(t, root, found)
+
+	_, err = Find(t.TempDir())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFind_NestedInLargerRepository(t *testing.T) {
+	// A monorepo with its own go.mod holding the workspace in a subdirectory
+	repo, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "go.mod"), []byte("module example.com/monorepo\n"), 0644))
+	root := filepath.Join(repo, "practice", "dsa")
+	require.NoError(t, os.MkdirAll(root, 0755))
+	_, err = Init(root, "")
+	require.NoError(t, err)
+
+	found, err := Find(filepath.Join(root, "problems"))
+	require.NoError(t, err)
+	assert.Equal(t, root, found)
+}
+
+func TestRoot(t *testing.T) {
+	t.Run("relative to a subdirectory", func(t *testing.T) {
+		root := newWorkspace(t)
+		chdir(t, filepath.Join(root, "solutions", "variants"))
+
+		assert.Equal(t, filepath.Join("..", ".."), Root())
+		assert.Equal(t, filepath.Join("..", "..", "solutions", "two-sum.go"), Solutions("two-sum.go"))
+		assert.Equal(t, filepath.Join("..", "..", "problems", "templates"), Problems("templates"))
+		assert.Equal(t, filepath.Join("..", "..", ".dsa", "config.yaml"), Settings("config.yaml"))
+	})
+
+	t.Run("at the root", func(t *testing.T) {
+		chdir(t, newWorkspace(t))
+
+		assert.Equal(t, ".", Root())
+		assert.Equal(t, filepath.Join("solutions", "two-sum.go"), Solutions("two-sum.go"))
+	})
+
+	t.Run("falls back to the current directory", func(t *testing.T) {
+		chdir(t, t.TempDir())
+
+		assert.Equal(t, ".", Root())
+		assert.Equal(t, "problems", Problems())
+	})
+}
+
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+
+	created, err := Init(dir, "example.com/me/dsa")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"solutions" + string(filepath.Separator), "problems" + string(filepath.Separator), "go.mod", "go.sum", Marker,
+		filepath.Join("structures", "structures.go"), filepath.Join("structures", "notation.go"),
+		filepath.Join("structures", "compare.go"),
+	}, created)
+
+	goMod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+	assert.Regexp(t, `^module example\.com/me/dsa\n\ngo \d+\.\d+\n\nrequire github\.com/stretchr/testify v`, string(goMod))
+	goSum, err := os.ReadFile(filepath.Join(dir, "go.sum"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goSum), "github.com/stretchr/testify v1.11.1 h1:")
+
+	// A second run changes nothing
+	created, err = Init(dir, "other")
+	require.NoError(t, err)
+	assert.Empty(t, created)
+	goMod, err = os.ReadFile(filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(goMod), "example.com/me/dsa")
+}
+
+func TestModuleAndImportPath(t *testing.T) {
+	dir := t.TempDir()
+	_, err := Init(dir, "example.com/me/dsa")
+	require.NoError(t, err)
+
+	module, root, err := Module(filepath.Join(dir, "solutions"))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/me/dsa", module)
+	assert.Equal(t, dir, root)
+
+	path, err := ImportPath(filepath.Join(dir, "structures"))
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/me/dsa/structures", path)
+
+	path, err = ImportPath(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com/me/dsa", path)
+}
+
+func TestWriteStructures(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "structures")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "structures.go"), []byte("package structures // edited\n"), 0644))
+
+	created, err := WriteStructures(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"notation.go", "compare.go"}, created)
+
+	edited, err := os.ReadFile(filepath.Join(dir, "structures.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(edited), "edited", "existing files are left alone")
+}
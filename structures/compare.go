@@ -0,0 +1,157 @@
+package structures
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Comparison modes for Match. Tests use them for problems whose answers can
+// come in any order or differ by rounding.
+const (
+	Exact           = "exact"            // Same value, in LeetCode notation
+	Unordered       = "unordered"        // Same elements in any order
+	UnorderedNested = "unordered-nested" // Same elements in any order, at every level, e.g. group anagrams
+	Set             = "set"              // Same distinct elements, ignoring order and duplicates
+	FloatTolerance  = "float-tolerance"  // Floats within a tolerance, e.g. float-tolerance:1e-6
+)
+
+// ParseMode checks a comparison mode and returns its name and, for
+// float-tolerance, the tolerance
+func ParseMode(mode string) (string, float64, error) {
+	name, arg, hasArg := strings.Cut(mode, ":")
+	switch name {
+	case "", Exact, Unordered, UnorderedNested, Set:
+		if hasArg {
+			return "", 0, fmt.Errorf("comparison %q takes no argument", name)
+		}
+		if name == "" {
+			name = Exact
+		}
+		return name, 0, nil
+	case FloatTolerance:
+		if !hasArg {
+			return name, 1e-9, nil
+		}
+		tolerance, err := strconv.ParseFloat(arg, 64)
+		if err != nil || tolerance < 0 || math.IsNaN(tolerance) {
+			return "", 0, fmt.Errorf("invalid tolerance %q in %q", arg, mode)
+		}
+		return name, tolerance, nil
+	}
+	return "", 0, fmt.Errorf("unknown comparison %q (use %s, %s, %s, %s or %s:<tolerance>)",
+		mode, Exact, Unordered, UnorderedNested, Set, FloatTolerance)
+}
+
+// Match reports whether got matches want under a comparison mode. An invalid
+// mode never matches.
+func Match(mode string, want, got any) bool {
+	name, tolerance, err := ParseMode(mode)
+	if err != nil {
+		return false
+	}
+
+	switch name {
+	case Unordered:
+		return equalStrings(elementsOf(want), elementsOf(got))
+	case UnorderedNested:
+		return canonical(reflect.ValueOf(want)) == canonical(reflect.ValueOf(got))
+	case Set:
+		return equalStrings(distinct(elementsOf(want)), distinct(elementsOf(got)))
+	case FloatTolerance:
+		return within(reflect.ValueOf(want), reflect.ValueOf(got), tolerance)
+	default:
+		return Format(want) == Format(got)
+	}
+}
+
+// elementsOf formats the elements of a slice or array and sorts them. Any
+// other value is a single element.
+func elementsOf(v any) []string {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return []string{Format(v)}
+	}
+	elems := make([]string, rv.Len())
+	for i := range elems {
+		elems[i] = Format(rv.Index(i).Interface())
+	}
+	sort.Strings(elems)
+	return elems
+}
+
+// canonical formats a value with the elements of every slice sorted
+func canonical(v reflect.Value) string {
+	if !v.IsValid() {
+		return "null"
+	}
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && !isText(v.Type()) {
+		elems := make([]string, v.Len())
+		for i := range elems {
+			elems[i] = canonical(v.Index(i))
+		}
+		sort.Strings(elems)
+		return "[" + strings.Join(elems, ",") + "]"
+	}
+	return format(v)
+}
+
+// isText reports whether a slice type holds bytes, whose order is part of
+// the value
+func isText(t reflect.Type) bool {
+	return t.Elem().Kind() == reflect.Uint8
+}
+
+// distinct removes repeated strings from a sorted slice
+func distinct(sorted []string) []string {
+	var out []string
+	for i, s := range sorted {
+		if i == 0 || s != sorted[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// equalStrings reports whether two string slices are equal
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// within compares two values, allowing floats anywhere in them to differ by
+// up to tolerance
+func within(want, got reflect.Value, tolerance float64) bool {
+	if !want.IsValid() || !got.IsValid() {
+		return want.IsValid() == got.IsValid()
+	}
+
+	switch want.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if got.Kind() != reflect.Float32 && got.Kind() != reflect.Float64 {
+			return false
+		}
+		return math.Abs(want.Float()-got.Float()) <= tolerance
+	case reflect.Slice, reflect.Array:
+		if got.Kind() != want.Kind() || got.Len() != want.Len() {
+			return false
+		}
+		for i := 0; i < want.Len(); i++ {
+			if !within(want.Index(i), got.Index(i), tolerance) {
+				return false
+			}
+		}
+		return true
+	}
+	return format(want) == format(got)
+}
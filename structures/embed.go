@@ -0,0 +1,12 @@
+package structures
+
+import "embed"
+
+// Source holds this package's source, which 'dsa init' copies into new
+// workspaces so their tests can import it
+//
+//go:embed structures.go notation.go compare.go
+var Source embed.FS
+
+// SourceFiles lists the files in Source
+var SourceFiles = []string{"structures.go", "notation.go", "compare.go"}
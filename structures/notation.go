@@ -0,0 +1,348 @@
+package structures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Shape is the kind of data structure a type is shaped like
+type Shape int
+
+// Shapes recognised by Parse and Format
+const (
+	NoShape Shape = iota
+	List          // *T with fields Val and Next *T
+	Tree          // *T with fields Val, Left *T and Right *T
+	Graph         // *T with fields Val and Neighbors []*T
+)
+
+// ShapeOf returns the shape of a type, NoShape if it is not a pointer to a
+// list, tree or graph node
+func ShapeOf(t reflect.Type) Shape {
+	if t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return NoShape
+	}
+	node := t.Elem()
+	if _, ok := node.FieldByName("Val"); !ok {
+		return NoShape
+	}
+	field := func(name string, want reflect.Type) bool {
+		f, ok := node.FieldByName(name)
+		return ok && f.Type == want
+	}
+	switch {
+	case field("Next", t):
+		return List
+	case field("Left", t) && field("Right", t):
+		return Tree
+	case field("Neighbors", reflect.SliceOf(t)):
+		return Graph
+	}
+	return NoShape
+}
+
+// Parse builds a list, tree or graph of type T from its notation
+func Parse[T any](notation string) (T, error) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil {
+		return zero, fmt.Errorf("cannot parse into an interface type")
+	}
+
+	var node reflect.Value
+	var err error
+	switch ShapeOf(t) {
+	case List:
+		node, err = parseList(t, notation)
+	case Tree:
+		node, err = parseTree(t, notation)
+	case Graph:
+		node, err = parseGraph(t, notation)
+	default:
+		return zero, fmt.Errorf("%s is not a list, tree or graph node", t)
+	}
+	if err != nil {
+		return zero, fmt.Errorf("parse %s %q: %w", t, notation, err)
+	}
+	return node.Interface().(T), nil
+}
+
+// MustParse is like Parse but panics on invalid notation, for test tables
+func MustParse[T any](notation string) T {
+	node, err := Parse[T](notation)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// elements decodes a JSON array, keeping each element raw
+func elements(notation string) ([]json.RawMessage, error) {
+	var elems []json.RawMessage
+	if err := json.Unmarshal([]byte(notation), &elems); err != nil {
+		return nil, fmt.Errorf("expected a JSON array: %w", err)
+	}
+	return elems, nil
+}
+
+// isNull reports whether a raw JSON element is null
+func isNull(elem json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(elem), []byte("null"))
+}
+
+// newNode allocates a node of pointer type t holding the decoded value
+func newNode(t reflect.Type, elem json.RawMessage) (reflect.Value, error) {
+	node := reflect.New(t.Elem())
+	if err := json.Unmarshal(elem, node.Elem().FieldByName("Val").Addr().Interface()); err != nil {
+		return reflect.Value{}, fmt.Errorf("value %s: %w", elem, err)
+	}
+	return node, nil
+}
+
+func parseList(t reflect.Type, notation string) (reflect.Value, error) {
+	elems, err := elements(notation)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	head := reflect.Zero(t)
+	var tail reflect.Value
+	for i, elem := range elems {
+		if isNull(elem) {
+			return reflect.Value{}, fmt.Errorf("element %d: a list cannot hold null", i)
+		}
+		node, err := newNode(t, elem)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if tail.IsValid() {
+			tail.Elem().FieldByName("Next").Set(node)
+		} else {
+			head = node
+		}
+		tail = node
+	}
+	return head, nil
+}
+
+func parseTree(t reflect.Type, notation string) (reflect.Value, error) {
+	elems, err := elements(notation)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if len(elems) == 0 || isNull(elems[0]) {
+		return reflect.Zero(t), nil
+	}
+
+	root, err := newNode(t, elems[0])
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// Each node in the queue takes the next two elements as its children
+	queue := []reflect.Value{root}
+	next := 1
+	for len(queue) > 0 && next < len(elems) {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, side := range []string{"Left", "Right"} {
+			if next >= len(elems) {
+				break
+			}
+			elem := elems[next]
+			next++
+			if isNull(elem) {
+				continue
+			}
+			child, err := newNode(t, elem)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			parent.Elem().FieldByName(side).Set(child)
+			queue = append(queue, child)
+		}
+	}
+	if next < len(elems) {
+		return reflect.Value{}, fmt.Errorf("%d value(s) left over with no parent", len(elems)-next)
+	}
+	return root, nil
+}
+
+func parseGraph(t reflect.Type, notation string) (reflect.Value, error) {
+	var adjacency [][]int
+	if err := json.Unmarshal([]byte(notation), &adjacency); err != nil {
+		return reflect.Value{}, fmt.Errorf("expected adjacency lists such as [[2],[1]]: %w", err)
+	}
+	if len(adjacency) == 0 {
+		return reflect.Zero(t), nil
+	}
+
+	val, _ := t.Elem().FieldByName("Val")
+	nodes := make([]reflect.Value, len(adjacency))
+	for i := range nodes {
+		nodes[i] = reflect.New(t.Elem())
+		field := nodes[i].Elem().FieldByName("Val")
+		switch val.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			field.SetInt(int64(i + 1))
+		default:
+			return reflect.Value{}, fmt.Errorf("graph nodes need an integer Val, not %s", val.Type)
+		}
+	}
+
+	for i, neighbors := range adjacency {
+		list := reflect.MakeSlice(reflect.SliceOf(t), 0, len(neighbors))
+		for _, neighbor := range neighbors {
+			if neighbor < 1 || neighbor > len(nodes) {
+				return reflect.Value{}, fmt.Errorf("node %d: neighbor %d out of range 1..%d", i+1, neighbor, len(nodes))
+			}
+			list = reflect.Append(list, nodes[neighbor-1])
+		}
+		nodes[i].Elem().FieldByName("Neighbors").Set(list)
+	}
+	return nodes[0], nil
+}
+
+// Format writes a value in LeetCode notation: lists, trees and graphs as
+// described in the package documentation, slices of them element by element
+// and anything else as JSON
+func Format(v any) string {
+	if v == nil {
+		return "null"
+	}
+	return format(reflect.ValueOf(v))
+}
+
+func format(v reflect.Value) string {
+	switch ShapeOf(v.Type()) {
+	case List:
+		return formatList(v)
+	case Tree:
+		return formatTree(v)
+	case Graph:
+		return formatGraph(v)
+	}
+
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && hasShape(v.Type().Elem()) {
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return "null"
+		}
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = format(v.Index(i))
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	}
+
+	return formatJSON(v)
+}
+
+// hasShape reports whether a type is a list, tree or graph node, or a slice
+// or array of them
+func hasShape(t reflect.Type) bool {
+	if ShapeOf(t) != NoShape {
+		return true
+	}
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		return hasShape(t.Elem())
+	}
+	return false
+}
+
+// formatJSON writes a value as JSON, falling back to Go syntax
+func formatJSON(v reflect.Value) string {
+	if !v.CanInterface() {
+		return fmt.Sprintf("%v", v)
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprintf("%#v", v.Interface())
+	}
+	return string(data)
+}
+
+// formatList writes the values of a list; a cycle is shown as "..." after the
+// last node before it repeats
+func formatList(head reflect.Value) string {
+	var parts []string
+	seen := map[uintptr]bool{}
+	for node := head; !node.IsNil(); node = node.Elem().FieldByName("Next") {
+		if seen[node.Pointer()] {
+			parts = append(parts, "...")
+			break
+		}
+		seen[node.Pointer()] = true
+		parts = append(parts, formatJSON(node.Elem().FieldByName("Val")))
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// formatTree writes a tree in level order with trailing nulls trimmed
+func formatTree(root reflect.Value) string {
+	var parts []string
+	queue := []reflect.Value{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.IsNil() {
+			parts = append(parts, "null")
+			continue
+		}
+		parts = append(parts, formatJSON(node.Elem().FieldByName("Val")))
+		queue = append(queue, node.Elem().FieldByName("Left"), node.Elem().FieldByName("Right"))
+	}
+	for len(parts) > 0 && parts[len(parts)-1] == "null" {
+		parts = parts[:len(parts)-1]
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
+
+// formatGraph writes the adjacency lists of the nodes reachable from start,
+// ordered by Val
+func formatGraph(start reflect.Value) string {
+	if start.IsNil() {
+		return "[]"
+	}
+
+	var nodes []reflect.Value
+	seen := map[uintptr]bool{start.Pointer(): true}
+	queue := []reflect.Value{start}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		nodes = append(nodes, node)
+		neighbors := node.Elem().FieldByName("Neighbors")
+		for i := 0; i < neighbors.Len(); i++ {
+			neighbor := neighbors.Index(i)
+			if !neighbor.IsNil() && !seen[neighbor.Pointer()] {
+				seen[neighbor.Pointer()] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i].Elem().FieldByName("Val"), nodes[j].Elem().FieldByName("Val")
+		if a.CanInt() && b.CanInt() {
+			return a.Int() < b.Int()
+		}
+		return formatJSON(a) < formatJSON(b)
+	})
+
+	parts := make([]string, len(nodes))
+	for i, node := range nodes {
+		neighbors := node.Elem().FieldByName("Neighbors")
+		vals := make([]string, 0, neighbors.Len())
+		for j := 0; j < neighbors.Len(); j++ {
+			if neighbor := neighbors.Index(j); !neighbor.IsNil() {
+				vals = append(vals, formatJSON(neighbor.Elem().FieldByName("Val")))
+			}
+		}
+		parts[i] = "[" + strings.Join(vals, ",") + "]"
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
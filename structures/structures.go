@@ -0,0 +1,58 @@
+// Package structures provides the linked list, binary tree and graph types of
+// LeetCode-style problems and converts them to and from LeetCode's notation:
+//
+//	[1,2,3]                     linked list 1 -> 2 -> 3
+//	[3,9,20,null,null,15,7]     binary tree in level order, null for no child
+//	[[2,4],[1,3],[2,4],[1,3]]   graph as adjacency lists; node i has Val i+1
+//
+// 'dsa init' copies this package into the workspace, and tests generated by
+// 'dsa test-gen' build their inputs with MustParse and compare results with
+// Format, so failures read in the same notation. Parse and Format work on any
+// type shaped like the ones below, so a solution can use these types or
+// declare its own:
+//
+//	type ListNode struct { Val int; Next *ListNode }
+//	type TreeNode struct { Val int; Left, Right *TreeNode }
+//	type Node struct { Val int; Neighbors []*Node }
+package structures
+
+// ListNode is a node of a singly linked list
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+// TreeNode is a node of a binary tree
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+// Node is a node of an undirected graph
+type Node struct {
+	Val       int
+	Neighbors []*Node
+}
+
+// NewList builds a linked list from its values
+func NewList(vals ...int) *ListNode {
+	dummy := &ListNode{}
+	tail := dummy
+	for _, val := range vals {
+		tail.Next = &ListNode{Val: val}
+		tail = tail.Next
+	}
+	return dummy.Next
+}
+
+// NewTree builds a binary tree from its level-order notation
+func NewTree(notation string) *TreeNode {
+	return MustParse[*TreeNode](notation)
+}
+
+// NewGraph builds a graph from its adjacency lists and returns the node with
+// Val 1, or nil for an empty graph
+func NewGraph(notation string) *Node {
+	return MustParse[*Node](notation)
+}
@@ -0,0 +1,152 @@
+package structures
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Solutions often declare their own node types
+type customList struct {
+	Val  string
+	Next *customList
+}
+
+func TestShapeOf(t *testing.T) {
+	assert.Equal(t, List, ShapeOf(reflect.TypeOf(&ListNode{})))
+	assert.Equal(t, List, ShapeOf(reflect.TypeOf(&customList{})))
+	assert.Equal(t, Tree, ShapeOf(reflect.TypeOf(&TreeNode{})))
+	assert.Equal(t, Graph, ShapeOf(reflect.TypeOf(&Node{})))
+	assert.Equal(t, NoShape, ShapeOf(reflect.TypeOf(ListNode{})))
+	assert.Equal(t, NoShape, ShapeOf(reflect.TypeOf([]int{})))
+}
+
+func TestList(t *testing.T) {
+	head, err := Parse[*ListNode]("[1,2,3]")
+	require.NoError(t, err)
+	assert.Equal(t, NewList(1, 2, 3), head)
+	assert.Equal(t, "[1,2,3]", Format(head))
+
+	empty, err := Parse[*ListNode]("[]")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+	assert.Equal(t, "[]", Format(empty))
+
+	custom := MustParse[*customList](`["a","b"]`)
+	assert.Equal(t, "b", custom.Next.Val)
+	assert.Equal(t, `["a","b"]`, Format(custom))
+
+	cycle := NewList(3, 2, 0, -4)
+	cycle.Next.Next.Next.Next = cycle.Next
+	assert.Equal(t, "[3,2,0,-4,...]", Format(cycle))
+
+	_, err = Parse[*ListNode]("[1,null]")
+	assert.ErrorContains(t, err, "a list cannot hold null")
+	_, err = Parse[*ListNode](`[1,"x"]`)
+	assert.ErrorContains(t, err, `value "x"`)
+}
+
+func TestTree(t *testing.T) {
+	root := NewTree("[3,9,20,null,null,15,7]")
+	require.NotNil(t, root)
+	assert.Equal(t, 9, root.Left.Val)
+	assert.Nil(t, root.Left.Left)
+	assert.Equal(t, 15, root.Right.Left.Val)
+	assert.Equal(t, 7, root.Right.Right.Val)
+	assert.Equal(t, "[3,9,20,null,null,15,7]", Format(root))
+
+	assert.Equal(t, "[1,null,2,3]", Format(NewTree("[1,null,2,3]")))
+	assert.Nil(t, NewTree("[]"))
+	assert.Equal(t, "[]", Format((*TreeNode)(nil)))
+
+	_, err := Parse[*TreeNode]("[1,null,null,2]")
+	assert.ErrorContains(t, err, "1 value(s) left over with no parent")
+	assert.Panics(t, func() { NewTree("not json") })
+}
+
+func TestGraph(t *testing.T) {
+	node := NewGraph("[[2,4],[1,3],[2,4],[1,3]]")
+	require.NotNil(t, node)
+	assert.Equal(t, 1, node.Val)
+	assert.Equal(t, []int{2, 4}, []int{node.Neighbors[0].Val, node.Neighbors[1].Val})
+	assert.Same(t, node, node.Neighbors[0].Neighbors[0])
+	assert.Equal(t, "[[2,4],[1,3],[2,4],[1,3]]", Format(node))
+
+	assert.Nil(t, NewGraph("[]"))
+	assert.Equal(t, "[[]]", Format(NewGraph("[[]]")))
+
+	_, err := Parse[*Node]("[[2],[3]]")
+	assert.ErrorContains(t, err, "node 2: neighbor 3 out of range 1..2")
+}
+
+func TestFormat(t *testing.T) {
+	lists := []*ListNode{NewList(1, 4), nil, NewList(2)}
+	assert.Equal(t, "[[1,4],[],[2]]", Format(lists))
+	assert.Equal(t, "null", Format([]*ListNode(nil)))
+	assert.Equal(t, "[1,2]", Format([]int{1, 2}))
+	assert.Equal(t, "true", Format(true))
+	assert.Equal(t, "null", Format(nil))
+}
+
+func TestParse_NotAStructure(t *testing.T) {
+	_, err := Parse[[]int]("[1]")
+	assert.ErrorContains(t, err, "is not a list, tree or graph node")
+}
+
+func TestSource(t *testing.T) {
+	for _, name := range SourceFiles {
+		data, err := Source.ReadFile(name)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "package structures")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		mode      string
+		want, got any
+		match     bool
+	}{
+		{Exact, []int{1, 2}, []int{1, 2}, true},
+		{Exact, []int{1, 2}, []int{2, 1}, false},
+		{"", NewList(1, 2), NewList(1, 2), true},
+		{Unordered, []int{1, 2, 2}, []int{2, 1, 2}, true},
+		{Unordered, []int{1, 2, 2}, []int{2, 1, 1}, false},
+		{Unordered, [][]int{{1, 2}, {3}}, [][]int{{3}, {1, 2}}, true},
+		{Unordered, [][]int{{1, 2}, {3}}, [][]int{{3}, {2, 1}}, false},
+		{UnorderedNested, [][]string{{"eat", "tea"}, {"bat"}}, [][]string{{"bat"}, {"tea", "eat"}}, true},
+		{UnorderedNested, [][]string{{"eat", "tea"}, {"bat"}}, [][]string{{"bat", "tea"}, {"eat"}}, false},
+		{UnorderedNested, [][]byte{[]byte("ab")}, [][]byte{[]byte("ba")}, false},
+		{Set, []int{1, 1, 2}, []int{2, 1}, true},
+		{Set, []int{1, 3}, []int{1, 2}, false},
+		{"float-tolerance:1e-6", 0.3, 0.1 + 0.2, true},
+		{"float-tolerance:1e-6", []float64{1, 2.5}, []float64{1.0000001, 2.5}, true},
+		{"float-tolerance:1e-6", []float64{1, 2.5}, []float64{1.1, 2.5}, false},
+		{FloatTolerance, 0.3, 0.1 + 0.2, true},
+		{"bogus", 1, 1, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, Match(tt.mode, tt.want, tt.got), "Match(%q, %v, %v)", tt.mode, tt.want, tt.got)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	name, tolerance, err := ParseMode("float-tolerance:1e-6")
+	require.NoError(t, err)
+	assert.Equal(t, FloatTolerance, name)
+	assert.Equal(t, 1e-6, tolerance)
+
+	name, _, err = ParseMode("")
+	require.NoError(t, err)
+	assert.Equal(t, Exact, name)
+
+	_, _, err = ParseMode("float-tolerance:abc")
+	assert.ErrorContains(t, err, `invalid tolerance "abc"`)
+	_, _, err = ParseMode("set:1")
+	assert.ErrorContains(t, err, "takes no argument")
+	_, _, err = ParseMode("sorted")
+	assert.ErrorContains(t, err, `unknown comparison "sorted"`)
+}